@@ -0,0 +1,366 @@
+package loggergo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/wasilak/loggergo/lib/types"
+)
+
+// redactedValue replaces secrets in the configuration served by the admin handler.
+const redactedValue = "[REDACTED]"
+
+// adminHandler serves the level, component levels and configuration of a logger over HTTP.
+type adminHandler struct {
+	logger func() *Logger
+	mux    *http.ServeMux
+}
+
+// adminReverts holds the temporary overrides made on a Logger through its admin handlers.
+// It is kept on the Logger, so that all admin handlers of a logger share it.
+type adminReverts struct {
+	mu         sync.Mutex
+	level      *pendingRevert
+	components *pendingRevert
+}
+
+// pendingRevert is a temporary override waiting to be reverted. It is only reverted if the
+// value is still the one applied by the override.
+type pendingRevert struct {
+	timer         *time.Timer
+	at            time.Time
+	level         slog.Level            // level to restore, for the level endpoint
+	levels        map[string]slog.Level // levels to restore, for the components endpoint
+	applied       slog.Level            // level set by the override
+	appliedLevels map[string]slog.Level // levels set by the override
+}
+
+// clearReverts cancels the pending reverts of the logger, e.g. when its configuration is
+// reloaded and the overridden values are replaced.
+func (l *Logger) clearReverts() {
+	l.reverts.mu.Lock()
+	defer l.reverts.mu.Unlock()
+	for _, revert := range []*pendingRevert{l.reverts.level, l.reverts.components} {
+		if revert != nil {
+			revert.timer.Stop()
+		}
+	}
+	l.reverts.level = nil
+	l.reverts.components = nil
+}
+
+// levelResponse is the body of the level endpoint.
+type levelResponse struct {
+	Level    slog.Level  `json:"level"`
+	RevertAt *time.Time  `json:"revert_at,omitempty"`
+	RevertTo *slog.Level `json:"revert_to,omitempty"`
+}
+
+// componentsResponse is the body of the components endpoint.
+type componentsResponse struct {
+	Levels   map[string]slog.Level `json:"levels"`
+	RevertAt *time.Time            `json:"revert_at,omitempty"`
+	RevertTo map[string]slog.Level `json:"revert_to,omitempty"`
+}
+
+// healthResponse is the body of the health endpoint.
+type healthResponse struct {
+	Status   string           `json:"status"` // "ok", or "degraded" if any OTEL output has failed over
+	Failover []FailoverStatus `json:"failover"`
+}
+
+// AdminHandler returns an http.Handler for viewing and changing the levels of the logger
+// created by Init or WatchConfig at runtime.
+//
+// Endpoints:
+//   - GET /level: the current level, e.g. {"level": "INFO"}
+//   - PUT /level: sets the level from a body such as {"level": "debug"}
+//   - GET /components: the component level map, e.g. {"levels": {"db": "DEBUG"}}
+//   - PUT /components: replaces the component level map from a body such as {"levels": {"db": "debug"}}
+//   - GET /config: the effective configuration, with secrets such as Redaction.HashKey redacted
+//   - GET /health: the state of the OTEL outputs with Config.Failover, e.g. {"status": "degraded", "failover": [{"output": "otel", "state": "open", ...}]}
+//
+// PUT requests accept a ?duration=10m parameter, which reverts the change after the given
+// duration. The response then includes "revert_at" and "revert_to". A later PUT to the same
+// endpoint cancels the pending revert; if it is temporary as well, it reverts to the value
+// from before the first override. A revert is skipped if the value has been changed by other
+// means since the override, and pending reverts are canceled when WatchConfig reloads the
+// configuration.
+//
+// Paths are relative to the handler, so mount it with http.StripPrefix. The handler does no
+// authentication, so it should only be exposed on an internal or protected listener.
+//
+// Example:
+//
+//	mux := http.NewServeMux()
+//	mux.Handle("/debug/logging/", http.StripPrefix("/debug/logging", loggergo.AdminHandler()))
+//
+//	// curl -X PUT -d '{"level":"debug"}' 'localhost:6060/debug/logging/level?duration=10m'
+func AdminHandler() http.Handler {
+	return newAdminHandler(globalLogger.Load)
+}
+
+// AdminHandler returns an http.Handler for viewing and changing the levels of this logger
+// at runtime. See the package-level AdminHandler for the endpoints.
+func (l *Logger) AdminHandler() http.Handler {
+	return newAdminHandler(func() *Logger { return l })
+}
+
+// newAdminHandler creates an admin handler for the logger returned by logger at request time.
+func newAdminHandler(logger func() *Logger) *adminHandler {
+	h := &adminHandler{logger: logger, mux: http.NewServeMux()}
+	h.mux.HandleFunc("GET /level", h.getLevel)
+	h.mux.HandleFunc("PUT /level", h.putLevel)
+	h.mux.HandleFunc("GET /components", h.getComponents)
+	h.mux.HandleFunc("PUT /components", h.putComponents)
+	h.mux.HandleFunc("GET /config", h.getConfig)
+	h.mux.HandleFunc("GET /health", h.getHealth)
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *adminHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.logger() == nil {
+		writeAdminError(w, http.StatusServiceUnavailable, errors.New("logger is not initialized"))
+		return
+	}
+	h.mux.ServeHTTP(w, r)
+}
+
+// getLevel serves the current level.
+func (h *adminHandler) getLevel(w http.ResponseWriter, r *http.Request) {
+	logger := h.logger()
+	logger.reverts.mu.Lock()
+	defer logger.reverts.mu.Unlock()
+	writeAdminJSON(w, h.levelResponse(logger))
+}
+
+// putLevel sets the level, optionally for a limited duration.
+func (h *adminHandler) putLevel(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Level string `json:"level"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeAdminError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	level, err := types.ParseLogLevel(body.Level)
+	if err != nil {
+		writeAdminError(w, http.StatusBadRequest, err)
+		return
+	}
+	duration, err := parseAdminDuration(r)
+	if err != nil {
+		writeAdminError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	logger := h.logger()
+	levelVar := logger.GetLogLevelAccessor()
+
+	reverts := &logger.reverts
+	reverts.mu.Lock()
+	defer reverts.mu.Unlock()
+
+	previous := levelVar.Level()
+	if reverts.level != nil {
+		reverts.level.timer.Stop()
+		previous = reverts.level.level
+		reverts.level = nil
+	}
+
+	levelVar.Set(level)
+	if duration > 0 {
+		revert := &pendingRevert{at: time.Now().Add(duration), level: previous, applied: level}
+		revert.timer = time.AfterFunc(duration, func() {
+			reverts.mu.Lock()
+			defer reverts.mu.Unlock()
+			if reverts.level == revert {
+				if levelVar.Level() == revert.applied {
+					levelVar.Set(revert.level)
+				}
+				reverts.level = nil
+			}
+		})
+		reverts.level = revert
+	}
+
+	writeAdminJSON(w, h.levelResponse(logger))
+}
+
+// levelResponse describes the current level. Must be called with the logger's reverts locked.
+func (h *adminHandler) levelResponse(logger *Logger) levelResponse {
+	response := levelResponse{Level: logger.GetLogLevelAccessor().Level()}
+	if revert := logger.reverts.level; revert != nil {
+		response.RevertAt = &revert.at
+		response.RevertTo = &revert.level
+	}
+	return response
+}
+
+// getComponents serves the component level map.
+func (h *adminHandler) getComponents(w http.ResponseWriter, r *http.Request) {
+	logger := h.logger()
+	logger.reverts.mu.Lock()
+	defer logger.reverts.mu.Unlock()
+	writeAdminJSON(w, h.componentsResponse(logger))
+}
+
+// putComponents replaces the component level map, optionally for a limited duration.
+func (h *adminHandler) putComponents(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Levels map[string]string `json:"levels"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeAdminError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	logger := h.logger()
+
+	levels := make(map[string]slog.Level, len(body.Levels))
+	for name, value := range body.Levels {
+		level, err := types.ParseLogLevel(value)
+		if err != nil {
+			writeAdminError(w, http.StatusBadRequest, fmt.Errorf("component %q: %w", name, err))
+			return
+		}
+		levels[name] = level
+	}
+
+	// Validate the names the same way as Config.ComponentLevels
+	cfg := logger.GetConfig()
+	cfg.ComponentLevels = levels
+	if err := cfg.Validate(); err != nil {
+		writeAdminError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	duration, err := parseAdminDuration(r)
+	if err != nil {
+		writeAdminError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	reverts := &logger.reverts
+	reverts.mu.Lock()
+	defer reverts.mu.Unlock()
+
+	previous := logger.GetComponentLevels()
+	if reverts.components != nil {
+		reverts.components.timer.Stop()
+		previous = reverts.components.levels
+		reverts.components = nil
+	}
+
+	logger.SetComponentLevels(levels)
+	if duration > 0 {
+		revert := &pendingRevert{at: time.Now().Add(duration), levels: previous, appliedLevels: logger.GetComponentLevels()}
+		revert.timer = time.AfterFunc(duration, func() {
+			reverts.mu.Lock()
+			defer reverts.mu.Unlock()
+			if reverts.components == revert {
+				if maps.Equal(logger.GetComponentLevels(), revert.appliedLevels) {
+					logger.SetComponentLevels(revert.levels)
+				}
+				reverts.components = nil
+			}
+		})
+		reverts.components = revert
+	}
+
+	writeAdminJSON(w, h.componentsResponse(logger))
+}
+
+// componentsResponse describes the component level map. Must be called with the logger's
+// reverts locked.
+func (h *adminHandler) componentsResponse(logger *Logger) componentsResponse {
+	response := componentsResponse{Levels: logger.GetComponentLevels()}
+	if response.Levels == nil {
+		response.Levels = map[string]slog.Level{}
+	}
+	if revert := logger.reverts.components; revert != nil {
+		response.RevertAt = &revert.at
+		response.RevertTo = maps.Clone(revert.levels)
+		if response.RevertTo == nil {
+			response.RevertTo = map[string]slog.Level{}
+		}
+	}
+	return response
+}
+
+// getConfig serves the effective configuration with secrets redacted.
+func (h *adminHandler) getConfig(w http.ResponseWriter, r *http.Request) {
+	logger := h.logger()
+
+	cfg := logger.GetConfig()
+	cfg.Level = logger.GetLogLevelAccessor().Level()
+	cfg.ComponentLevels = logger.GetComponentLevels()
+
+	writeAdminJSON(w, sanitizeConfig(cfg))
+}
+
+// getHealth serves the failover state of the OTEL outputs. The status code is always 200,
+// as a degraded logger still writes records to the console.
+func (h *adminHandler) getHealth(w http.ResponseWriter, r *http.Request) {
+	response := healthResponse{Status: "ok", Failover: h.logger().Health()}
+	for _, status := range response.Failover {
+		if status.State != types.BreakerClosed {
+			response.Status = "degraded"
+		}
+	}
+	writeAdminJSON(w, response)
+}
+
+// sanitizeConfig returns a copy of the configuration with secrets replaced by redactedValue.
+func sanitizeConfig(cfg types.Config) types.Config {
+	if cfg.Redaction.HashKey != "" {
+		cfg.Redaction.HashKey = redactedValue
+	}
+	// OTLP headers usually carry credentials; the map is copied so the shared configuration is not modified
+	if len(cfg.OTLP.Headers) > 0 {
+		headers := make(map[string]string, len(cfg.OTLP.Headers))
+		for name := range cfg.OTLP.Headers {
+			headers[name] = redactedValue
+		}
+		cfg.OTLP.Headers = headers
+	}
+	return cfg
+}
+
+// parseAdminDuration parses the optional duration parameter of a PUT request.
+func parseAdminDuration(r *http.Request) (time.Duration, error) {
+	value := r.URL.Query().Get("duration")
+	if value == "" {
+		return 0, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("invalid duration %q: expected a positive duration such as 10m", value)
+	}
+	return duration, nil
+}
+
+// writeAdminJSON writes v as a JSON response.
+func writeAdminJSON(w http.ResponseWriter, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		writeAdminError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(append(data, '\n'))
+}
+
+// writeAdminError writes err as a JSON error response with the given status.
+func writeAdminError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
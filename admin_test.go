@@ -0,0 +1,227 @@
+package loggergo
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newAdminTestLogger creates an independent logger for the admin handler tests
+func newAdminTestLogger(t *testing.T) *Logger {
+	t.Helper()
+	logger, err := New(context.Background(), Config{
+		Level:           slog.LevelInfo,
+		OutputStream:    &syncBuffer{},
+		SetAsDefault:    false,
+		ComponentLevels: map[string]slog.Level{"db": slog.LevelWarn},
+		Redaction:       RedactionConfig{HashKey: "secret", Rules: []RedactionRule{{Keys: []string{"token"}, Strategy: Types.RedactHash}}},
+		OTLP:            OTLPConfig{Headers: map[string]string{"Authorization": "Bearer secret"}},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { logger.Shutdown() })
+	return logger
+}
+
+// serveAdmin sends a request to the handler and decodes the JSON response into v
+func serveAdmin(t *testing.T, handler http.Handler, method, target, body string, v any) int {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(method, target, strings.NewReader(body)))
+	if v != nil {
+		if err := json.Unmarshal(recorder.Body.Bytes(), v); err != nil {
+			t.Fatalf("Invalid JSON response %q: %v", recorder.Body.String(), err)
+		}
+	}
+	return recorder.Code
+}
+
+// TestAdminHandler_Level tests reading and changing the level
+func TestAdminHandler_Level(t *testing.T) {
+	logger := newAdminTestLogger(t)
+	handler := logger.AdminHandler()
+
+	var response levelResponse
+	if code := serveAdmin(t, handler, http.MethodGet, "/level", "", &response); code != http.StatusOK || response.Level != slog.LevelInfo {
+		t.Fatalf("Expected INFO, got %d %+v", code, response)
+	}
+
+	if code := serveAdmin(t, handler, http.MethodPut, "/level", `{"level":"debug"}`, &response); code != http.StatusOK || response.Level != slog.LevelDebug || response.RevertAt != nil {
+		t.Fatalf("Expected permanent DEBUG, got %d %+v", code, response)
+	}
+	if logger.GetLogLevelAccessor().Level() != slog.LevelDebug {
+		t.Error("Expected the level variable to be changed")
+	}
+
+	var errResponse map[string]string
+	if code := serveAdmin(t, handler, http.MethodPut, "/level", `{"level":"loud"}`, &errResponse); code != http.StatusBadRequest || errResponse["error"] == "" {
+		t.Errorf("Expected 400 with error for invalid level, got %d %v", code, errResponse)
+	}
+	if code := serveAdmin(t, handler, http.MethodPut, "/level?duration=soon", `{"level":"warn"}`, nil); code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for invalid duration, got %d", code)
+	}
+	if code := serveAdmin(t, handler, http.MethodPost, "/level", `{"level":"warn"}`, nil); code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for POST, got %d", code)
+	}
+	if logger.GetLogLevelAccessor().Level() != slog.LevelDebug {
+		t.Error("Expected rejected requests to leave the level unchanged")
+	}
+}
+
+// TestAdminHandler_TemporaryLevel tests that a level set with a duration reverts
+func TestAdminHandler_TemporaryLevel(t *testing.T) {
+	logger := newAdminTestLogger(t)
+	handler := logger.AdminHandler()
+
+	var response levelResponse
+	serveAdmin(t, handler, http.MethodPut, "/level?duration=1h", `{"level":"debug"}`, &response)
+	if response.RevertAt == nil || response.RevertTo == nil || *response.RevertTo != slog.LevelInfo {
+		t.Fatalf("Expected revert to INFO, got %+v", response)
+	}
+
+	// A second temporary override reverts to the level from before the first one
+	serveAdmin(t, handler, http.MethodPut, "/level?duration=20ms", `{"level":"warn"}`, &response)
+	if response.Level != slog.LevelWarn || *response.RevertTo != slog.LevelInfo {
+		t.Fatalf("Expected WARN reverting to INFO, got %+v", response)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for logger.GetLogLevelAccessor().Level() != slog.LevelInfo {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the level to revert")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	var reverted levelResponse
+	serveAdmin(t, handler, http.MethodGet, "/level", "", &reverted)
+	if reverted.RevertAt != nil {
+		t.Errorf("Expected no pending revert, got %+v", reverted)
+	}
+}
+
+// TestAdminHandler_Components tests reading and temporarily changing component levels
+func TestAdminHandler_Components(t *testing.T) {
+	logger := newAdminTestLogger(t)
+	handler := logger.AdminHandler()
+
+	var response componentsResponse
+	serveAdmin(t, handler, http.MethodGet, "/components", "", &response)
+	if response.Levels["db"] != slog.LevelWarn {
+		t.Fatalf("Expected db at WARN, got %+v", response)
+	}
+
+	if code := serveAdmin(t, handler, http.MethodPut, "/components", `{"levels":{"db..pool":"debug"}}`, nil); code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for invalid component name, got %d", code)
+	}
+
+	serveAdmin(t, handler, http.MethodPut, "/components?duration=20ms", `{"levels":{"db":"debug","http":"error"}}`, &response)
+	if response.Levels["db"] != slog.LevelDebug || response.RevertTo["db"] != slog.LevelWarn {
+		t.Fatalf("Expected db at DEBUG reverting to WARN, got %+v", response)
+	}
+	if !logger.Named("db").Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("Expected debug to be enabled for db")
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for logger.GetComponentLevels()["db"] != slog.LevelWarn {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the component levels to revert")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if _, ok := logger.GetComponentLevels()["http"]; ok {
+		t.Error("Expected the whole map to be reverted")
+	}
+}
+
+// TestAdminHandler_RevertKeepsLaterChanges tests that a revert is skipped once the value has been changed by other means
+func TestAdminHandler_RevertKeepsLaterChanges(t *testing.T) {
+	logger := newAdminTestLogger(t)
+	handler := logger.AdminHandler()
+
+	serveAdmin(t, handler, http.MethodPut, "/level?duration=20ms", `{"level":"debug"}`, nil)
+	serveAdmin(t, handler, http.MethodPut, "/components?duration=20ms", `{"levels":{"db":"debug"}}`, nil)
+	logger.GetLogLevelAccessor().Set(slog.LevelError)
+	logger.SetComponentLevels(map[string]slog.Level{"db": slog.LevelError})
+
+	var level levelResponse
+	var components componentsResponse
+	deadline := time.Now().Add(5 * time.Second)
+	for level.RevertAt != nil || components.RevertAt != nil || level.Level == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the pending reverts to expire")
+		}
+		time.Sleep(5 * time.Millisecond)
+		level, components = levelResponse{}, componentsResponse{}
+		serveAdmin(t, handler, http.MethodGet, "/level", "", &level)
+		serveAdmin(t, handler, http.MethodGet, "/components", "", &components)
+	}
+
+	if level.Level != slog.LevelError || components.Levels["db"] != slog.LevelError {
+		t.Errorf("Expected the later changes to be kept, got %+v and %+v", level, components)
+	}
+}
+
+// TestAdminHandler_SharedReverts tests that the admin handlers of a logger share its pending reverts
+func TestAdminHandler_SharedReverts(t *testing.T) {
+	logger := newAdminTestLogger(t)
+	first, second := logger.AdminHandler(), logger.AdminHandler()
+
+	serveAdmin(t, first, http.MethodPut, "/level?duration=1h", `{"level":"debug"}`, nil)
+
+	var response levelResponse
+	serveAdmin(t, second, http.MethodGet, "/level", "", &response)
+	if response.RevertAt == nil || *response.RevertTo != slog.LevelInfo {
+		t.Fatalf("Expected the pending revert to be visible through another handler, got %+v", response)
+	}
+
+	var changed, current levelResponse
+	serveAdmin(t, second, http.MethodPut, "/level", `{"level":"warn"}`, &changed)
+	if changed.RevertAt != nil {
+		t.Errorf("Expected the permanent change to cancel the pending revert, got %+v", changed)
+	}
+	serveAdmin(t, first, http.MethodGet, "/level", "", &current)
+	if current.Level != slog.LevelWarn || current.RevertAt != nil {
+		t.Errorf("Expected WARN without pending revert, got %+v", current)
+	}
+}
+
+// TestAdminHandler_Config tests that the effective configuration is served without secrets
+func TestAdminHandler_Config(t *testing.T) {
+	logger := newAdminTestLogger(t)
+	logger.GetLogLevelAccessor().Set(slog.LevelError)
+
+	recorder := httptest.NewRecorder()
+	logger.AdminHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/config", nil))
+
+	body := recorder.Body.String()
+	if recorder.Code != http.StatusOK || recorder.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("Expected JSON response, got %d %s", recorder.Code, recorder.Header().Get("Content-Type"))
+	}
+	if strings.Contains(body, "secret") || !strings.Contains(body, `"hash_key":"[REDACTED]"`) {
+		t.Errorf("Expected hash key to be redacted, got %s", body)
+	}
+	if !strings.Contains(body, `"Authorization":"[REDACTED]"`) {
+		t.Errorf("Expected OTLP headers to be redacted, got %s", body)
+	}
+	if !strings.Contains(body, `"level":"error"`) {
+		t.Errorf("Expected the effective level, got %s", body)
+	}
+}
+
+// TestAdminHandler_NotInitialized tests the package-level handler before Init
+func TestAdminHandler_NotInitialized(t *testing.T) {
+	previous := globalLogger.Swap(nil)
+	defer globalLogger.Store(previous)
+
+	if code := serveAdmin(t, AdminHandler(), http.MethodGet, "/level", "", nil); code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 before Init, got %d", code)
+	}
+}
@@ -0,0 +1,199 @@
+package loggergo
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/wasilak/loggergo/lib/types"
+)
+
+// defaultAsyncQueueSize is the queue size used when AsyncConfig.QueueSize is zero.
+const defaultAsyncQueueSize = 1024
+
+// asyncItem is a queued record together with the handler that must write it.
+type asyncItem struct {
+	ctx     context.Context
+	record  slog.Record
+	handler slog.Handler
+}
+
+// asyncCore is the queue and worker pool shared by an AsyncHandler and the handlers derived from it.
+type asyncCore struct {
+	config  types.AsyncConfig
+	queue   chan asyncItem
+	dropped atomic.Uint64
+
+	mu     sync.RWMutex
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// AsyncHandler wraps an existing slog.Handler and writes records on background goroutines.
+//
+// Handle puts records on a bounded queue and returns immediately; Workers goroutines take
+// them off the queue and pass them to the wrapped handler. When the queue is full, the
+// configured OverflowPolicy decides whether the caller waits or a record is dropped.
+// Dropped records are counted and reported by Dropped.
+//
+// Close stops accepting records, drains the queue and waits for the workers. Records logged
+// after Close are written synchronously.
+//
+// Thread Safety:
+//
+// AsyncHandler is safe for concurrent use. Handlers returned by WithAttrs and WithGroup
+// share the queue, workers and dropped count of the handler they were derived from.
+type AsyncHandler struct {
+	innerHandler slog.Handler
+	core         *asyncCore
+}
+
+// NewAsyncHandler creates a new AsyncHandler wrapping the given handler and starts its workers.
+//
+// Example:
+//
+//	handler := loggergo.NewAsyncHandler(slog.NewJSONHandler(os.Stdout, nil), loggergo.AsyncConfig{
+//	    Enabled:        true,
+//	    QueueSize:      4096,
+//	    OverflowPolicy: loggergo.Types.OverflowDropOldest,
+//	})
+//	defer handler.Close()
+//	logger := slog.New(handler)
+func NewAsyncHandler(handler slog.Handler, config types.AsyncConfig) *AsyncHandler {
+	if config.QueueSize <= 0 {
+		config.QueueSize = defaultAsyncQueueSize
+	}
+	if config.Workers <= 0 {
+		config.Workers = 1
+	}
+	if config.OverflowPolicy == (types.OverflowPolicy{}) {
+		config.OverflowPolicy = types.OverflowBlock
+	}
+
+	core := &asyncCore{
+		config: config,
+		queue:  make(chan asyncItem, config.QueueSize),
+	}
+
+	core.wg.Add(config.Workers)
+	for i := 0; i < config.Workers; i++ {
+		go core.work()
+	}
+
+	return &AsyncHandler{innerHandler: handler, core: core}
+}
+
+// Enabled reports whether the handler handles records at the given level.
+// It delegates the check to the inner handler.
+func (h *AsyncHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.innerHandler.Enabled(ctx, level)
+}
+
+// Handle queues the record for writing, applying the overflow policy if the queue is full.
+// It returns an error only when the record is written synchronously after Close.
+func (h *AsyncHandler) Handle(ctx context.Context, record slog.Record) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	h.core.mu.RLock()
+	defer h.core.mu.RUnlock()
+
+	if h.core.closed {
+		return h.innerHandler.Handle(ctx, record)
+	}
+
+	// The record outlives the call, so it must not share state with the caller or be cancelled with its context
+	item := asyncItem{
+		ctx:     context.WithoutCancel(ctx),
+		record:  record.Clone(),
+		handler: h.innerHandler,
+	}
+
+	h.core.enqueue(item)
+	return nil
+}
+
+// WithAttrs returns a new handler with the given attributes added.
+// The new handler shares the queue and workers of h.
+func (h *AsyncHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &AsyncHandler{innerHandler: h.innerHandler.WithAttrs(attrs), core: h.core}
+}
+
+// WithGroup returns a new handler with the given group name.
+// The new handler shares the queue and workers of h.
+func (h *AsyncHandler) WithGroup(name string) slog.Handler {
+	return &AsyncHandler{innerHandler: h.innerHandler.WithGroup(name), core: h.core}
+}
+
+// Dropped returns the number of records dropped because the queue was full.
+func (h *AsyncHandler) Dropped() uint64 {
+	return h.core.dropped.Load()
+}
+
+// Close stops accepting records, writes all queued records and waits for the workers to exit.
+// Calling Close more than once is safe.
+func (h *AsyncHandler) Close() error {
+	h.core.mu.Lock()
+	if h.core.closed {
+		h.core.mu.Unlock()
+		return nil
+	}
+	h.core.closed = true
+	close(h.core.queue)
+	h.core.mu.Unlock()
+
+	h.core.wg.Wait()
+	return nil
+}
+
+// enqueue puts the item on the queue according to the overflow policy. Must be called with mu read-locked.
+func (c *asyncCore) enqueue(item asyncItem) {
+	switch c.config.OverflowPolicy {
+	case types.OverflowDropNewest:
+		select {
+		case c.queue <- item:
+		default:
+			c.dropped.Add(1)
+		}
+	case types.OverflowDropOldest:
+		for {
+			select {
+			case c.queue <- item:
+				return
+			default:
+			}
+			// Make room by discarding the oldest record; another worker may have freed a slot meanwhile
+			select {
+			case <-c.queue:
+				c.dropped.Add(1)
+			default:
+			}
+		}
+	case types.OverflowDropBelowLevel:
+		if item.record.Level < c.config.DropLevel {
+			select {
+			case c.queue <- item:
+			default:
+				c.dropped.Add(1)
+			}
+			return
+		}
+		c.queue <- item
+	default:
+		c.queue <- item
+	}
+}
+
+// work writes queued records until the queue is closed and empty.
+func (c *asyncCore) work() {
+	defer c.wg.Done()
+	for item := range c.queue {
+		if err := item.handler.Handle(item.ctx, item.record); err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: asynchronous log write failed: %v\n", err)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package loggergo
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/wasilak/loggergo/lib/types"
+)
+
+// blockingHandler is a test handler that records messages and can be paused
+type blockingHandler struct {
+	mu       sync.Mutex
+	messages []string
+	release  chan struct{}
+	started  chan struct{}
+	once     sync.Once
+}
+
+func newBlockingHandler() *blockingHandler {
+	return &blockingHandler{release: make(chan struct{}), started: make(chan struct{})}
+}
+
+func (h *blockingHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *blockingHandler) Handle(_ context.Context, r slog.Record) error {
+	h.once.Do(func() { close(h.started) })
+	<-h.release
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.messages = append(h.messages, r.Message)
+	return nil
+}
+
+func (h *blockingHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+func (h *blockingHandler) WithGroup(string) slog.Handler      { return h }
+
+func (h *blockingHandler) got() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]string{}, h.messages...)
+}
+
+// TestAsyncHandler_DrainsOnClose tests that all queued records are written in order by Close
+func TestAsyncHandler_DrainsOnClose(t *testing.T) {
+	var buf bytes.Buffer
+	handler := NewAsyncHandler(slog.NewTextHandler(&buf, nil), types.AsyncConfig{Enabled: true, QueueSize: 10})
+	logger := slog.New(handler).With("component", "test")
+
+	for i := 0; i < 100; i++ {
+		logger.Info("message", "i", i)
+	}
+
+	if err := handler.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 100 {
+		t.Fatalf("Expected 100 records after Close, got %d", len(lines))
+	}
+	if !strings.Contains(lines[0], "component=test i=0") || !strings.Contains(lines[99], "i=99") {
+		t.Errorf("Expected records in order with attributes, got first %q and last %q", lines[0], lines[99])
+	}
+	if handler.Dropped() != 0 {
+		t.Errorf("Expected no dropped records with block policy, got %d", handler.Dropped())
+	}
+
+	// Records logged after Close are written synchronously
+	logger.Info("after close")
+	if !strings.Contains(buf.String(), "after close") {
+		t.Error("Expected record logged after Close to be written")
+	}
+}
+
+// TestAsyncHandler_OverflowPolicies tests which records are kept when the queue is full
+func TestAsyncHandler_OverflowPolicies(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  types.AsyncConfig
+		levels  []slog.Level
+		want    []string
+		dropped uint64
+	}{
+		{
+			name:    "drop newest",
+			config:  types.AsyncConfig{QueueSize: 2, OverflowPolicy: types.OverflowDropNewest},
+			levels:  []slog.Level{slog.LevelInfo, slog.LevelInfo, slog.LevelInfo, slog.LevelInfo},
+			want:    []string{"m0", "m1", "m2"},
+			dropped: 2,
+		},
+		{
+			name:    "drop oldest",
+			config:  types.AsyncConfig{QueueSize: 2, OverflowPolicy: types.OverflowDropOldest},
+			levels:  []slog.Level{slog.LevelInfo, slog.LevelInfo, slog.LevelInfo, slog.LevelInfo},
+			want:    []string{"m0", "m3", "m4"},
+			dropped: 2,
+		},
+		{
+			name:    "drop below level",
+			config:  types.AsyncConfig{QueueSize: 2, OverflowPolicy: types.OverflowDropBelowLevel, DropLevel: slog.LevelWarn},
+			levels:  []slog.Level{slog.LevelInfo, slog.LevelInfo, slog.LevelDebug, slog.LevelInfo},
+			want:    []string{"m0", "m1", "m2"},
+			dropped: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := newBlockingHandler()
+			handler := NewAsyncHandler(inner, tt.config)
+			logger := slog.New(handler)
+
+			// The first record is taken by the worker, which then blocks; the next two fill the queue
+			logger.Info("m0")
+			<-inner.started
+			for i, level := range tt.levels {
+				logger.Log(context.Background(), level, "m"+string(rune('1'+i)))
+			}
+
+			if handler.Dropped() != tt.dropped {
+				t.Errorf("Expected %d dropped records, got %d", tt.dropped, handler.Dropped())
+			}
+
+			close(inner.release)
+			handler.Close()
+
+			got := inner.got()
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Expected records %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestAsyncHandler_DropBelowLevelBlocksForImportantRecords tests that records at or above DropLevel are never dropped
+func TestAsyncHandler_DropBelowLevelBlocksForImportantRecords(t *testing.T) {
+	inner := newBlockingHandler()
+	handler := NewAsyncHandler(inner, types.AsyncConfig{QueueSize: 1, OverflowPolicy: types.OverflowDropBelowLevel, DropLevel: slog.LevelWarn})
+	logger := slog.New(handler)
+
+	logger.Info("m0")
+	<-inner.started
+	logger.Info("m1")
+
+	done := make(chan struct{})
+	go func() {
+		logger.Error("important")
+		close(done)
+	}()
+
+	close(inner.release)
+	<-done
+	handler.Close()
+
+	got := inner.got()
+	if len(got) != 3 || got[2] != "important" {
+		t.Errorf("Expected the error record to be written, got %v", got)
+	}
+	if handler.Dropped() != 0 {
+		t.Errorf("Expected no dropped records, got %d", handler.Dropped())
+	}
+}
+
+// TestAsyncHandler_ConcurrentLogging tests concurrent logging with several workers
+func TestAsyncHandler_ConcurrentLogging(t *testing.T) {
+	inner := newBlockingHandler()
+	close(inner.release)
+	handler := NewAsyncHandler(inner, types.AsyncConfig{QueueSize: 16, Workers: 4})
+	logger := slog.New(handler)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				logger.Info("message")
+			}
+		}()
+	}
+	wg.Wait()
+	handler.Close()
+
+	if got := len(inner.got()); got != 1000 {
+		t.Errorf("Expected 1000 records, got %d", got)
+	}
+}
+
+// TestInit_AsyncDrainsOnShutdown tests that Init wraps the output in an AsyncHandler drained by Shutdown
+func TestInit_AsyncDrainsOnShutdown(t *testing.T) {
+	var buf syncBuffer
+
+	_, logger, err := Init(context.Background(), types.Config{
+		Level:        slog.LevelInfo,
+		Output:       types.OutputConsole,
+		OutputStream: &buf,
+		Async:        types.AsyncConfig{Enabled: true, QueueSize: 8},
+	})
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	for i := 0; i < 50; i++ {
+		logger.Info("async message")
+	}
+
+	if err := Shutdown(); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	if got := strings.Count(buf.String(), "async message"); got != 50 {
+		t.Errorf("Expected 50 records after Shutdown, got %d", got)
+	}
+	if DroppedRecords() != 0 {
+		t.Errorf("Expected no dropped records, got %d", DroppedRecords())
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent use
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
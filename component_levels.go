@@ -0,0 +1,210 @@
+package loggergo
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"math"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// ComponentAttributeKey is the attribute key under which Named adds the component name.
+const ComponentAttributeKey = "component"
+
+// useDefaultLevel marks a componentLeveler without a matching entry in the level map.
+const useDefaultLevel = math.MinInt64
+
+// componentLeveler is the slog.Leveler of one component. Its level is resolved when the level
+// map changes, so Level is a single atomic load (two when it falls back to the default level).
+type componentLeveler struct {
+	defaultLevel *slog.LevelVar
+	level        atomic.Int64
+}
+
+// Level returns the component's effective level.
+func (c *componentLeveler) Level() slog.Level {
+	level := c.level.Load()
+	if level == useDefaultLevel {
+		return c.defaultLevel.Level()
+	}
+	return slog.Level(level)
+}
+
+// componentLevels resolves the levels of named loggers from a hierarchical level map.
+//
+// It is also the slog.Leveler of the handler chain below the component handlers. Its Level is
+// the lowest level any component can have, so that the chain doesn't filter out records a
+// component has enabled.
+type componentLevels struct {
+	defaultLevel *slog.LevelVar
+
+	mu         sync.Mutex
+	levels     map[string]slog.Level
+	components map[string]*componentLeveler
+
+	minLevel atomic.Int64 // lowest level in levels, or math.MaxInt64 if empty
+}
+
+// newComponentLevels creates the level resolver of a logger, falling back to defaultLevel.
+func newComponentLevels(defaultLevel *slog.LevelVar, levels map[string]slog.Level) *componentLevels {
+	c := &componentLevels{
+		defaultLevel: defaultLevel,
+		components:   make(map[string]*componentLeveler),
+	}
+	c.set(levels)
+	return c
+}
+
+// Level returns the lowest of the default level and the levels in the map.
+func (c *componentLevels) Level() slog.Level {
+	return min(c.defaultLevel.Level(), slog.Level(c.minLevel.Load()))
+}
+
+// leveler returns the leveler of the named component, creating it on first use.
+// The empty name is the root logger, which only matches "*".
+func (c *componentLevels) leveler(name string) *componentLeveler {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if l, ok := c.components[name]; ok {
+		return l
+	}
+
+	l := &componentLeveler{defaultLevel: c.defaultLevel}
+	l.level.Store(c.resolve(name))
+	c.components[name] = l
+	return l
+}
+
+// set replaces the level map and re-resolves the levels of all existing components.
+func (c *componentLevels) set(levels map[string]slog.Level) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.levels = maps.Clone(levels)
+
+	minLevel := int64(math.MaxInt64)
+	for _, level := range c.levels {
+		minLevel = min(minLevel, int64(level))
+	}
+	c.minLevel.Store(minLevel)
+
+	for name, l := range c.components {
+		l.level.Store(c.resolve(name))
+	}
+}
+
+// get returns a copy of the level map.
+func (c *componentLevels) get() map[string]slog.Level {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return maps.Clone(c.levels)
+}
+
+// resolve returns the level of the longest matching prefix of name ("db.pool.conn", "db.pool", "db"),
+// then "*", or useDefaultLevel. Must be called with mu locked.
+func (c *componentLevels) resolve(name string) int64 {
+	for name != "" {
+		if level, ok := c.levels[name]; ok {
+			return int64(level)
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+	if level, ok := c.levels["*"]; ok {
+		return int64(level)
+	}
+	return useDefaultLevel
+}
+
+// componentHandler filters records by the level of the component its logger belongs to.
+type componentHandler struct {
+	innerHandler slog.Handler
+	level        slog.Leveler
+}
+
+// Enabled reports whether the level is enabled for the component and by the inner handler.
+func (h *componentHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level.Level() && h.innerHandler.Enabled(ctx, level)
+}
+
+// Handle passes the record to the inner handler.
+func (h *componentHandler) Handle(ctx context.Context, record slog.Record) error {
+	return h.innerHandler.Handle(ctx, record)
+}
+
+// WithAttrs returns a new handler with the given attributes added.
+func (h *componentHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &componentHandler{innerHandler: h.innerHandler.WithAttrs(attrs), level: h.level}
+}
+
+// WithGroup returns a new handler with the given group name.
+func (h *componentHandler) WithGroup(name string) slog.Handler {
+	return &componentHandler{innerHandler: h.innerHandler.WithGroup(name), level: h.level}
+}
+
+// Named returns a child logger for the named component.
+//
+// Its level is resolved from Config.ComponentLevels by the longest matching dot-separated
+// prefix of name, then the "*" entry, then the logger's level. For example, with
+// "db=debug, db.pool=warn, *=info", Named("db.pool.conn") logs at Warn, Named("db.query")
+// at Debug and Named("http") at Info. The root logger itself only matches "*".
+//
+// Every record of the child logger has a "component" attribute set to name.
+//
+// Example:
+//
+//	dbLogger := logger.Named("db.pool")
+//	dbLogger.Debug("connection acquired", "wait", wait)
+func (l *Logger) Named(name string) *slog.Logger {
+	handler := l.handler.WithAttrs([]slog.Attr{slog.String(ComponentAttributeKey, name)})
+	return slog.New(&componentHandler{innerHandler: handler, level: l.levels.leveler(name)})
+}
+
+// SetComponentLevels replaces the component level map at runtime.
+// Existing loggers returned by Named pick up the new levels immediately.
+func (l *Logger) SetComponentLevels(levels map[string]slog.Level) {
+	l.levels.set(levels)
+}
+
+// GetComponentLevels returns a copy of the current component level map.
+func (l *Logger) GetComponentLevels() map[string]slog.Level {
+	return l.levels.get()
+}
+
+// Named returns a child logger for the named component of the logger created by Init.
+// See Logger.Named for how its level is resolved.
+//
+// If Init has not been called, it returns slog.Default() with the component attribute added.
+//
+// Example:
+//
+//	loggergo.SetComponentLevels(map[string]slog.Level{"db": slog.LevelDebug, "db.pool": slog.LevelWarn})
+//	poolLogger := loggergo.Named("db.pool")
+func Named(name string) *slog.Logger {
+	if logger := globalLogger.Load(); logger != nil {
+		return logger.Named(name)
+	}
+	return slog.Default().With(ComponentAttributeKey, name)
+}
+
+// SetComponentLevels replaces the component level map of the logger created by Init.
+// It does nothing if Init has not been called.
+func SetComponentLevels(levels map[string]slog.Level) {
+	if logger := globalLogger.Load(); logger != nil {
+		logger.SetComponentLevels(levels)
+	}
+}
+
+// GetComponentLevels returns a copy of the component level map of the logger created by Init.
+func GetComponentLevels() map[string]slog.Level {
+	if logger := globalLogger.Load(); logger != nil {
+		return logger.GetComponentLevels()
+	}
+	return nil
+}
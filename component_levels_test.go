@@ -0,0 +1,170 @@
+package loggergo
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/wasilak/loggergo/lib/types"
+)
+
+// newComponentTestLogger creates an independent logger writing text records to the returned buffer
+func newComponentTestLogger(t *testing.T, levels map[string]slog.Level) (*Logger, *bytes.Buffer) {
+	t.Helper()
+	var buf bytes.Buffer
+	logger, err := New(context.Background(), types.Config{
+		Level:              slog.LevelInfo,
+		Format:             types.LogFormatText,
+		Output:             types.OutputConsole,
+		OutputStream:       &buf,
+		OtelTracingEnabled: false,
+		ComponentLevels:    levels,
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { logger.Shutdown() })
+	return logger, &buf
+}
+
+// TestLogger_Named_HierarchicalLevels tests that levels resolve by the longest matching prefix
+func TestLogger_Named_HierarchicalLevels(t *testing.T) {
+	logger, _ := newComponentTestLogger(t, map[string]slog.Level{
+		"db":      slog.LevelDebug,
+		"db.pool": slog.LevelWarn,
+		"*":       slog.LevelError,
+	})
+
+	tests := []struct {
+		name  string
+		level slog.Level
+	}{
+		{name: "db", level: slog.LevelDebug},
+		{name: "db.query", level: slog.LevelDebug},
+		{name: "db.pool", level: slog.LevelWarn},
+		{name: "db.pool.conn", level: slog.LevelWarn},
+		{name: "dbx", level: slog.LevelError},
+		{name: "http", level: slog.LevelError},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			named := logger.Named(tt.name)
+			if !named.Enabled(ctx, tt.level) || named.Enabled(ctx, tt.level-1) {
+				t.Errorf("Expected effective level %s for %q", tt.level, tt.name)
+			}
+		})
+	}
+
+	// The root logger only matches "*"
+	if logger.Enabled(ctx, slog.LevelWarn) {
+		t.Error("Expected root logger to use the \"*\" level")
+	}
+}
+
+// TestLogger_Named_AddsComponentAttribute tests that records carry the component name
+func TestLogger_Named_AddsComponentAttribute(t *testing.T) {
+	logger, buf := newComponentTestLogger(t, map[string]slog.Level{"db": slog.LevelDebug})
+
+	logger.Named("db.pool").Debug("connection acquired", "conn", 1)
+	logger.Debug("root debug")
+
+	output := buf.String()
+	if !strings.Contains(output, "component=db.pool") || !strings.Contains(output, "connection acquired") {
+		t.Errorf("Expected debug record with component attribute, got %q", output)
+	}
+	if strings.Contains(output, "root debug") {
+		t.Error("Expected root logger to keep the Info level")
+	}
+}
+
+// TestLogger_SetComponentLevels tests that level changes apply to existing named loggers
+func TestLogger_SetComponentLevels(t *testing.T) {
+	logger, _ := newComponentTestLogger(t, nil)
+	ctx := context.Background()
+
+	db := logger.Named("db")
+	if db.Enabled(ctx, slog.LevelDebug) {
+		t.Fatal("Expected Debug to be disabled without component levels")
+	}
+
+	logger.SetComponentLevels(map[string]slog.Level{"db": slog.LevelDebug})
+	if !db.Enabled(ctx, slog.LevelDebug) {
+		t.Error("Expected Debug to be enabled after SetComponentLevels")
+	}
+	if got := logger.GetComponentLevels(); got["db"] != slog.LevelDebug {
+		t.Errorf("Expected GetComponentLevels to return the new map, got %v", got)
+	}
+
+	// Components without an entry follow the logger level
+	http := logger.Named("http")
+	logger.GetLogLevelAccessor().Set(slog.LevelError)
+	if http.Enabled(ctx, slog.LevelWarn) {
+		t.Error("Expected component without entry to follow the logger level")
+	}
+
+	logger.SetComponentLevels(nil)
+	if db.Enabled(ctx, slog.LevelWarn) {
+		t.Error("Expected component to fall back to the logger level after removing its entry")
+	}
+}
+
+// TestLogger_Named_Concurrent tests concurrent logging and level changes
+func TestLogger_Named_Concurrent(t *testing.T) {
+	logger, _ := newComponentTestLogger(t, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				logger.Named("db.pool").Debug("message")
+			}
+		}()
+		go func(i int) {
+			defer wg.Done()
+			level := slog.LevelDebug
+			if i%2 == 0 {
+				level = slog.LevelWarn
+			}
+			logger.SetComponentLevels(map[string]slog.Level{"db": level})
+		}(i)
+	}
+	wg.Wait()
+}
+
+// TestLogger_OtelFormatFollowsLevels tests that LogFormatOtel writes records enabled by component levels and runtime level changes
+func TestLogger_OtelFormatFollowsLevels(t *testing.T) {
+	out := &syncBuffer{}
+	logger, err := New(context.Background(), types.Config{
+		Level:           slog.LevelInfo,
+		Format:          types.LogFormatOtel,
+		OutputStream:    out,
+		SetAsDefault:    false,
+		ComponentLevels: map[string]slog.Level{"db": slog.LevelDebug},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer logger.Shutdown()
+
+	logger.Named("db").Debug("db debug")
+	logger.Debug("root debug before")
+	logger.GetLogLevelAccessor().Set(slog.LevelDebug)
+	logger.Debug("root debug after")
+
+	output := out.String()
+	for _, want := range []string{"db debug", "root debug after"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in the output, got %q", want, output)
+		}
+	}
+	if strings.Contains(output, "root debug before") {
+		t.Errorf("Expected Debug to be dropped before lowering the level, got %q", output)
+	}
+}
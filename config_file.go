@@ -0,0 +1,87 @@
+package loggergo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/wasilak/loggergo/lib"
+	"github.com/wasilak/loggergo/lib/types"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadConfigFile reads a JSON (.json) or YAML (.yaml, .yml) configuration file and returns
+// the default configuration with the file's settings applied.
+//
+// Keys are the json tags of Config (e.g. "level", "otel_service_name", "syslog"). Levels and
+// enums are strings, durations are strings such as "24h", and output_stream is "stdout",
+// "stderr" (case-insensitive) or a file path. The file is opened when the logger is set up,
+// so a path that cannot be opened fails Init, New or the reload, not loading the file.
+//
+// Unknown keys, invalid values and configurations that fail Config.Validate are reported as
+// a *ValidationError with one FieldError per problem and its field path (e.g. "Syslog.Format").
+//
+// Example config.yaml:
+//
+//	level: debug
+//	format: json
+//	output: file
+//	file:
+//	  path: /var/log/myapp/app.log
+//	  max_size: 104857600
+//	  rotation_interval: 24h
+//	component_levels:
+//	  db: debug
+//	  "*": info
+//
+// Example:
+//
+//	config, err := loggergo.LoadConfigFile("config.yaml")
+//	if err != nil {
+//	    log.Fatalf("Invalid logger configuration: %v", err)
+//	}
+//	ctx, logger, err := loggergo.Init(ctx, config)
+func LoadConfigFile(path string) (types.Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return lib.DefaultConfig(), fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	return parseConfigFile(path, data)
+}
+
+// parseConfigFile applies the contents of a configuration file to the defaults.
+// The format is chosen by the extension of path.
+func parseConfigFile(path string, data []byte) (types.Config, error) {
+	config := lib.DefaultConfig()
+	var err error
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+	case ".yaml", ".yml":
+		var node yaml.Node
+		if err := yaml.Unmarshal(data, &node); err != nil {
+			return config, fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+		if len(node.Content) == 0 {
+			// An empty document leaves the defaults
+			return config, nil
+		}
+		if data, err = types.YAMLToJSON(node.Content[0]); err != nil {
+			return config, fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+	default:
+		return config, fmt.Errorf("unsupported config file extension %q: expected .json, .yaml or .yml", filepath.Ext(path))
+	}
+
+	if err := types.DecodeConfig(data, &config); err != nil {
+		return config, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return config, err
+	}
+
+	return config, nil
+}
@@ -0,0 +1,123 @@
+package loggergo
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/wasilak/loggergo/lib/types"
+)
+
+// writeConfigFile writes content to a file with the given name in a temporary directory
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	return path
+}
+
+// TestNew_UnopenableOutputStream tests that an output_stream file that cannot be opened fails
+// the logger setup instead of losing every record
+func TestNew_UnopenableOutputStream(t *testing.T) {
+	blocker := writeConfigFile(t, "blocker", "")
+	config, err := LoadConfigFile(writeConfigFile(t, "config.yaml", "set_as_default: false\noutput_stream: "+filepath.Join(blocker, "app.log")+"\n"))
+	if err != nil {
+		t.Fatalf("LoadConfigFile failed: %v", err)
+	}
+
+	_, err = New(context.Background(), config)
+	var initErr *types.InitError
+	if !errors.As(err, &initErr) || initErr.Stage != "handler_creation" {
+		t.Errorf("Expected a handler_creation error, got %v", err)
+	}
+}
+
+// TestLoadConfigFile tests that JSON and YAML files are applied on top of the defaults
+func TestLoadConfigFile(t *testing.T) {
+	files := map[string]string{
+		"config.json": `{
+			"level": "debug",
+			"output": "file",
+			"file": {"path": "/var/log/app.log", "rotation_interval": "24h"},
+			"component_levels": {"db": "warn"}
+		}`,
+		"config.yml": `
+level: debug
+output: file
+file:
+  path: /var/log/app.log
+  rotation_interval: 24h
+component_levels:
+  db: warn
+`,
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			config, err := LoadConfigFile(writeConfigFile(t, name, content))
+			if err != nil {
+				t.Fatalf("LoadConfigFile failed: %v", err)
+			}
+
+			if config.Level != slog.LevelDebug || config.Output != types.OutputFile {
+				t.Errorf("Expected debug level and file output, got %v, %v", config.Level, config.Output)
+			}
+			if config.File.Path != "/var/log/app.log" || config.File.RotationInterval != 24*time.Hour {
+				t.Errorf("Unexpected file config: %+v", config.File)
+			}
+			if config.ComponentLevels["db"] != slog.LevelWarn {
+				t.Errorf("Expected db component at warn, got %v", config.ComponentLevels)
+			}
+
+			// Settings absent from the file keep their defaults
+			if config.Format != types.LogFormatJSON || config.OtelServiceName != "my-service" || config.OutputStream != os.Stdout {
+				t.Errorf("Expected defaults to be kept, got %v, %q, %v", config.Format, config.OtelServiceName, config.OutputStream)
+			}
+		})
+	}
+}
+
+// TestLoadConfigFile_Errors tests that invalid files are reported with useful errors
+func TestLoadConfigFile_Errors(t *testing.T) {
+	if _, err := LoadConfigFile(filepath.Join(t.TempDir(), "missing.yaml")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected not-exist error, got %v", err)
+	}
+
+	if _, err := LoadConfigFile(writeConfigFile(t, "config.toml", `level = "debug"`)); err == nil {
+		t.Error("Expected error for unsupported extension")
+	}
+
+	if _, err := LoadConfigFile(writeConfigFile(t, "config.yaml", "level: [debug")); err == nil {
+		t.Error("Expected error for malformed YAML")
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		field   string
+	}{
+		{"unknown field", "sylog:\n  address: localhost:514\n", "sylog"},
+		{"invalid enum", "syslog:\n  format: rfc1\n", "Syslog.Format"},
+		{"failed validation", "output: file\n", "File.Path"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadConfigFile(writeConfigFile(t, "config.yaml", tt.content))
+
+			var valErr *types.ValidationError
+			if !errors.As(err, &valErr) {
+				t.Fatalf("Expected *ValidationError, got %v", err)
+			}
+			if valErr.Errors[0].Field != tt.field {
+				t.Errorf("Expected error for %s, got %v", tt.field, valErr.Errors)
+			}
+		})
+	}
+}
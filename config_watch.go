@@ -0,0 +1,407 @@
+package loggergo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/wasilak/loggergo/lib"
+	"github.com/wasilak/loggergo/lib/types"
+)
+
+// configWatchInterval is how often WatchConfig checks the configuration file for changes.
+var configWatchInterval = time.Second
+
+// handlerSlot holds the handler chain of one configuration generation.
+type handlerSlot struct {
+	handler slog.Handler // nil while records are held for the next generation
+
+	mu      sync.RWMutex // held for reading by the Handle calls in flight on the slot
+	retired bool         // set once the slot has been swapped out and drained
+
+	pendingMu sync.Mutex
+	pending   []pendingRecord // records held while handler is nil
+}
+
+// pendingRecord is a record held by a slot without handler chain, with the handler it was
+// passed to, so that it can be handled with the same attributes and groups by the next chain.
+type pendingRecord struct {
+	handler *swapHandler
+	ctx     context.Context
+	record  slog.Record
+}
+
+// retire waits for the Handle calls in flight on the slot to return. Later calls use the slot
+// swapped in instead, so retire must be called after the swap.
+func (s *handlerSlot) retire() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.retired = true
+}
+
+// swapCache is a derived handler resolved against a particular generation.
+type swapCache struct {
+	slot    *handlerSlot
+	handler slog.Handler
+}
+
+// swapHandler passes records to the handler chain of the current configuration generation.
+//
+// Handlers derived with WithAttrs and WithGroup replay their attributes and groups on top of
+// the current chain, so loggers created before a reload keep their attributes after it.
+// The derived chain is cached until the next swap.
+type swapHandler struct {
+	current *atomic.Pointer[handlerSlot]
+
+	parent *swapHandler
+	attrs  []slog.Attr
+	group  string
+
+	cache atomic.Pointer[swapCache]
+}
+
+// newSwapHandler creates a swapHandler passing records to handler until the next swap.
+func newSwapHandler(handler slog.Handler) *swapHandler {
+	h := &swapHandler{current: new(atomic.Pointer[handlerSlot])}
+	h.swap(handler)
+	return h
+}
+
+// swap atomically replaces the handler chain of h and of all handlers derived from it.
+// It returns the slot of the previous chain, which is still used by the Handle calls in flight
+// until it is retired.
+func (h *swapHandler) swap(handler slog.Handler) *handlerSlot {
+	return h.current.Swap(&handlerSlot{handler: handler})
+}
+
+// hold swaps in a slot without handler chain, which keeps the records passed to h in memory
+// until it is released, and returns it. It is used while no chain can handle records.
+func (h *swapHandler) hold() *handlerSlot {
+	slot := &handlerSlot{}
+	h.current.Swap(slot).retire()
+	return slot
+}
+
+// release retires a slot returned by hold, after the next chain has been swapped in, and passes
+// the records it holds to that chain.
+func (h *swapHandler) release(slot *handlerSlot) {
+	slot.retire()
+	current := h.current.Load()
+	for _, pending := range slot.pending {
+		pending.handler.resolveFor(current).Handle(pending.ctx, pending.record)
+	}
+}
+
+// resolve returns the handler of the current generation with h's attributes and groups applied.
+func (h *swapHandler) resolve() slog.Handler {
+	return h.resolveFor(h.current.Load())
+}
+
+// resolveFor returns the handler of the given generation with h's attributes and groups applied.
+func (h *swapHandler) resolveFor(slot *handlerSlot) slog.Handler {
+	if h.parent == nil {
+		return slot.handler
+	}
+	if cached := h.cache.Load(); cached != nil && cached.slot == slot {
+		return cached.handler
+	}
+
+	handler := h.parent.resolveFor(slot)
+	if h.group != "" {
+		handler = handler.WithGroup(h.group)
+	} else {
+		handler = handler.WithAttrs(h.attrs)
+	}
+
+	h.cache.Store(&swapCache{slot: slot, handler: handler})
+	return handler
+}
+
+// Enabled reports whether the current handler chain handles records at the given level.
+// Records are always enabled while they are held for the next chain.
+func (h *swapHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	slot := h.current.Load()
+	if slot.handler == nil {
+		return true
+	}
+	return h.resolveFor(slot).Enabled(ctx, level)
+}
+
+// Handle passes the record to the current handler chain, or holds it until the next chain is
+// swapped in. A call racing with a swap uses the new chain once the previous one is retired.
+func (h *swapHandler) Handle(ctx context.Context, record slog.Record) error {
+	for {
+		slot := h.current.Load()
+		slot.mu.RLock()
+		if slot.retired {
+			slot.mu.RUnlock()
+			continue
+		}
+		defer slot.mu.RUnlock()
+
+		if slot.handler == nil {
+			slot.pendingMu.Lock()
+			defer slot.pendingMu.Unlock()
+			slot.pending = append(slot.pending, pendingRecord{handler: h, ctx: ctx, record: record.Clone()})
+			return nil
+		}
+		return h.resolveFor(slot).Handle(ctx, record)
+	}
+}
+
+// WithAttrs returns a new handler with the given attributes added.
+func (h *swapHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	return &swapHandler{current: h.current, parent: h, attrs: attrs}
+}
+
+// WithGroup returns a new handler with the given group name.
+func (h *swapHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &swapHandler{current: h.current, parent: h, group: name}
+}
+
+// configWatcher reloads the configuration of a watched logger when its file changes.
+type configWatcher struct {
+	path   string
+	logger *Logger
+	swap   *swapHandler
+
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu      sync.Mutex
+	ctx     context.Context
+	manager *lib.ConfigManager // resources of the current generation
+	data    []byte             // file contents last applied or rejected
+	readErr string             // last read error, reported once
+}
+
+// WatchConfig initializes the global logger from a configuration file and reloads it whenever
+// the file changes, until ctx is done or Shutdown is called.
+//
+// The file is read with LoadConfigFile and checked for changes every second. All settings are
+// reloaded, including the level, component levels, redaction rules, context keys, format and
+// output: a new handler chain is built and atomically swapped in behind the returned logger,
+// then, once the records in flight on the previous chain are handled, its resources (files,
+// OTEL providers, async queues) are released. A spool directory (see SpoolConfig) cannot be
+// used by two chains, so when the new chain spools to the directory of the previous one, the
+// previous chain is released first and records logged meanwhile are held in memory until the
+// new chain is swapped in. Loggers derived from the returned logger with With, WithGroup or
+// Named follow the reloads.
+//
+// If a reload fails (unreadable file, invalid configuration, output that cannot be opened),
+// the previous configuration is kept and a warning is logged through the logger itself.
+//
+// The returned logger becomes the logger of the package-level functions (Named,
+// SetComponentLevels, DroppedRecords, ...) and is set as slog.Default() if the file's
+// configuration has set_as_default enabled when the watch starts.
+//
+// Example:
+//
+//	logger, err := loggergo.WatchConfig(ctx, "/etc/myapp/logging.yaml")
+//	if err != nil {
+//	    log.Fatalf("Failed to initialize logger: %v", err)
+//	}
+//	defer loggergo.Shutdown()
+//	logger.Info("Logger initialized, edit logging.yaml to change it")
+func WatchConfig(ctx context.Context, path string) (*slog.Logger, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	cfg, err := parseConfigFile(path, data)
+	if err != nil {
+		return nil, err
+	}
+
+	manager := lib.NewConfigManager()
+	manager.SetConfig(cfg)
+
+	levels := newComponentLevels(logLevel, nil)
+	ctx, handler, state, err := buildHandler(ctx, manager, levels)
+	if err != nil {
+		return nil, err
+	}
+
+	logLevel.Set(cfg.Level.Level())
+	levels.set(cfg.ComponentLevels)
+	lib.SetConfig(cfg)
+
+	swap := newSwapHandler(handler)
+	logger := &Logger{
+		Logger:   slog.New(&componentHandler{innerHandler: swap, level: levels.leveler("")}),
+		manager:  lib.GlobalConfigManager(),
+		logLevel: logLevel,
+		levels:   levels,
+		handler:  swap,
+	}
+	logger.state.Store(state)
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	w := &configWatcher{
+		path:    path,
+		logger:  logger,
+		swap:    swap,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+		ctx:     watchCtx,
+		manager: manager,
+		data:    data,
+	}
+	lib.RegisterCleanup(w.close)
+
+	if cfg.SetAsDefault {
+		slog.SetDefault(logger.Logger)
+	}
+	globalLogger.Store(logger)
+
+	go w.watch()
+
+	return logger.Logger, nil
+}
+
+// watch polls the file until the watcher's context is done.
+func (w *configWatcher) watch() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(configWatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+			w.reload()
+		}
+	}
+}
+
+// reload applies the file if its contents changed since the last check.
+func (w *configWatcher) reload() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	data, err := os.ReadFile(w.path)
+	if err != nil {
+		// Editors often replace files by renaming, so a missing file is reported only once
+		if err.Error() != w.readErr {
+			w.readErr = err.Error()
+			w.logger.Warn("failed to read logging configuration, keeping the previous configuration", "path", w.path, "error", err)
+		}
+		return
+	}
+	w.readErr = ""
+
+	if bytes.Equal(data, w.data) {
+		return
+	}
+	w.data = data
+
+	cfg, err := parseConfigFile(w.path, data)
+	if err != nil {
+		w.logger.Warn("failed to reload logging configuration, keeping the previous configuration", "path", w.path, "error", err)
+		return
+	}
+
+	previous := w.manager
+	if len(w.logger.state.Load().spools) > 0 && cfg.Spool.Dir != "" &&
+		filepath.Clean(cfg.Spool.Dir) == filepath.Clean(previous.GetConfig().Spool.Dir) {
+		w.handoff(cfg)
+		return
+	}
+
+	manager, old, err := w.apply(cfg)
+	if err != nil {
+		w.logger.Warn("failed to reload logging configuration, keeping the previous configuration", "path", w.path, "error", err)
+		return
+	}
+
+	old.retire()
+	w.manager = manager
+	if err := previous.Shutdown(); err != nil {
+		w.logger.Warn("failed to release resources of the previous logging configuration", "error", err)
+	}
+
+	w.logger.Info("logging configuration reloaded", "path", w.path)
+}
+
+// handoff applies cfg when its spool directory is the one of the current generation, which is
+// released before the new chain opens the directory. Records logged meanwhile are held and
+// handled by the new chain, or by the previous configuration rebuilt if cfg cannot be applied.
+func (w *configWatcher) handoff(cfg types.Config) {
+	previous := w.manager
+	held := w.swap.hold()
+	releaseErr := previous.Shutdown()
+
+	manager, _, err := w.apply(cfg)
+	var restoreErr error
+	if err != nil {
+		manager, _, restoreErr = w.apply(previous.GetConfig())
+	}
+	if restoreErr != nil {
+		// Neither chain can be built, so records go to stderr until the next reload
+		w.swap.swap(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: w.logger.logLevel}))
+		manager = lib.NewConfigManager()
+		manager.SetConfig(previous.GetConfig())
+	}
+	w.swap.release(held)
+	w.manager = manager
+
+	if releaseErr != nil {
+		w.logger.Warn("failed to release resources of the previous logging configuration", "error", releaseErr)
+	}
+	switch {
+	case restoreErr != nil:
+		w.logger.Warn("failed to reload logging configuration and to restore the previous one, logging to stderr", "path", w.path, "error", errors.Join(err, restoreErr))
+	case err != nil:
+		w.logger.Warn("failed to reload logging configuration, keeping the previous configuration", "path", w.path, "error", err)
+	default:
+		w.logger.Info("logging configuration reloaded", "path", w.path)
+	}
+}
+
+// apply builds the handler chain of cfg and swaps it in. It returns the manager of the new
+// chain's resources and the slot of the previous chain, which must be retired before its
+// resources are released. On failure the new resources are released and nothing is swapped.
+func (w *configWatcher) apply(cfg types.Config) (*lib.ConfigManager, *handlerSlot, error) {
+	manager := lib.NewConfigManager()
+	manager.SetConfig(cfg)
+
+	_, handler, state, err := buildHandler(context.WithoutCancel(w.ctx), manager, w.logger.levels)
+	if err != nil {
+		manager.Shutdown()
+		return nil, nil, err
+	}
+
+	old := w.swap.swap(handler)
+	w.logger.state.Store(state)
+	w.logger.logLevel.Set(cfg.Level.Level())
+	w.logger.levels.set(cfg.ComponentLevels)
+	w.logger.clearReverts()
+	lib.SetConfig(cfg)
+	return manager, old, nil
+}
+
+// close stops watching and releases the resources of the current generation.
+func (w *configWatcher) close() error {
+	w.cancel()
+	<-w.done
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.manager.Shutdown()
+}
@@ -0,0 +1,318 @@
+package loggergo
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// waitForFile waits until the file contains substr and returns its contents
+func waitForFile(t *testing.T, path, substr string) string {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		data, _ := os.ReadFile(path)
+		if strings.Contains(string(data), substr) {
+			return string(data)
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %q in %s, got:\n%s", substr, path, data)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestWatchConfig tests that changes to the file are applied to existing loggers
+func TestWatchConfig(t *testing.T) {
+	defer func(interval time.Duration) { configWatchInterval = interval }(configWatchInterval)
+	configWatchInterval = 10 * time.Millisecond
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logging.yaml")
+	first := filepath.Join(dir, "first.log")
+	second := filepath.Join(dir, "second.log")
+
+	os.WriteFile(path, []byte("level: info\nset_as_default: false\noutput_stream: "+first+"\n"), 0o600)
+
+	logger, err := WatchConfig(context.Background(), path)
+	if err != nil {
+		t.Fatalf("WatchConfig failed: %v", err)
+	}
+	defer Shutdown()
+
+	child := logger.With("request_id", "abc")
+	db := Named("db")
+
+	child.Debug("hidden")
+	child.Info("one", "password", "secret")
+
+	// Change level, format, output, redaction and component levels at once
+	os.WriteFile(path, []byte(`level: debug
+format: text
+set_as_default: false
+output_stream: `+second+`
+redaction:
+  rules:
+    - keys: [password]
+component_levels:
+  db: error
+`), 0o600)
+	waitForFile(t, second, "logging configuration reloaded")
+
+	child.Debug("two", "password", "secret")
+	db.Warn("filtered by component level")
+	db.Error("db failure")
+
+	output := waitForFile(t, second, "db failure")
+	if !strings.Contains(output, "msg=two request_id=abc password=[REDACTED]") {
+		t.Errorf("Expected text output with attributes and redaction after reload, got:\n%s", output)
+	}
+	if !strings.Contains(output, "component=db") || strings.Contains(output, "filtered by component level") {
+		t.Errorf("Expected component levels to be reloaded, got:\n%s", output)
+	}
+
+	data, _ := os.ReadFile(first)
+	if strings.Contains(string(data), "hidden") || !strings.Contains(string(data), `"msg":"one"`) || strings.Contains(string(data), "two") {
+		t.Errorf("Unexpected output before reload:\n%s", data)
+	}
+
+	if GetConfig().Level != slog.LevelDebug || GetLogLevelAccessor().Level() != slog.LevelDebug {
+		t.Errorf("Expected the global configuration to follow the reload, got %v", GetConfig().Level)
+	}
+}
+
+// TestWatchConfig_InvalidReload tests that an invalid file keeps the previous configuration
+func TestWatchConfig_InvalidReload(t *testing.T) {
+	defer func(interval time.Duration) { configWatchInterval = interval }(configWatchInterval)
+	configWatchInterval = 10 * time.Millisecond
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logging.json")
+	output := filepath.Join(dir, "app.log")
+
+	os.WriteFile(path, []byte(`{"level": "warn", "set_as_default": false, "output_stream": "`+output+`"}`), 0o600)
+
+	logger, err := WatchConfig(context.Background(), path)
+	if err != nil {
+		t.Fatalf("WatchConfig failed: %v", err)
+	}
+	defer Shutdown()
+
+	os.WriteFile(path, []byte(`{"level": "loud"}`), 0o600)
+	waitForFile(t, output, "failed to reload logging configuration")
+
+	logger.Info("still filtered")
+	logger.Warn("still written")
+
+	data := waitForFile(t, output, "still written")
+	if strings.Contains(data, "still filtered") || !strings.Contains(data, "Level") {
+		t.Errorf("Expected the previous configuration to be kept with a warning naming the field, got:\n%s", data)
+	}
+	if GetConfig().Level != slog.LevelWarn {
+		t.Errorf("Expected global level to stay warn, got %v", GetConfig().Level)
+	}
+}
+
+// TestWatchConfig_UnopenableOutputStream tests that a reload to an output file that cannot be
+// opened keeps the previous configuration
+func TestWatchConfig_UnopenableOutputStream(t *testing.T) {
+	defer func(interval time.Duration) { configWatchInterval = interval }(configWatchInterval)
+	configWatchInterval = 10 * time.Millisecond
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logging.yaml")
+	output := filepath.Join(dir, "app.log")
+	blocker := filepath.Join(dir, "blocker")
+	os.WriteFile(blocker, nil, 0o600)
+
+	os.WriteFile(path, []byte("set_as_default: false\noutput_stream: "+output+"\n"), 0o600)
+
+	logger, err := WatchConfig(context.Background(), path)
+	if err != nil {
+		t.Fatalf("WatchConfig failed: %v", err)
+	}
+	defer Shutdown()
+
+	os.WriteFile(path, []byte("set_as_default: false\noutput_stream: "+filepath.Join(blocker, "app.log")+"\n"), 0o600)
+	waitForFile(t, output, "failed to reload logging configuration")
+
+	logger.Info("still written")
+	waitForFile(t, output, "still written")
+}
+
+// TestWatchConfig_ClearsReverts tests that a reload cancels the temporary overrides made through the admin handler
+func TestWatchConfig_ClearsReverts(t *testing.T) {
+	defer func(interval time.Duration) { configWatchInterval = interval }(configWatchInterval)
+	configWatchInterval = 10 * time.Millisecond
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logging.yaml")
+	output := filepath.Join(dir, "app.log")
+	os.WriteFile(path, []byte("level: info\nset_as_default: false\noutput_stream: "+output+"\n"), 0o600)
+
+	if _, err := WatchConfig(context.Background(), path); err != nil {
+		t.Fatalf("WatchConfig failed: %v", err)
+	}
+	defer Shutdown()
+
+	var response levelResponse
+	serveAdmin(t, AdminHandler(), http.MethodPut, "/level?duration=1h", `{"level":"debug"}`, &response)
+	if response.RevertAt == nil {
+		t.Fatalf("Expected a pending revert, got %+v", response)
+	}
+
+	os.WriteFile(path, []byte("level: warn\nset_as_default: false\noutput_stream: "+output+"\n"), 0o600)
+	waitFor(t, "the reload", func() bool { return GetLogLevelAccessor().Level() == slog.LevelWarn })
+
+	var reloaded levelResponse
+	serveAdmin(t, AdminHandler(), http.MethodGet, "/level", "", &reloaded)
+	if reloaded.Level != slog.LevelWarn || reloaded.RevertAt != nil {
+		t.Errorf("Expected the reloaded level without pending revert, got %+v", reloaded)
+	}
+}
+
+// TestWatchConfig_InitialError tests that an invalid file is rejected when the watch starts
+func TestWatchConfig_InitialError(t *testing.T) {
+	if _, err := WatchConfig(context.Background(), filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+
+	path := filepath.Join(t.TempDir(), "logging.yaml")
+	os.WriteFile(path, []byte("output: file\n"), 0o600)
+	if _, err := WatchConfig(context.Background(), path); err == nil {
+		t.Error("Expected error for invalid configuration")
+	}
+}
+
+// TestSwapHandler tests that derived handlers replay their attributes on the current handler
+func TestSwapHandler(t *testing.T) {
+	var before, after strings.Builder
+	swap := newSwapHandler(slog.NewTextHandler(&before, nil))
+	logger := slog.New(swap).With("a", 1).WithGroup("g").With("b", 2)
+
+	logger.Info("first")
+	swap.swap(slog.NewJSONHandler(&after, nil))
+	logger.Info("second")
+
+	if !strings.Contains(before.String(), "msg=first a=1 g.b=2") {
+		t.Errorf("Unexpected output before swap: %s", before.String())
+	}
+	if !strings.Contains(after.String(), `"msg":"second","a":1,"g":{"b":2}`) {
+		t.Errorf("Unexpected output after swap: %s", after.String())
+	}
+}
+
+// TestSwapHandler_DrainsInFlight tests that retiring a chain waits for the records in flight on it
+func TestSwapHandler_DrainsInFlight(t *testing.T) {
+	blocking := newBlockingHandler()
+	after := &syncBuffer{}
+	swap := newSwapHandler(blocking)
+	logger := slog.New(swap)
+
+	go logger.Info("in flight")
+	<-blocking.started
+	old := swap.swap(slog.NewTextHandler(after, nil))
+	logger.Info("after swap")
+
+	retired := make(chan struct{})
+	go func() {
+		old.retire()
+		close(retired)
+	}()
+	select {
+	case <-retired:
+		t.Fatal("Expected retire to wait for the record in flight")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(blocking.release)
+	<-retired
+	blocking.mu.Lock()
+	defer blocking.mu.Unlock()
+	if len(blocking.messages) != 1 || blocking.messages[0] != "in flight" || !strings.Contains(after.String(), `msg="after swap"`) {
+		t.Errorf("Expected each record in its chain, got %v and %q", blocking.messages, after.String())
+	}
+}
+
+// TestSwapHandler_HoldsRecords tests that records logged while no chain is swapped in are handled by the next one
+func TestSwapHandler_HoldsRecords(t *testing.T) {
+	var before, after strings.Builder
+	swap := newSwapHandler(slog.NewTextHandler(&before, nil))
+	logger := slog.New(swap).With("a", 1)
+
+	held := swap.hold()
+	logger.Info("held")
+	if before.Len() != 0 || after.Len() != 0 {
+		t.Fatalf("Expected the record to be held, got %q", before.String())
+	}
+
+	swap.swap(slog.NewJSONHandler(&after, nil))
+	swap.release(held)
+	if !strings.Contains(after.String(), `"msg":"held","a":1`) {
+		t.Errorf("Expected the held record in the next chain, got %q", after.String())
+	}
+}
+
+// TestWatchConfig_SpoolHandoff tests that a reload keeping the spool directory hands it over to the new chain
+func TestWatchConfig_SpoolHandoff(t *testing.T) {
+	defer func(interval time.Duration) { configWatchInterval = interval }(configWatchInterval)
+	configWatchInterval = 10 * time.Millisecond
+
+	collector := &toggleCollector{retryable: true}
+	collector.down.Store(true)
+	server := httptest.NewServer(collector)
+	defer server.Close()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logging.yaml")
+	output := filepath.Join(dir, "app.log")
+	config := func(level string) []byte {
+		return []byte(`level: ` + level + `
+output: fanout
+output_stream: ` + output + `
+set_as_default: false
+otel_logger_name: test
+otel_service_name: handoff-test
+otlp:
+  endpoint: ` + server.URL + `
+  protocol: http/protobuf
+  batch:
+    export_interval: 20ms
+    export_timeout: 200ms
+spool:
+  dir: ` + filepath.Join(dir, "spool") + `
+  retry_interval: 20ms
+`)
+	}
+	os.WriteFile(path, config("info"), 0o600)
+
+	logger, err := WatchConfig(context.Background(), path)
+	if err != nil {
+		t.Fatalf("WatchConfig failed: %v", err)
+	}
+	defer Shutdown()
+
+	logger.Info("spooled before reload")
+	waitFor(t, "the record to be spooled", func() bool { return GetSpoolStats().Records > 0 })
+
+	os.WriteFile(path, config("debug"), 0o600)
+	data := waitForFile(t, output, "logging configuration reloaded")
+	if strings.Contains(data, "failed to reload") {
+		t.Fatalf("Expected the reload to open the spool directory again, got:\n%s", data)
+	}
+	if GetSpoolStats().Records == 0 {
+		t.Fatal("Expected the spooled record to be loaded by the new chain")
+	}
+
+	logger.Debug("logged after reload")
+	collector.down.Store(false)
+	waitFor(t, "the spooled records to be exported", func() bool {
+		return collector.received("spooled before reload") && collector.received("logged after reload")
+	})
+}
@@ -0,0 +1,52 @@
+package loggergo
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+// contextAttrsKey is the context key of the attributes stored by ContextWith.
+type contextAttrsKey struct{}
+
+// ContextWith returns a copy of ctx carrying the given attributes in addition to those stored
+// by earlier calls on its parents. The context handler adds them to every record logged with
+// the returned context, after the attributes of ContextKeys and ContextExtractors and in the
+// context group, if one is set. No list of keys has to be configured.
+//
+// Attributes are deduplicated by key: an attribute replaces one with the same key from an
+// outer call, keeping its position, so the innermost value wins. ctx itself is not modified.
+//
+// Example:
+//
+//	ctx = loggergo.ContextWith(ctx, slog.String("tenant_id", tenant), slog.String("user_id", user))
+//	ctx = loggergo.ContextWith(ctx, slog.String("job_id", job.ID))
+//	logger.InfoContext(ctx, "job started") // tenant_id, user_id and job_id
+func ContextWith(ctx context.Context, attrs ...slog.Attr) context.Context {
+	if len(attrs) == 0 {
+		return ctx
+	}
+
+	merged := slices.Clone(contextAttrs(ctx))
+	for _, attr := range attrs {
+		i := slices.IndexFunc(merged, func(a slog.Attr) bool { return a.Key == attr.Key })
+		if i >= 0 {
+			merged[i] = attr
+		} else {
+			merged = append(merged, attr)
+		}
+	}
+	return context.WithValue(ctx, contextAttrsKey{}, merged)
+}
+
+// ContextAttrs returns the attributes stored in ctx by ContextWith, or nil if there are none.
+// The returned slice is a copy and may be modified.
+func ContextAttrs(ctx context.Context) []slog.Attr {
+	return slices.Clone(contextAttrs(ctx))
+}
+
+// contextAttrs returns the attributes stored in ctx by ContextWith without copying them.
+func contextAttrs(ctx context.Context) []slog.Attr {
+	attrs, _ := ctx.Value(contextAttrsKey{}).([]slog.Attr)
+	return attrs
+}
@@ -0,0 +1,91 @@
+package loggergo
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// attrsString formats attributes as key=value pairs in order
+func attrsString(attrs []slog.Attr) string {
+	parts := make([]string, 0, len(attrs))
+	for _, attr := range attrs {
+		parts = append(parts, attr.String())
+	}
+	return strings.Join(parts, " ")
+}
+
+// TestContextWith_Accumulates tests accumulating attributes through nested calls with innermost-wins deduplication
+func TestContextWith_Accumulates(t *testing.T) {
+	outer := ContextWith(context.Background(), slog.String("tenant_id", "acme"), slog.String("user_id", "u-1"))
+	inner := ContextWith(outer, slog.String("job_id", "j-1"), slog.String("user_id", "u-2"))
+	sibling := ContextWith(outer, slog.String("job_id", "j-2"))
+
+	if got := attrsString(ContextAttrs(inner)); got != "tenant_id=acme user_id=u-2 job_id=j-1" {
+		t.Errorf("Expected the innermost user_id in its original position, got %q", got)
+	}
+	if got := attrsString(ContextAttrs(outer)); got != "tenant_id=acme user_id=u-1" {
+		t.Errorf("Expected the outer context to be unchanged, got %q", got)
+	}
+	if got := attrsString(ContextAttrs(sibling)); got != "tenant_id=acme user_id=u-1 job_id=j-2" {
+		t.Errorf("Expected the sibling context not to share attributes, got %q", got)
+	}
+
+	dup := ContextWith(context.Background(), slog.Int("attempt", 1), slog.Int("attempt", 2))
+	if got := attrsString(ContextAttrs(dup)); got != "attempt=2" {
+		t.Errorf("Expected duplicate keys in one call to be deduplicated, got %q", got)
+	}
+
+	if ContextWith(outer) != outer || ContextAttrs(context.Background()) != nil {
+		t.Error("Expected no attributes to leave the context as it is")
+	}
+}
+
+// TestInit_ContextWith tests that every record logged with the context carries its attributes
+func TestInit_ContextWith(t *testing.T) {
+	out := &syncBuffer{}
+	logger, err := New(context.Background(), Config{
+		Level:        slog.LevelInfo,
+		OutputStream: out,
+		SetAsDefault: false,
+		ContextKeys:  []interface{}{"request_id"},
+		ContextGroup: "ctx",
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer logger.Shutdown()
+
+	ctx := context.WithValue(context.Background(), "request_id", "req-123")
+	ctx = ContextWith(ctx, slog.String("tenant_id", "acme"), slog.Group("job", slog.String("id", "j-1")))
+	ctx = ContextWith(ctx, slog.String("tenant_id", "globex"))
+	logger.InfoContext(ctx, "job started")
+	logger.Named("worker").InfoContext(ctx, "job finished")
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 records, got %q", out.String())
+	}
+	for _, line := range lines {
+		var entry struct {
+			Ctx struct {
+				RequestID string `json:"request_id"`
+				TenantID  string `json:"tenant_id"`
+				Job       struct {
+					ID string `json:"id"`
+				} `json:"job"`
+			} `json:"ctx"`
+		}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Failed to parse log output %q: %v", line, err)
+		}
+		if entry.Ctx.RequestID != "req-123" || entry.Ctx.TenantID != "globex" || entry.Ctx.Job.ID != "j-1" {
+			t.Errorf("Expected the context attributes with the innermost tenant_id, got %s", line)
+		}
+		if strings.Count(line, "tenant_id") != 1 {
+			t.Errorf("Expected tenant_id once, got %s", line)
+		}
+	}
+}
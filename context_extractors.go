@@ -0,0 +1,178 @@
+package loggergo
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ExtractKey returns a ContextExtractor adding the value stored in the context under key as an
+// attribute with the given name. Records logged with a context without the value get no attribute.
+//
+// Example:
+//
+//	config.ContextExtractors = []loggergo.ContextExtractor{
+//	    loggergo.ExtractKey(requestIDKey, "request.id"),
+//	}
+func ExtractKey(key any, name string) ContextExtractor {
+	return ContextExtractorFunc(func(ctx context.Context) []slog.Attr {
+		if value := ctx.Value(key); value != nil {
+			return []slog.Attr{slog.Any(name, value)}
+		}
+		return nil
+	})
+}
+
+// ExtractKeyOrDefault returns a ContextExtractor like ExtractKey that adds defaultValue when the
+// context has no value under key. Unlike Config.ContextKeysDefault, the default is per key.
+func ExtractKeyOrDefault(key any, name string, defaultValue any) ContextExtractor {
+	return ContextExtractorFunc(func(ctx context.Context) []slog.Attr {
+		value := ctx.Value(key)
+		if value == nil {
+			value = defaultValue
+		}
+		return []slog.Attr{slog.Any(name, value)}
+	})
+}
+
+// ExtractBaggage returns a ContextExtractor adding the members of the OpenTelemetry baggage in
+// the context as string attributes named after the member keys. With members, only those are
+// added, in the given order; otherwise every member is added.
+//
+// Example:
+//
+//	loggergo.ExtractBaggage("tenant.id", "user.id")
+func ExtractBaggage(members ...string) ContextExtractor {
+	return ContextExtractorFunc(func(ctx context.Context) []slog.Attr {
+		bag := baggage.FromContext(ctx)
+		if bag.Len() == 0 {
+			return nil
+		}
+
+		var attrs []slog.Attr
+		if len(members) == 0 {
+			for _, member := range bag.Members() {
+				attrs = append(attrs, slog.String(member.Key(), member.Value()))
+			}
+			return attrs
+		}
+		for _, key := range members {
+			if member := bag.Member(key); member.Key() != "" {
+				attrs = append(attrs, slog.String(key, member.Value()))
+			}
+		}
+		return attrs
+	})
+}
+
+// ExtractSpanAttributes returns a ContextExtractor adding the attributes of the span in the
+// context. With keys, only those attributes are added, in the given order; otherwise all of them.
+//
+// The OpenTelemetry API does not expose span attributes, so only spans that do, such as the
+// recording spans of the SDK (sdktrace.ReadOnlySpan), have their attributes added.
+func ExtractSpanAttributes(keys ...string) ContextExtractor {
+	return ContextExtractorFunc(func(ctx context.Context) []slog.Attr {
+		span, ok := trace.SpanFromContext(ctx).(interface{ Attributes() []attribute.KeyValue })
+		if !ok {
+			return nil
+		}
+		spanAttrs := span.Attributes()
+
+		var attrs []slog.Attr
+		if len(keys) == 0 {
+			for _, kv := range spanAttrs {
+				attrs = append(attrs, slog.Any(string(kv.Key), kv.Value.AsInterface()))
+			}
+			return attrs
+		}
+		for _, key := range keys {
+			for _, kv := range spanAttrs {
+				if string(kv.Key) == key {
+					attrs = append(attrs, slog.Any(key, kv.Value.AsInterface()))
+					break
+				}
+			}
+		}
+		return attrs
+	})
+}
+
+// ExtractField returns a ContextExtractor adding a value nested in the value stored in the
+// context under key, as an attribute with the given name. The path is a dot-separated list of
+// exported struct field names or string map keys, followed through pointers and interfaces
+// (e.g. "User.ID"); an empty path adds the stored value itself. Records logged with a context
+// without the value, or with a nil pointer or missing map key on the path, get no attribute.
+// Neither do values that reflection does not allow to read, instead of panicking.
+//
+// Example:
+//
+//	type RequestInfo struct {
+//	    User struct{ ID string }
+//	}
+//
+//	loggergo.ExtractField(requestInfoKey, "User.ID", "user.id")
+func ExtractField(key any, path string, name string) ContextExtractor {
+	var fields []string
+	if path != "" {
+		fields = strings.Split(path, ".")
+	}
+
+	return ContextExtractorFunc(func(ctx context.Context) []slog.Attr {
+		value := ctx.Value(key)
+		if value == nil {
+			return nil
+		}
+		if field, ok := fieldValue(reflect.ValueOf(value), fields); ok {
+			return []slog.Attr{slog.Any(name, field.Interface())}
+		}
+		return nil
+	})
+}
+
+// fieldValue follows the struct fields and map keys of fields from v. It reports false if one
+// of them is missing or a nil pointer, interface or map is met on the way, or if the value
+// cannot be used with Interface because it was obtained through an unexported field.
+func fieldValue(v reflect.Value, fields []string) (reflect.Value, bool) {
+	for _, name := range fields {
+		for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+
+		switch v.Kind() {
+		case reflect.Struct:
+			field, ok := v.Type().FieldByName(name)
+			if !ok || !field.IsExported() {
+				return reflect.Value{}, false
+			}
+			var err error
+			if v, err = v.FieldByIndexErr(field.Index); err != nil {
+				return reflect.Value{}, false
+			}
+		case reflect.Map:
+			if v.Type().Key().Kind() != reflect.String {
+				return reflect.Value{}, false
+			}
+			if v = v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key())); !v.IsValid() {
+				return reflect.Value{}, false
+			}
+		default:
+			return reflect.Value{}, false
+		}
+	}
+
+	if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+		return reflect.Value{}, false
+	}
+	if !v.CanInterface() {
+		return reflect.Value{}, false
+	}
+	return v, true
+}
@@ -0,0 +1,222 @@
+package loggergo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+type extractorKey string
+
+// requestInfo is a value stored in the context whose nested fields are extracted
+type requestInfo struct {
+	User   *requestUser
+	Labels map[string]string
+}
+
+type requestUser struct {
+	ID   string
+	Role string
+}
+
+// tenantInfo is embedded unexported, so its exported fields are promoted from an unexported field
+type tenantInfo struct {
+	Tenant string
+}
+
+type scopedRequest struct {
+	tenantInfo
+	*requestUser
+}
+
+// logWithExtractors logs one record with ctx through a context handler and returns the decoded JSON entry
+func logWithExtractors(t *testing.T, ctx context.Context, group string, extractors ...ContextExtractor) map[string]any {
+	t.Helper()
+	var buf bytes.Buffer
+	handler := NewCustomContextAttributeHandler(slog.NewJSONHandler(&buf, nil), nil, nil, extractors...).WithContextGroup(group)
+	slog.New(handler).InfoContext(ctx, "test message")
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to parse log output %q: %v", buf.String(), err)
+	}
+	return entry
+}
+
+// TestExtractKey tests extracting context values under explicit attribute names, with and without defaults
+func TestExtractKey(t *testing.T) {
+	ctx := context.WithValue(context.Background(), extractorKey("request_id"), "req-123")
+	entry := logWithExtractors(t, ctx, "",
+		ExtractKey(extractorKey("request_id"), "request.id"),
+		ExtractKey(extractorKey("user_id"), "user.id"),
+		ExtractKeyOrDefault(extractorKey("tenant_id"), "tenant.id", "none"),
+		ExtractKeyOrDefault(extractorKey("request_id"), "request", "none"),
+	)
+
+	if entry["request.id"] != "req-123" || entry["request"] != "req-123" {
+		t.Errorf("Expected the request ID under both names, got %v", entry)
+	}
+	if _, ok := entry["user.id"]; ok {
+		t.Errorf("Expected no attribute for a missing key without default, got %v", entry["user.id"])
+	}
+	if entry["tenant.id"] != "none" {
+		t.Errorf("Expected the per-key default, got %v", entry["tenant.id"])
+	}
+}
+
+// TestExtractBaggage tests extracting all or selected baggage members
+func TestExtractBaggage(t *testing.T) {
+	tenant, _ := baggage.NewMember("tenant", "acme")
+	user, _ := baggage.NewMember("user", "42")
+	bag, _ := baggage.New(tenant, user)
+	ctx := baggage.ContextWithBaggage(context.Background(), bag)
+
+	entry := logWithExtractors(t, ctx, "", ExtractBaggage())
+	if entry["tenant"] != "acme" || entry["user"] != "42" {
+		t.Errorf("Expected all baggage members, got %v", entry)
+	}
+
+	entry = logWithExtractors(t, ctx, "", ExtractBaggage("user", "missing"))
+	if _, ok := entry["tenant"]; ok || entry["user"] != "42" {
+		t.Errorf("Expected only the user member, got %v", entry)
+	}
+	if _, ok := entry["missing"]; ok {
+		t.Errorf("Expected no attribute for a missing member, got %v", entry)
+	}
+
+	entry = logWithExtractors(t, context.Background(), "", ExtractBaggage())
+	if len(entry) != 3 {
+		t.Errorf("Expected no attributes without baggage, got %v", entry)
+	}
+}
+
+// TestExtractSpanAttributes tests extracting the attributes of an SDK span
+func TestExtractSpanAttributes(t *testing.T) {
+	provider := sdktrace.NewTracerProvider()
+	defer provider.Shutdown(context.Background())
+	ctx, span := provider.Tracer("test").Start(context.Background(), "operation")
+	span.SetAttributes(attribute.String("http.route", "/orders"), attribute.Int("retry", 2), attribute.Bool("cached", true))
+	defer span.End()
+
+	entry := logWithExtractors(t, ctx, "", ExtractSpanAttributes())
+	if entry["http.route"] != "/orders" || entry["retry"] != float64(2) || entry["cached"] != true {
+		t.Errorf("Expected all span attributes, got %v", entry)
+	}
+
+	entry = logWithExtractors(t, ctx, "", ExtractSpanAttributes("retry"))
+	if _, ok := entry["http.route"]; ok || entry["retry"] != float64(2) {
+		t.Errorf("Expected only the retry attribute, got %v", entry)
+	}
+
+	entry = logWithExtractors(t, context.Background(), "", ExtractSpanAttributes())
+	if len(entry) != 3 {
+		t.Errorf("Expected no attributes without a span, got %v", entry)
+	}
+}
+
+// TestExtractField tests extracting values nested in a struct stored in the context
+func TestExtractField(t *testing.T) {
+	info := &requestInfo{User: &requestUser{ID: "u-1", Role: "admin"}, Labels: map[string]string{"region": "eu"}}
+	ctx := context.WithValue(context.Background(), extractorKey("info"), info)
+
+	entry := logWithExtractors(t, ctx, "",
+		ExtractField(extractorKey("info"), "User.ID", "user.id"),
+		ExtractField(extractorKey("info"), "Labels.region", "region"),
+		ExtractField(extractorKey("info"), "User.Missing", "missing"),
+		ExtractField(extractorKey("info"), "Labels.zone", "zone"),
+	)
+	if entry["user.id"] != "u-1" || entry["region"] != "eu" {
+		t.Errorf("Expected the nested values, got %v", entry)
+	}
+	for _, name := range []string{"missing", "zone"} {
+		if _, ok := entry[name]; ok {
+			t.Errorf("Expected no attribute %s for a missing field, got %v", name, entry)
+		}
+	}
+
+	ctx = context.WithValue(context.Background(), extractorKey("info"), &requestInfo{})
+	entry = logWithExtractors(t, ctx, "", ExtractField(extractorKey("info"), "User.ID", "user.id"))
+	if _, ok := entry["user.id"]; ok {
+		t.Errorf("Expected no attribute through a nil pointer, got %v", entry)
+	}
+
+	scoped := scopedRequest{tenantInfo: tenantInfo{Tenant: "acme"}, requestUser: &requestUser{ID: "u-2"}}
+	ctx = context.WithValue(context.Background(), extractorKey("info"), scoped)
+	entry = logWithExtractors(t, ctx, "",
+		ExtractField(extractorKey("info"), "Tenant", "tenant"),
+		ExtractField(extractorKey("info"), "ID", "user.id"),
+	)
+	if entry["tenant"] != "acme" || entry["user.id"] != "u-2" {
+		t.Errorf("Expected the fields promoted from unexported embedded structs, got %v", entry)
+	}
+
+	// A value read through an unexported field cannot be used with Interface
+	hidden := reflect.ValueOf(struct{ info requestInfo }{requestInfo{User: &requestUser{ID: "u-3"}}}).Field(0)
+	if _, ok := fieldValue(hidden, []string{"User", "ID"}); ok {
+		t.Error("Expected no value through an unexported field")
+	}
+}
+
+// TestContextHandler_Group tests placing context keys and extracted attributes in the context group
+func TestContextHandler_Group(t *testing.T) {
+	var buf bytes.Buffer
+	handler := NewCustomContextAttributeHandler(slog.NewJSONHandler(&buf, nil), []interface{}{"request_id"}, nil,
+		ExtractKey(extractorKey("user_id"), "user_id"),
+	).WithContextGroup("ctx")
+	logger := slog.New(handler).With("service", "orders")
+
+	ctx := context.WithValue(context.Background(), "request_id", "req-123")
+	ctx = context.WithValue(ctx, extractorKey("user_id"), "u-1")
+	logger.InfoContext(ctx, "grouped")
+	logger.Info("no context")
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	var grouped, plain map[string]any
+	if err := json.Unmarshal(lines[0], &grouped); err != nil {
+		t.Fatalf("Failed to parse log output: %v", err)
+	}
+	group, ok := grouped["ctx"].(map[string]any)
+	if !ok || group["request_id"] != "req-123" || group["user_id"] != "u-1" || grouped["service"] != "orders" {
+		t.Errorf("Expected the context attributes in the ctx group, got %v", grouped)
+	}
+
+	if err := json.Unmarshal(lines[1], &plain); err != nil {
+		t.Fatalf("Failed to parse log output: %v", err)
+	}
+	if _, ok := plain["ctx"]; ok {
+		t.Errorf("Expected no group without context attributes, got %v", plain)
+	}
+}
+
+// TestInit_ContextExtractors tests that extractors and the group from Config are applied
+func TestInit_ContextExtractors(t *testing.T) {
+	out := &syncBuffer{}
+	logger, err := New(context.Background(), Config{
+		Level:             slog.LevelInfo,
+		OutputStream:      out,
+		SetAsDefault:      false,
+		ContextExtractors: []ContextExtractor{ExtractKeyOrDefault(extractorKey("tenant"), "tenant", "none")},
+		ContextGroup:      "ctx",
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer logger.Shutdown()
+
+	logger.InfoContext(context.WithValue(context.Background(), extractorKey("tenant"), "acme"), "hello")
+
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(out.String()), &entry); err != nil {
+		t.Fatalf("Failed to parse log output %q: %v", out.String(), err)
+	}
+	if group, ok := entry["ctx"].(map[string]any); !ok || group["tenant"] != "acme" {
+		t.Errorf("Expected the tenant in the ctx group, got %v", entry)
+	}
+}
@@ -22,6 +22,8 @@ type CustomContextAttributeHandler struct {
 	innerHandler       slog.Handler
 	keys               []interface{}
 	ContextKeysDefault interface{}
+	extractors         []ContextExtractor
+	group              string
 }
 
 // NewCustomContextAttributeHandler creates a new handler that wraps the given handler
@@ -31,6 +33,7 @@ type CustomContextAttributeHandler struct {
 //   - handler: The underlying slog.Handler to wrap
 //   - keys: Slice of context keys to extract from context.Context
 //   - contextKeysDefault: Default value to use when a key is not found in context (can be nil)
+//   - extractors: Optional extractors adding further attributes, run in order after the keys
 //
 // Returns:
 //   - *CustomContextAttributeHandler: A new handler that extracts context values
@@ -50,14 +53,25 @@ type CustomContextAttributeHandler struct {
 //
 //	ctx := context.WithValue(context.Background(), requestIDKey, "req-123")
 //	logger.InfoContext(ctx, "Processing request") // Will include request_id: "req-123"
-func NewCustomContextAttributeHandler(handler slog.Handler, keys []interface{}, contextKeysDefault interface{}) *CustomContextAttributeHandler {
+func NewCustomContextAttributeHandler(handler slog.Handler, keys []interface{}, contextKeysDefault interface{}, extractors ...ContextExtractor) *CustomContextAttributeHandler {
 	return &CustomContextAttributeHandler{
 		innerHandler:       handler,
 		keys:               keys,
 		ContextKeysDefault: contextKeysDefault,
+		extractors:         extractors,
 	}
 }
 
+// WithContextGroup returns a copy of the handler that places the attributes taken from the
+// context in a group with the given name, e.g. {"ctx": {"request_id": "req-123"}}. Records
+// logged with a context that yields no attributes get no group. An empty name adds them at
+// the top level.
+func (h *CustomContextAttributeHandler) WithContextGroup(name string) *CustomContextAttributeHandler {
+	clone := *h
+	clone.group = name
+	return &clone
+}
+
 // Enabled reports whether the handler handles records at the given level.
 // It delegates the check to the inner handler.
 func (h *CustomContextAttributeHandler) Enabled(ctx context.Context, level slog.Level) bool {
@@ -68,7 +82,9 @@ func (h *CustomContextAttributeHandler) Enabled(ctx context.Context, level slog.
 //
 // It extracts values for all configured keys from the context and adds them as attributes
 // to the log record. If a key is not found, it uses the default value (if configured) or
-// omits the field.
+// omits the field. The attributes returned by the extractors and those stored in the context
+// by ContextWith follow, and all of them are placed in the context group, if one is set with
+// WithContextGroup.
 //
 // Error Handling:
 //
@@ -93,6 +109,7 @@ func (h *CustomContextAttributeHandler) Handle(ctx context.Context, record slog.
 		ctx = context.Background()
 	}
 
+	var attrs []slog.Attr
 	for _, key := range h.keys {
 		// Safe context value extraction with error handling
 		val := ctx.Value(key)
@@ -100,15 +117,25 @@ func (h *CustomContextAttributeHandler) Handle(ctx context.Context, record slog.
 		if val == nil {
 			// Use default value for missing keys
 			if h.ContextKeysDefault != nil {
-				record.Add(slog.Any(fmt.Sprintf("%v", key), h.ContextKeysDefault))
+				attrs = append(attrs, slog.Any(fmt.Sprintf("%v", key), h.ContextKeysDefault))
 			}
 			// If no default is set, omit the field (graceful handling)
 		} else {
 			// Add the extracted value to the log record
 			// slog.Any handles type formatting appropriately
-			record.Add(slog.Any(fmt.Sprintf("%v", key), val))
+			attrs = append(attrs, slog.Any(fmt.Sprintf("%v", key), val))
 		}
 	}
+	for _, extractor := range h.extractors {
+		attrs = append(attrs, extractor.Extract(ctx)...)
+	}
+	attrs = append(attrs, contextAttrs(ctx)...)
+
+	if h.group != "" && len(attrs) > 0 {
+		record.AddAttrs(slog.Attr{Key: h.group, Value: slog.GroupValue(attrs...)})
+	} else {
+		record.AddAttrs(attrs...)
+	}
 
 	// Delegate to the inner handler
 	return h.innerHandler.Handle(ctx, record)
@@ -117,11 +144,15 @@ func (h *CustomContextAttributeHandler) Handle(ctx context.Context, record slog.
 // WithAttrs returns a new handler with the given attributes added.
 // The new handler preserves the context extraction behavior.
 func (h *CustomContextAttributeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
-	return NewCustomContextAttributeHandler(h.innerHandler.WithAttrs(attrs), h.keys, h.ContextKeysDefault)
+	clone := *h
+	clone.innerHandler = h.innerHandler.WithAttrs(attrs)
+	return &clone
 }
 
 // WithGroup returns a new handler with the given group name.
 // The new handler preserves the context extraction behavior.
 func (h *CustomContextAttributeHandler) WithGroup(name string) slog.Handler {
-	return NewCustomContextAttributeHandler(h.innerHandler.WithGroup(name), h.keys, h.ContextKeysDefault)
+	clone := *h
+	clone.innerHandler = h.innerHandler.WithGroup(name)
+	return &clone
 }
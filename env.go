@@ -0,0 +1,137 @@
+package loggergo
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/wasilak/loggergo/lib"
+	"github.com/wasilak/loggergo/lib/types"
+)
+
+// ConfigFromEnv returns the default configuration with the values set in environment variables applied.
+//
+// Variable names are the prefix, an underscore and the setting name, e.g. with prefix "LOGGERGO":
+//
+//	LOGGERGO_LEVEL                 debug, info, warn, error (or e.g. info+2)
+//	LOGGERGO_FORMAT                json, text (or plain), otel
+//	LOGGERGO_OUTPUT                console, otel, fanout, file, syslog, journald
+//	LOGGERGO_DEV_MODE              true or false
+//	LOGGERGO_DEV_FLAVOR            tint, slogor, devslog
+//	LOGGERGO_OTEL_TRACING_ENABLED  true or false
+//	LOGGERGO_OTEL_LOGGER_NAME      string
+//	LOGGERGO_OTEL_SERVICE_NAME     string
+//	LOGGERGO_SET_AS_DEFAULT        true or false
+//	LOGGERGO_CONTEXT_KEYS          comma-separated string keys, e.g. request_id,user_id
+//	LOGGERGO_CONTEXT_KEYS_DEFAULT  string
+//	LOGGERGO_CONTEXT_GROUP         string
+//	LOGGERGO_COMPONENT_LEVELS      comma-separated component=level pairs, e.g. db=debug,*=info
+//
+// Unset and empty variables leave the default value. Values are case-insensitive. Unlike
+// Types.LogLevelFromString and friends, invalid values are not replaced by a fallback: they
+// are returned as a *ValidationError with one FieldError per variable, named after it.
+//
+// Context keys read from the environment are strings, so they match values stored in the
+// context under string keys.
+//
+// Example:
+//
+//	config, err := loggergo.ConfigFromEnv("LOGGERGO")
+//	if err != nil {
+//	    log.Fatalf("Invalid logger configuration: %v", err)
+//	}
+//	ctx, logger, err := loggergo.Init(ctx, config)
+func ConfigFromEnv(prefix string) (types.Config, error) {
+	config := lib.DefaultConfig()
+	env := envReader{prefix: prefix}
+
+	env.read("LEVEL", func(value string) error {
+		level, err := types.ParseLogLevel(value)
+		config.Level = level
+		return err
+	})
+	env.read("FORMAT", func(value string) (err error) {
+		config.Format, err = types.ParseLogFormat(value)
+		return err
+	})
+	env.read("OUTPUT", func(value string) (err error) {
+		config.Output, err = types.ParseOutputType(value)
+		return err
+	})
+	env.read("DEV_MODE", func(value string) (err error) {
+		config.DevMode, err = strconv.ParseBool(value)
+		return err
+	})
+	env.read("DEV_FLAVOR", func(value string) (err error) {
+		config.DevFlavor, err = types.ParseDevFlavor(value)
+		return err
+	})
+	env.read("OTEL_TRACING_ENABLED", func(value string) (err error) {
+		config.OtelTracingEnabled, err = strconv.ParseBool(value)
+		return err
+	})
+	env.read("OTEL_LOGGER_NAME", func(value string) error {
+		config.OtelLoggerName = value
+		return nil
+	})
+	env.read("OTEL_SERVICE_NAME", func(value string) error {
+		config.OtelServiceName = value
+		return nil
+	})
+	env.read("SET_AS_DEFAULT", func(value string) (err error) {
+		config.SetAsDefault, err = strconv.ParseBool(value)
+		return err
+	})
+	env.read("CONTEXT_KEYS", func(value string) error {
+		config.ContextKeys = nil
+		for _, key := range strings.Split(value, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				config.ContextKeys = append(config.ContextKeys, key)
+			}
+		}
+		return nil
+	})
+	env.read("CONTEXT_KEYS_DEFAULT", func(value string) error {
+		config.ContextKeysDefault = value
+		return nil
+	})
+	env.read("CONTEXT_GROUP", func(value string) error {
+		config.ContextGroup = value
+		return nil
+	})
+	env.read("COMPONENT_LEVELS", func(value string) (err error) {
+		config.ComponentLevels, err = types.ParseComponentLevels(value)
+		return err
+	})
+
+	if len(env.errors) > 0 {
+		return config, &types.ValidationError{Errors: env.errors}
+	}
+	return config, nil
+}
+
+// envReader reads prefixed environment variables and collects parse errors as FieldErrors.
+type envReader struct {
+	prefix string
+	errors []types.FieldError
+}
+
+// read calls parse with the value of the named variable if it is set and not empty.
+func (r *envReader) read(name string, parse func(value string) error) {
+	if r.prefix != "" {
+		name = strings.TrimSuffix(r.prefix, "_") + "_" + name
+	}
+
+	value := strings.TrimSpace(os.Getenv(name))
+	if value == "" {
+		return
+	}
+
+	if err := parse(value); err != nil {
+		r.errors = append(r.errors, types.FieldError{
+			Field:  name,
+			Value:  value,
+			Reason: err.Error(),
+		})
+	}
+}
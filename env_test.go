@@ -0,0 +1,78 @@
+package loggergo
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/wasilak/loggergo/lib/types"
+)
+
+// TestConfigFromEnv tests that prefixed variables are applied on top of the defaults
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("MYAPP_LEVEL", "DEBUG")
+	t.Setenv("MYAPP_FORMAT", "plain")
+	t.Setenv("MYAPP_OUTPUT", "Fanout")
+	t.Setenv("MYAPP_DEV_FLAVOR", "slogor")
+	t.Setenv("MYAPP_OTEL_TRACING_ENABLED", "false")
+	t.Setenv("MYAPP_OTEL_SERVICE_NAME", "orders")
+	t.Setenv("MYAPP_CONTEXT_KEYS", "request_id, user_id,")
+	t.Setenv("MYAPP_CONTEXT_GROUP", "ctx")
+	t.Setenv("MYAPP_COMPONENT_LEVELS", "db=debug,*=warn")
+
+	config, err := ConfigFromEnv("MYAPP")
+	if err != nil {
+		t.Fatalf("ConfigFromEnv failed: %v", err)
+	}
+
+	if config.Level != slog.LevelDebug {
+		t.Errorf("Expected Debug level, got %v", config.Level)
+	}
+	if config.Format != types.LogFormatText || config.Output != types.OutputFanout || config.DevFlavor != types.DevFlavorSlogor {
+		t.Errorf("Expected text format, fanout output and slogor flavor, got %v, %v, %v", config.Format, config.Output, config.DevFlavor)
+	}
+	if config.OtelTracingEnabled {
+		t.Error("Expected OtelTracingEnabled to be false")
+	}
+	if config.OtelServiceName != "orders" {
+		t.Errorf("Expected service name orders, got %q", config.OtelServiceName)
+	}
+	if len(config.ContextKeys) != 2 || config.ContextKeys[0] != "request_id" || config.ContextKeys[1] != "user_id" {
+		t.Errorf("Expected two context keys, got %v", config.ContextKeys)
+	}
+	if config.ContextGroup != "ctx" {
+		t.Errorf("Expected context group ctx, got %q", config.ContextGroup)
+	}
+	if config.ComponentLevels["db"] != slog.LevelDebug || config.ComponentLevels["*"] != slog.LevelWarn {
+		t.Errorf("Expected component levels, got %v", config.ComponentLevels)
+	}
+
+	// Unset variables keep their defaults
+	if !config.SetAsDefault || config.OtelLoggerName != "my/pkg/name" {
+		t.Errorf("Expected defaults for unset variables, got SetAsDefault=%v OtelLoggerName=%q", config.SetAsDefault, config.OtelLoggerName)
+	}
+}
+
+// TestConfigFromEnv_InvalidValues tests that bad values are reported as FieldErrors
+func TestConfigFromEnv_InvalidValues(t *testing.T) {
+	t.Setenv("LOGGERGO_LEVEL", "verbose")
+	t.Setenv("LOGGERGO_FORMAT", "xml")
+	t.Setenv("LOGGERGO_OUTPUT", "console")
+	t.Setenv("LOGGERGO_DEV_MODE", "maybe")
+
+	_, err := ConfigFromEnv("LOGGERGO_")
+
+	var valErr *types.ValidationError
+	if !errors.As(err, &valErr) {
+		t.Fatalf("Expected *ValidationError, got %v", err)
+	}
+	want := []string{"LOGGERGO_LEVEL", "LOGGERGO_FORMAT", "LOGGERGO_DEV_MODE"}
+	if len(valErr.Errors) != len(want) {
+		t.Fatalf("Expected %d errors, got %v", len(want), valErr.Errors)
+	}
+	for i, field := range want {
+		if valErr.Errors[i].Field != field {
+			t.Errorf("Expected error %d for %s, got %s", i, field, valErr.Errors[i].Field)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package loggergo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/wasilak/loggergo/lib"
+	"github.com/wasilak/loggergo/lib/modes"
+	"github.com/wasilak/loggergo/lib/outputs"
+	"github.com/wasilak/loggergo/lib/types"
+)
+
+// FailoverStatus represents the state of the circuit breaker of an OTEL output with Config.Failover enabled.
+type FailoverStatus struct {
+	Output string `json:"output"` // Output is the name of the sink, or "otel" for Config.Output.
+	outputs.BreakerStatus
+}
+
+// failoverHandler writes records to an OTEL handler while the circuit breaker watching its
+// exports is closed, to a fallback handler while the breaker is open, and to both while the
+// breaker is half-open and the OTEL output is being probed.
+//
+// Every state change is logged to the fallback handler; the return to the closed state is
+// logged to the OTEL handler as well. Like any other record, they are dropped below the
+// configured level.
+//
+// Thread Safety:
+//
+// failoverHandler is safe for concurrent use.
+type failoverHandler struct {
+	primary  slog.Handler
+	fallback slog.Handler
+	breaker  *outputs.CircuitBreaker
+	output   string
+	level    slog.Leveler
+
+	// Handlers without the attributes and groups of the logger, for state change records
+	rootPrimary  slog.Handler
+	rootFallback slog.Handler
+}
+
+// newFailoverHandler creates a handler failing over to fallback with a circuit breaker configured
+// by config. The OTEL handler is set by setPrimary once its exporter has been wrapped with the
+// breaker. output names the output in the status and in the state change records, which are
+// only logged at or above level, as the OTEL handler does not filter by level itself.
+func newFailoverHandler(fallback slog.Handler, config types.FailoverConfig, output string, level slog.Leveler) *failoverHandler {
+	h := &failoverHandler{fallback: fallback, output: output, level: level, rootFallback: fallback}
+	h.breaker = outputs.NewCircuitBreaker(config, h.report)
+	return h
+}
+
+// setPrimary sets the OTEL handler. It must be called before the handler is used.
+func (h *failoverHandler) setPrimary(primary slog.Handler) {
+	h.primary = primary
+	h.rootPrimary = primary
+}
+
+// status returns the state of the circuit breaker.
+func (h *failoverHandler) status() FailoverStatus {
+	return FailoverStatus{Output: h.output, BreakerStatus: h.breaker.Status()}
+}
+
+// Enabled reports whether either handler handles records at the given level.
+func (h *failoverHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.primary.Enabled(ctx, level) || h.fallback.Enabled(ctx, level)
+}
+
+// Handle passes the record to the handlers selected by the state of the circuit breaker.
+func (h *failoverHandler) Handle(ctx context.Context, record slog.Record) error {
+	switch h.breaker.State() {
+	case types.BreakerOpen:
+		return h.handle(ctx, h.fallback, record)
+	case types.BreakerHalfOpen:
+		return errors.Join(h.handle(ctx, h.primary, record.Clone()), h.handle(ctx, h.fallback, record))
+	default:
+		return h.handle(ctx, h.primary, record)
+	}
+}
+
+// WithAttrs returns a new handler with the given attributes added to both handlers.
+func (h *failoverHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.primary = h.primary.WithAttrs(attrs)
+	clone.fallback = h.fallback.WithAttrs(attrs)
+	return &clone
+}
+
+// WithGroup returns a new handler with the given group name opened in both handlers.
+func (h *failoverHandler) WithGroup(name string) slog.Handler {
+	clone := *h
+	clone.primary = h.primary.WithGroup(name)
+	clone.fallback = h.fallback.WithGroup(name)
+	return &clone
+}
+
+// handle passes the record to handler if it is enabled for the record's level.
+func (h *failoverHandler) handle(ctx context.Context, handler slog.Handler, record slog.Record) error {
+	if !handler.Enabled(ctx, record.Level) {
+		return nil
+	}
+	return handler.Handle(ctx, record)
+}
+
+// report logs a state change of the circuit breaker, subject to the level of the handlers.
+func (h *failoverHandler) report(from types.BreakerState, status outputs.BreakerStatus) {
+	level, msg := slog.LevelInfo, "OTEL export recovered, writing records to the OTEL output"
+	switch status.State {
+	case types.BreakerOpen:
+		level, msg = slog.LevelWarn, "OTEL export failing, writing records to the console"
+	case types.BreakerHalfOpen:
+		msg = "probing OTEL export"
+	}
+
+	if h.level != nil && level < h.level.Level() {
+		return
+	}
+
+	record := slog.NewRecord(status.Since, level, msg, 0)
+	record.AddAttrs(
+		slog.String("failover.output", h.output),
+		slog.String("failover.state", status.State.String()),
+		slog.String("failover.previous_state", from.String()),
+		slog.Int("failover.consecutive_failures", status.ConsecutiveFailures),
+	)
+	if status.State == types.BreakerOpen && status.LastError != "" {
+		record.AddAttrs(slog.String("error", status.LastError))
+	}
+
+	ctx := context.Background()
+	h.handle(ctx, h.rootFallback, record.Clone())
+	if status.State == types.BreakerClosed {
+		h.handle(ctx, h.rootPrimary, record)
+	}
+}
+
+// buildFailover creates the OTEL output of the manager's config with a console fallback,
+// as described by config.Failover. The breaker watches the exporter before it is wrapped by wrappers.
+// If OTEL setup fails, only the console handler is returned. Errors are returned as *types.InitError.
+func buildFailover(ctx context.Context, manager *lib.ConfigManager, opts slog.HandlerOptions, output string, wrappers []outputs.ExporterWrapper) (context.Context, slog.Handler, *failoverHandler, error) {
+	cfg := manager.GetConfig()
+
+	consoleHandler, err := modes.ConsoleMode(manager, cfg, opts)
+	if err != nil {
+		return ctx, nil, nil, &types.InitError{
+			Stage:  "handler_creation",
+			Cause:  err,
+			Config: cfg,
+		}
+	}
+
+	failover := newFailoverHandler(consoleHandler, cfg.Failover, output, opts.Level)
+	wrappers = append([]outputs.ExporterWrapper{failover.breaker.WrapExporter}, wrappers...)
+	otelHandler, newCtx, err := modes.OtelMode(ctx, manager, wrappers...)
+	if err != nil {
+		// Graceful degradation: fall back to console mode on OTEL failure
+		fmt.Fprintf(os.Stderr, "WARNING: OTEL initialization failed (%v), falling back to console mode\n", err)
+		return ctx, consoleHandler, nil, nil
+	}
+	failover.setPrimary(otelHandler)
+
+	return newCtx, failover, failover, nil
+}
+
+// Health returns the state of the circuit breaker of every OTEL output of the logger with
+// Config.Failover enabled: Config.Output or the OTEL sinks. It is empty without failover.
+func (l *Logger) Health() []FailoverStatus {
+	state := l.state.Load()
+	if state == nil {
+		return nil
+	}
+	statuses := make([]FailoverStatus, 0, len(state.failovers))
+	for _, failover := range state.failovers {
+		statuses = append(statuses, failover.status())
+	}
+	return statuses
+}
+
+// Health returns the state of the circuit breakers of the logger created by Init.
+// See Logger.Health. It returns nil if Init has not been called.
+func Health() []FailoverStatus {
+	if logger := globalLogger.Load(); logger != nil {
+		return logger.Health()
+	}
+	return nil
+}
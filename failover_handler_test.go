@@ -0,0 +1,197 @@
+package loggergo
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// toggleCollector is a stub OTLP/HTTP collector that fails exports while it is down
+type toggleCollector struct {
+	down      atomic.Bool
+	retryable bool // answer 503 instead of 500 while down
+
+	mu     sync.Mutex
+	bodies [][]byte
+}
+
+// ServeHTTP implements the OTLP/HTTP logs endpoint, answering 500 (not retried) or 503 while down
+func (c *toggleCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if c.down.Load() {
+		code := http.StatusInternalServerError
+		if c.retryable {
+			code = http.StatusServiceUnavailable
+		}
+		http.Error(w, "collector down", code)
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+	c.mu.Lock()
+	c.bodies = append(c.bodies, body)
+	c.mu.Unlock()
+	w.Header().Set("Content-Type", "application/x-protobuf")
+}
+
+// received reports whether an export containing s was received
+func (c *toggleCollector) received(s string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, body := range c.bodies {
+		if bytes.Contains(body, []byte(s)) {
+			return true
+		}
+	}
+	return false
+}
+
+// waitFor polls condition until it is true or the timeout expires
+func waitFor(t *testing.T, what string, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestFailover_ConsoleWhileCollectorDown tests failing over to the console and back as the collector goes down and up
+func TestFailover_ConsoleWhileCollectorDown(t *testing.T) {
+	collector := &toggleCollector{}
+	collector.down.Store(true)
+	server := httptest.NewServer(collector)
+	defer server.Close()
+
+	console := &syncBuffer{}
+	logger, err := New(context.Background(), Config{
+		Level:           slog.LevelInfo,
+		Output:          Types.OutputOtel,
+		OutputStream:    console,
+		OtelLoggerName:  "test",
+		OtelServiceName: "failover-test",
+		SetAsDefault:    false,
+		OTLP: OTLPConfig{
+			Endpoint: server.URL,
+			Protocol: Types.OTLPProtocolHTTP,
+			Batch:    OTLPBatchConfig{ExportInterval: 20 * time.Millisecond},
+		},
+		Failover: FailoverConfig{Enabled: true, FailureThreshold: 1, OpenDuration: 100 * time.Millisecond},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer logger.Shutdown()
+
+	if health := logger.Health(); len(health) != 1 || health[0].Output != "otel" || health[0].State != Types.BreakerClosed {
+		t.Fatalf("Expected a closed breaker for the otel output, got %+v", health)
+	}
+
+	logger.Info("lost while down")
+	waitFor(t, "the breaker to open", func() bool { return logger.Health()[0].State == Types.BreakerOpen })
+	waitFor(t, "the failover record", func() bool { return strings.Contains(console.String(), "OTEL export failing") })
+
+	var health healthResponse
+	if code := serveAdmin(t, logger.AdminHandler(), http.MethodGet, "/health", "", &health); code != http.StatusOK || health.Status != "degraded" || health.Failover[0].LastError == "" {
+		t.Errorf("Expected a degraded health with the export error, got %d %+v", code, health)
+	}
+
+	logger.Info("written to the console")
+	if !strings.Contains(console.String(), "written to the console") {
+		t.Errorf("Expected the record in the console while the breaker is open, got %q", console.String())
+	}
+
+	collector.down.Store(false)
+	time.Sleep(100 * time.Millisecond)
+	logger.Info("probe record")
+	waitFor(t, "the breaker to close", func() bool { return logger.Health()[0].State == Types.BreakerClosed })
+
+	out := console.String()
+	for _, want := range []string{"probing OTEL export", "probe record", "OTEL export recovered"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in the console, got %q", want, out)
+		}
+	}
+	if !collector.received("probe record") {
+		t.Error("Expected the probe record to be exported")
+	}
+
+	written := len(console.String())
+	logger.Info("back to otel")
+	waitFor(t, "the record to be exported", func() bool { return collector.received("back to otel") })
+	if out := console.String()[written:]; out != "" {
+		t.Errorf("Expected no console output with a closed breaker, got %q", out)
+	}
+}
+
+// TestFailover_StateRecordsFollowLevel tests that the state change records are filtered by the configured level
+func TestFailover_StateRecordsFollowLevel(t *testing.T) {
+	tests := []struct {
+		level   slog.Level
+		want    []string
+		notWant []string
+	}{
+		{slog.LevelWarn, []string{"OTEL export failing"}, []string{"probing OTEL export", "OTEL export recovered"}},
+		{slog.LevelError, nil, []string{"OTEL export failing", "probing OTEL export", "OTEL export recovered"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			collector := &toggleCollector{}
+			collector.down.Store(true)
+			server := httptest.NewServer(collector)
+			defer server.Close()
+
+			console := &syncBuffer{}
+			logger, err := New(context.Background(), Config{
+				Level:           tt.level,
+				Output:          Types.OutputOtel,
+				OutputStream:    console,
+				OtelLoggerName:  "test",
+				OtelServiceName: "failover-test",
+				SetAsDefault:    false,
+				OTLP: OTLPConfig{
+					Endpoint: server.URL,
+					Protocol: Types.OTLPProtocolHTTP,
+					Batch:    OTLPBatchConfig{ExportInterval: 20 * time.Millisecond},
+				},
+				Failover: FailoverConfig{Enabled: true, FailureThreshold: 1, OpenDuration: 100 * time.Millisecond},
+			})
+			if err != nil {
+				t.Fatalf("New failed: %v", err)
+			}
+			defer logger.Shutdown()
+
+			logger.Error("lost while down")
+			waitFor(t, "the breaker to open", func() bool { return logger.Health()[0].State == Types.BreakerOpen })
+
+			collector.down.Store(false)
+			time.Sleep(100 * time.Millisecond)
+			logger.Error("probe record")
+			waitFor(t, "the breaker to close", func() bool { return logger.Health()[0].State == Types.BreakerClosed })
+			// Exported after the probe, so the state changes have been reported
+			logger.Error("back to otel")
+			waitFor(t, "the record to be exported", func() bool { return collector.received("back to otel") })
+
+			out := console.String()
+			for _, want := range tt.want {
+				if !strings.Contains(out, want) {
+					t.Errorf("Expected %q in the console, got %q", want, out)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(out, notWant) || collector.received(notWant) {
+					t.Errorf("Expected no %q record at level %v, got %q", notWant, tt.level, out)
+				}
+			}
+		})
+	}
+}
@@ -20,8 +20,12 @@ import (
 	"github.com/wasilak/loggergo/lib/types"
 )
 
-// configManager provides thread-safe access to the global configuration
-type configManager struct {
+// ConfigManager provides thread-safe access to a configuration and its cleanup functions.
+//
+// The package-level functions (InitConfig, GetConfig, MergeConfig, RegisterCleanup, Shutdown, ...)
+// operate on a global ConfigManager used by loggergo.Init. Independent loggers created with
+// loggergo.New own their own ConfigManager, so they never share configuration or cleanup state.
+type ConfigManager struct {
 	mu            sync.RWMutex
 	config        types.Config
 	cleanupFuncs  []func() error
@@ -29,21 +33,28 @@ type configManager struct {
 }
 
 // globalConfigManager is the singleton instance for configuration management
-var globalConfigManager = &configManager{}
+var globalConfigManager = &ConfigManager{}
 
-// InitConfig initializes the global configuration with default values.
-//
-// This function is called automatically by Init() and should not typically be called directly.
-// It sets up sensible defaults for all configuration fields.
+// NewConfigManager creates a ConfigManager initialized with default values.
 //
-// Thread Safety:
+// Example:
 //
-// InitConfig is safe to call concurrently from multiple goroutines.
-func InitConfig() {
-	globalConfigManager.mu.Lock()
-	defer globalConfigManager.mu.Unlock()
-	
-	globalConfigManager.config = types.Config{
+//	manager := lib.NewConfigManager()
+//	manager.MergeConfig(types.Config{Level: slog.LevelDebug})
+func NewConfigManager() *ConfigManager {
+	m := &ConfigManager{}
+	m.InitConfig()
+	return m
+}
+
+// GlobalConfigManager returns the global ConfigManager used by the package-level functions.
+func GlobalConfigManager() *ConfigManager {
+	return globalConfigManager
+}
+
+// DefaultConfig returns a Config populated with the default values used by InitConfig.
+func DefaultConfig() types.Config {
+	return types.Config{
 		Level:              slog.LevelInfo,
 		Format:             types.LogFormatJSON,
 		DevMode:            false,
@@ -59,6 +70,26 @@ func InitConfig() {
 	}
 }
 
+// InitConfig initializes the global configuration with default values.
+//
+// This function is called automatically by Init() and should not typically be called directly.
+// It sets up sensible defaults for all configuration fields.
+//
+// Thread Safety:
+//
+// InitConfig is safe to call concurrently from multiple goroutines.
+func InitConfig() {
+	globalConfigManager.InitConfig()
+}
+
+// InitConfig resets the manager's configuration to default values.
+func (m *ConfigManager) InitConfig() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.config = DefaultConfig()
+}
+
 // GetConfig returns a copy of the current configuration in a thread-safe manner.
 //
 // Thread Safety:
@@ -71,9 +102,14 @@ func InitConfig() {
 //	config := lib.GetConfig()
 //	fmt.Printf("Current log level: %v\n", config.Level)
 func GetConfig() types.Config {
-	globalConfigManager.mu.RLock()
-	defer globalConfigManager.mu.RUnlock()
-	return globalConfigManager.config
+	return globalConfigManager.GetConfig()
+}
+
+// GetConfig returns a copy of the manager's configuration in a thread-safe manner.
+func (m *ConfigManager) GetConfig() types.Config {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.config
 }
 
 // SetConfig sets the configuration in a thread-safe manner.
@@ -95,9 +131,14 @@ func GetConfig() types.Config {
 //	}
 //	lib.SetConfig(newConfig)
 func SetConfig(config types.Config) {
-	globalConfigManager.mu.Lock()
-	defer globalConfigManager.mu.Unlock()
-	globalConfigManager.config = config
+	globalConfigManager.SetConfig(config)
+}
+
+// SetConfig replaces the manager's configuration in a thread-safe manner.
+func (m *ConfigManager) SetConfig(config types.Config) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.config = config
 }
 
 // MergeConfig merges an override configuration with the current base configuration.
@@ -106,9 +147,13 @@ func SetConfig(config types.Config) {
 //   - Non-zero values in override config replace values in base config
 //   - Zero values in override config are ignored (base config values retained)
 //   - For pointer fields (Level, OutputStream), nil values are ignored
-//   - For slice fields (ContextKeys), empty slices are ignored
+//   - For slice and map fields (ContextKeys, ContextExtractors, Sinks, Routes, ComponentLevels), empty values are ignored
 //   - For string fields, empty strings are ignored
 //   - For enum fields (Format, DevFlavor, Output), zero values are ignored
+//   - For File, the whole struct is replaced if File.Path is non-empty
+//   - For Redaction, the whole struct is replaced if Redaction.Rules is non-empty
+//   - For Resource, the whole struct is replaced if any of its fields is set
+//   - For OtelFormat, Syslog, Journald, Async, Sampling, TraceSampling, Failover, Spool and OTLP, the whole struct is replaced if any of its fields is set
 //
 // IMPORTANT - Boolean Field Behavior:
 //   Boolean fields (DevMode, OtelTracingEnabled, SetAsDefault) have special behavior
@@ -135,7 +180,13 @@ func SetConfig(config types.Config) {
 //     - For partial overrides: explicitly set all boolean fields you want to preserve
 //     - Consider using InitConfig() + SetConfig() instead of MergeConfig() for clarity
 func MergeConfig(override types.Config) types.Config {
-	libConfig := GetConfig()
+	return globalConfigManager.MergeConfig(override)
+}
+
+// MergeConfig merges an override configuration with the manager's configuration.
+// See the package-level MergeConfig for the merge precedence rules.
+func (m *ConfigManager) MergeConfig(override types.Config) types.Config {
+	libConfig := m.GetConfig()
 
 	// Enum fields: override if non-zero
 	if override.Format != (types.LogFormat{}) {
@@ -163,6 +214,9 @@ func MergeConfig(override types.Config) types.Config {
 	if override.OtelServiceName != "" {
 		libConfig.OtelServiceName = override.OtelServiceName
 	}
+	if override.ContextGroup != "" {
+		libConfig.ContextGroup = override.ContextGroup
+	}
 
 	// Boolean fields: We need special handling to allow false to override true
 	// We only skip the override if both values are the same (no change intended)
@@ -180,14 +234,64 @@ func MergeConfig(override types.Config) types.Config {
 	if len(override.ContextKeys) > 0 {
 		libConfig.ContextKeys = override.ContextKeys
 	}
+	if len(override.ContextExtractors) > 0 {
+		libConfig.ContextExtractors = override.ContextExtractors
+	}
+	if len(override.Sinks) > 0 {
+		libConfig.Sinks = override.Sinks
+	}
+	if len(override.Routes) > 0 {
+		libConfig.Routes = override.Routes
+	}
 
 	// Interface fields: override if non-nil
 	if override.ContextKeysDefault != nil {
 		libConfig.ContextKeysDefault = override.ContextKeysDefault
 	}
 
-	// Save the merged config back to the global config manager
-	SetConfig(libConfig)
+	// Struct fields: override as a whole if the identifying field is set
+	if override.File.Path != "" {
+		libConfig.File = override.File
+	}
+	if override.OtelFormat != (types.OtelFormatConfig{}) {
+		libConfig.OtelFormat = override.OtelFormat
+	}
+	if override.Syslog != (types.SyslogConfig{}) {
+		libConfig.Syslog = override.Syslog
+	}
+	if override.Journald != (types.JournaldConfig{}) {
+		libConfig.Journald = override.Journald
+	}
+	if override.Async != (types.AsyncConfig{}) {
+		libConfig.Async = override.Async
+	}
+	if override.Sampling != (types.SamplingConfig{}) {
+		libConfig.Sampling = override.Sampling
+	}
+	if override.TraceSampling != (types.TraceSamplingConfig{}) {
+		libConfig.TraceSampling = override.TraceSampling
+	}
+	if len(override.Redaction.Rules) > 0 {
+		libConfig.Redaction = override.Redaction
+	}
+	if override.Failover != (types.FailoverConfig{}) {
+		libConfig.Failover = override.Failover
+	}
+	if override.Spool != (types.SpoolConfig{}) {
+		libConfig.Spool = override.Spool
+	}
+	if !override.OTLP.IsZero() {
+		libConfig.OTLP = override.OTLP
+	}
+	if !override.Resource.IsZero() {
+		libConfig.Resource = override.Resource
+	}
+	if len(override.ComponentLevels) > 0 {
+		libConfig.ComponentLevels = override.ComponentLevels
+	}
+
+	// Save the merged config back to the config manager
+	m.SetConfig(libConfig)
 	
 	return libConfig
 }
@@ -207,9 +311,14 @@ func MergeConfig(override types.Config) types.Config {
 //	    return provider.Shutdown(context.Background())
 //	})
 func RegisterCleanup(cleanup func() error) {
-	globalConfigManager.cleanupMu.Lock()
-	defer globalConfigManager.cleanupMu.Unlock()
-	globalConfigManager.cleanupFuncs = append(globalConfigManager.cleanupFuncs, cleanup)
+	globalConfigManager.RegisterCleanup(cleanup)
+}
+
+// RegisterCleanup registers a cleanup function to be called during the manager's Shutdown.
+func (m *ConfigManager) RegisterCleanup(cleanup func() error) {
+	m.cleanupMu.Lock()
+	defer m.cleanupMu.Unlock()
+	m.cleanupFuncs = append(m.cleanupFuncs, cleanup)
 }
 
 // Shutdown performs cleanup of all registered resources.
@@ -236,21 +345,26 @@ func RegisterCleanup(cleanup func() error) {
 //	
 //	// Use logger...
 func Shutdown() error {
-	globalConfigManager.cleanupMu.Lock()
-	defer globalConfigManager.cleanupMu.Unlock()
-	
+	return globalConfigManager.Shutdown()
+}
+
+// Shutdown calls the manager's cleanup functions in reverse order of registration.
+func (m *ConfigManager) Shutdown() error {
+	m.cleanupMu.Lock()
+	defer m.cleanupMu.Unlock()
+
 	var errors []error
-	
+
 	// Call cleanup functions in reverse order (LIFO)
-	for i := len(globalConfigManager.cleanupFuncs) - 1; i >= 0; i-- {
-		if err := globalConfigManager.cleanupFuncs[i](); err != nil {
+	for i := len(m.cleanupFuncs) - 1; i >= 0; i-- {
+		if err := m.cleanupFuncs[i](); err != nil {
 			errors = append(errors, err)
 		}
 	}
-	
+
 	// Clear cleanup functions after execution
-	globalConfigManager.cleanupFuncs = nil
-	
+	m.cleanupFuncs = nil
+
 	if len(errors) > 0 {
 		return fmt.Errorf("shutdown errors: %v", errors)
 	}
@@ -726,3 +726,39 @@ func TestShutdown_EmptyCleanupList(t *testing.T) {
 		t.Errorf("Shutdown returned error with empty cleanup list: %v", err)
 	}
 }
+
+// TestConfigManager_Isolation tests that independent config managers do not share state
+func TestConfigManager_Isolation(t *testing.T) {
+	InitConfig()
+
+	first := NewConfigManager()
+	second := NewConfigManager()
+
+	first.MergeConfig(types.Config{Level: slog.LevelDebug, OtelServiceName: "first", SetAsDefault: true})
+	second.MergeConfig(types.Config{Level: slog.LevelError, OtelServiceName: "second", SetAsDefault: true})
+
+	if first.GetConfig().OtelServiceName != "first" || second.GetConfig().OtelServiceName != "second" {
+		t.Errorf("Expected managers to keep their own config, got %q and %q",
+			first.GetConfig().OtelServiceName, second.GetConfig().OtelServiceName)
+	}
+	if GetConfig().OtelServiceName != DefaultConfig().OtelServiceName {
+		t.Errorf("Expected global config to be untouched, got OtelServiceName %q", GetConfig().OtelServiceName)
+	}
+
+	var calls []string
+	first.RegisterCleanup(func() error {
+		calls = append(calls, "first")
+		return nil
+	})
+	second.RegisterCleanup(func() error {
+		calls = append(calls, "second")
+		return nil
+	})
+
+	if err := first.Shutdown(); err != nil {
+		t.Errorf("Shutdown returned error: %v", err)
+	}
+	if len(calls) != 1 || calls[0] != "first" {
+		t.Errorf("Expected only first manager's cleanup to run, got %v", calls)
+	}
+}
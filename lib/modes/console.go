@@ -1,40 +1,53 @@
 package modes
 
 import (
+	"fmt"
 	"log/slog"
 
 	"github.com/wasilak/loggergo/lib"
 	"github.com/wasilak/loggergo/lib/outputs"
 	"github.com/wasilak/loggergo/lib/types"
-	otelgoslog "github.com/wasilak/otelgo/slog"
 )
 
-// consoleMode returns a slog.Handler based on the provided defaultConfig and opts.
-// It checks the defaultConfig.Format and sets up the appropriate handler based on the format.
-// If defaultConfig.OtelTracingEnabled is true, it wraps the handler with otelgoslog.NewTracingHandler.
+// consoleMode returns a slog.Handler based on config and opts, writing to config.OutputStream.
+// The config is passed explicitly, so callers such as FileMode can write to their own writer
+// without changing the manager's config; the manager only receives the cleanup functions.
+// It checks the config.Format and sets up the appropriate handler based on the format.
+// If config.OtelTracingEnabled is true, it wraps the handler with otelgoslog.NewTracingHandler
+// and, if config.TraceSampling.Enabled is set, keeps Debug and Info records only for sampled spans.
 // Returns the handler and any error encountered.
-func ConsoleMode(opts slog.HandlerOptions) (slog.Handler, error) {
+func ConsoleMode(manager *lib.ConfigManager, config types.Config, opts slog.HandlerOptions) (slog.Handler, error) {
 	var handler slog.Handler
 	var err error
 
-	if lib.GetConfig().Format == types.LogFormatOtel {
-		return outputs.SetupOtelFormat()
-	}
-
-	if lib.GetConfig().Format == types.LogFormatJSON {
-		handler = slog.NewJSONHandler(lib.GetConfig().OutputStream, &opts)
+	// Files named in a configuration file are opened by the logger, so it closes them too.
+	// They are opened here rather than on the first write, so a bad path fails the setup.
+	if target, ok := config.OutputStream.(*types.FileTarget); ok {
+		if err := target.Open(); err != nil {
+			return nil, fmt.Errorf("failed to open output stream: %w", err)
+		}
+		manager.RegisterCleanup(target.Close)
 	}
 
-	if lib.GetConfig().Format == types.LogFormatText {
-		handler, err = outputs.SetupPlainFormat(opts)
+	if config.Format == types.LogFormatOtel {
+		handler, err = outputs.SetupOtelFormat(manager, config, opts)
 		if err != nil {
 			return nil, err
 		}
+		// The OTel bridge reads the span from the context itself, so only trace sampling applies
+		return withTraceSampling(handler, config), nil
+	}
+
+	if config.Format == types.LogFormatJSON {
+		handler = slog.NewJSONHandler(config.OutputStream, &opts)
 	}
 
-	if lib.GetConfig().OtelTracingEnabled {
-		handler = otelgoslog.NewTracingHandler(handler)
+	if config.Format == types.LogFormatText {
+		handler, err = outputs.SetupPlainFormat(config, opts)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	return handler, nil
+	return withTracing(handler, config), nil
 }
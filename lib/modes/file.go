@@ -0,0 +1,38 @@
+package modes
+
+import (
+	"log/slog"
+
+	"github.com/wasilak/loggergo/lib"
+	"github.com/wasilak/loggergo/lib/outputs"
+)
+
+// FileMode returns a slog.Handler writing to a rotating file described by the manager's config.File.
+// It opens the file and builds the handler with ConsoleMode writing to it, so Format, DevMode and
+// OtelTracingEnabled apply unchanged. The manager's config keeps its own OutputStream.
+// If config.File.ReopenOnSignal is true, the file is reopened whenever the process receives SIGHUP.
+// The file and the signal watcher are closed by the manager's Shutdown.
+// Returns the handler and any error encountered.
+func FileMode(manager *lib.ConfigManager, opts slog.HandlerOptions) (slog.Handler, error) {
+	config := manager.GetConfig()
+
+	file, err := outputs.NewRotatingFile(config.File)
+	if err != nil {
+		return nil, err
+	}
+
+	manager.RegisterCleanup(file.Close)
+
+	if config.File.ReopenOnSignal {
+		// Registered after file.Close, so the watcher is stopped before the file is closed (LIFO)
+		stop := outputs.WatchReopenSignal(file)
+		manager.RegisterCleanup(func() error {
+			stop()
+			return nil
+		})
+	}
+
+	config.OutputStream = file
+
+	return ConsoleMode(manager, config, opts)
+}
@@ -0,0 +1,27 @@
+package modes
+
+import (
+	"log/slog"
+
+	"github.com/wasilak/loggergo/lib"
+	"github.com/wasilak/loggergo/lib/outputs"
+)
+
+// JournaldMode returns a slog.Handler sending records to systemd-journald as described by the manager's config.Journald.
+// The SYSLOG_IDENTIFIER defaults to config.OtelServiceName.
+// If config.OtelTracingEnabled is true, it wraps the handler with otelgoslog.NewTracingHandler
+// and, if config.TraceSampling.Enabled is set, keeps Debug and Info records only for sampled spans.
+// The connection is closed by the manager's Shutdown.
+// Returns the handler and any error encountered.
+func JournaldMode(manager *lib.ConfigManager, opts slog.HandlerOptions) (slog.Handler, error) {
+	config := manager.GetConfig()
+
+	journaldHandler, err := outputs.NewJournaldHandler(config.Journald, config.OtelServiceName, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	manager.RegisterCleanup(journaldHandler.Close)
+
+	return withTracing(journaldHandler, config), nil
+}
@@ -5,17 +5,30 @@ import (
 	"log/slog"
 
 	"github.com/wasilak/loggergo/lib"
+	"github.com/wasilak/loggergo/lib/outputs"
 	otellogs "github.com/wasilak/otelgo/logs"
 	"go.opentelemetry.io/contrib/bridges/otelslog"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
 )
 
-// otelMode returns a slog.Handler for OpenTelemetry mode based on the provided defaultConfig.
-// It initializes the otellogs package and returns a handler with the otelslog.WithLoggerProvider option.
+// otelMode returns a slog.Handler for OpenTelemetry mode based on the manager's config.
+// It creates a provider exporting as described by config.OTLP with the resource described by config.Resource,
+// with the exporter wrapped by wrappers, or initializes the otellogs package if all of them are empty,
+// and returns a handler with the otelslog.WithLoggerProvider option.
+// If config.OtelTracingEnabled and config.TraceSampling.Enabled are set, Debug and Info records are kept only for sampled spans.
+// The provider shutdown is registered as a cleanup function on the manager.
 // Returns the handler and any error encountered.
-func OtelMode(ctx context.Context) (slog.Handler, context.Context, error) {
-	otelGoLogsConfig := otellogs.OtelGoLogsConfig{}
+func OtelMode(ctx context.Context, manager *lib.ConfigManager, wrappers ...outputs.ExporterWrapper) (slog.Handler, context.Context, error) {
+	config := manager.GetConfig()
 
-	ctx, provider, err := otellogs.Init(ctx, otelGoLogsConfig)
+	var provider *sdklog.LoggerProvider
+	var err error
+	if config.OTLP.IsZero() && config.Resource.IsZero() && len(wrappers) == 0 {
+		// Without OTLP and resource settings the exporter is configured by OTEL_EXPORTER_OTLP_* variables
+		ctx, provider, err = otellogs.Init(ctx, otellogs.OtelGoLogsConfig{})
+	} else {
+		provider, err = outputs.NewOTLPProvider(ctx, config, wrappers...)
+	}
 	if err != nil {
 		return nil, ctx, err
 	}
@@ -23,7 +36,7 @@ func OtelMode(ctx context.Context) (slog.Handler, context.Context, error) {
 	// Register cleanup for OTEL provider
 	// The provider has a Shutdown method that needs to be called
 	// to flush any pending logs and release resources
-	lib.RegisterCleanup(func() error {
+	manager.RegisterCleanup(func() error {
 		// Use a background context for shutdown as the original context may be cancelled
 		shutdownCtx := context.Background()
 		if provider != nil {
@@ -32,5 +45,7 @@ func OtelMode(ctx context.Context) (slog.Handler, context.Context, error) {
 		return nil
 	})
 
-	return otelslog.NewHandler(lib.GetConfig().OtelLoggerName, otelslog.WithLoggerProvider(provider)), ctx, nil
+	handler := otelslog.NewHandler(config.OtelLoggerName, otelslog.WithLoggerProvider(provider))
+
+	return withTraceSampling(handler, config), ctx, nil
 }
@@ -0,0 +1,27 @@
+package modes
+
+import (
+	"log/slog"
+
+	"github.com/wasilak/loggergo/lib"
+	"github.com/wasilak/loggergo/lib/outputs"
+)
+
+// SyslogMode returns a slog.Handler sending records to the syslog server described by the manager's config.Syslog.
+// The APP-NAME defaults to config.OtelServiceName.
+// If config.OtelTracingEnabled is true, it wraps the handler with otelgoslog.NewTracingHandler
+// and, if config.TraceSampling.Enabled is set, keeps Debug and Info records only for sampled spans.
+// The connection is closed by the manager's Shutdown.
+// Returns the handler and any error encountered.
+func SyslogMode(manager *lib.ConfigManager, opts slog.HandlerOptions) (slog.Handler, error) {
+	config := manager.GetConfig()
+
+	syslogHandler, err := outputs.NewSyslogHandler(config.Syslog, config.OtelServiceName, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	manager.RegisterCleanup(syslogHandler.Close)
+
+	return withTracing(syslogHandler, config), nil
+}
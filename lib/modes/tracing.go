@@ -0,0 +1,29 @@
+package modes
+
+import (
+	"log/slog"
+
+	"github.com/wasilak/loggergo/lib/outputs"
+	"github.com/wasilak/loggergo/lib/types"
+	otelgoslog "github.com/wasilak/otelgo/slog"
+)
+
+// withTracing wraps the handler with otelgoslog.NewTracingHandler if config.OtelTracingEnabled is true,
+// and with an outputs.TraceSamplingHandler if config.TraceSampling.Enabled is also true.
+func withTracing(handler slog.Handler, config types.Config) slog.Handler {
+	if !config.OtelTracingEnabled {
+		return handler
+	}
+
+	handler = otelgoslog.NewTracingHandler(handler)
+	return withTraceSampling(handler, config)
+}
+
+// withTraceSampling wraps the handler with an outputs.TraceSamplingHandler if both
+// config.OtelTracingEnabled and config.TraceSampling.Enabled are true.
+func withTraceSampling(handler slog.Handler, config types.Config) slog.Handler {
+	if !config.OtelTracingEnabled || !config.TraceSampling.Enabled {
+		return handler
+	}
+	return outputs.NewTraceSamplingHandler(handler, config.TraceSampling)
+}
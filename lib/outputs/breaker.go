@@ -0,0 +1,151 @@
+package outputs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/wasilak/loggergo/lib/types"
+	"go.opentelemetry.io/otel/sdk/log"
+)
+
+// Defaults of FailoverConfig.
+const (
+	defaultFailureThreshold = 3
+	defaultOpenDuration     = 30 * time.Second
+)
+
+// BreakerStatus is a snapshot of a CircuitBreaker.
+type BreakerStatus struct {
+	State               types.BreakerState `json:"state"`
+	Since               time.Time          `json:"since"`                // Since is when the breaker entered State.
+	ConsecutiveFailures int                `json:"consecutive_failures"` // ConsecutiveFailures is the number of failed exports since the last successful one.
+	LastError           string             `json:"last_error,omitempty"` // LastError is the error of the last failed export.
+}
+
+// CircuitBreaker tracks the results of the exports of an OTLP exporter as described by
+// types.FailoverConfig and decides whether records are written to the OTEL output.
+//
+// The breaker is safe for concurrent use. onChange is called on every state change, without
+// any lock held, from the goroutine that caused it.
+type CircuitBreaker struct {
+	failureThreshold int
+	latencyThreshold time.Duration
+	openDuration     time.Duration
+	onChange         func(from types.BreakerState, status BreakerStatus)
+	now              func() time.Time
+
+	mu     sync.Mutex
+	status BreakerStatus
+}
+
+// NewCircuitBreaker creates a closed circuit breaker with the given settings, calling onChange
+// (if non-nil) on every state change.
+func NewCircuitBreaker(config types.FailoverConfig, onChange func(from types.BreakerState, status BreakerStatus)) *CircuitBreaker {
+	b := &CircuitBreaker{
+		failureThreshold: config.FailureThreshold,
+		latencyThreshold: config.LatencyThreshold,
+		openDuration:     config.OpenDuration,
+		onChange:         onChange,
+		now:              time.Now,
+	}
+	if b.failureThreshold == 0 {
+		b.failureThreshold = defaultFailureThreshold
+	}
+	if b.openDuration == 0 {
+		b.openDuration = defaultOpenDuration
+	}
+	b.status = BreakerStatus{State: types.BreakerClosed, Since: b.now()}
+	return b
+}
+
+// State returns the state of the breaker. An open breaker becomes half-open once OpenDuration
+// has elapsed, so State is also what starts probing the OTEL output.
+func (b *CircuitBreaker) State() types.BreakerState {
+	b.mu.Lock()
+	if b.status.State != types.BreakerOpen || b.now().Sub(b.status.Since) < b.openDuration {
+		defer b.mu.Unlock()
+		return b.status.State
+	}
+	from, status := b.setState(types.BreakerHalfOpen)
+	b.mu.Unlock()
+
+	b.notify(from, status)
+	return status.State
+}
+
+// Status returns a snapshot of the breaker.
+func (b *CircuitBreaker) Status() BreakerStatus {
+	b.State() // applies a pending transition to half-open
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.status
+}
+
+// Record records the result of an export that took latency. A successful export closes the
+// breaker; a failed one opens it if it is half-open or if FailureThreshold is reached.
+func (b *CircuitBreaker) Record(err error, latency time.Duration) {
+	if err == nil && b.latencyThreshold > 0 && latency > b.latencyThreshold {
+		err = fmt.Errorf("export took %s, more than the latency threshold of %s", latency, b.latencyThreshold)
+	}
+
+	b.mu.Lock()
+	var from types.BreakerState
+	var status BreakerStatus
+	changed := false
+	if err == nil {
+		b.status.ConsecutiveFailures = 0
+		if b.status.State != types.BreakerClosed {
+			from, status = b.setState(types.BreakerClosed)
+			changed = true
+		}
+	} else {
+		b.status.ConsecutiveFailures++
+		b.status.LastError = err.Error()
+		if b.status.State == types.BreakerHalfOpen ||
+			(b.status.State == types.BreakerClosed && b.status.ConsecutiveFailures >= b.failureThreshold) {
+			from, status = b.setState(types.BreakerOpen)
+			changed = true
+		}
+	}
+	b.mu.Unlock()
+
+	if changed {
+		b.notify(from, status)
+	}
+}
+
+// WrapExporter returns an exporter recording the result and duration of every export of exporter.
+func (b *CircuitBreaker) WrapExporter(exporter log.Exporter) log.Exporter {
+	return &breakerExporter{Exporter: exporter, breaker: b}
+}
+
+// setState changes the state and returns the previous state and the new status. Must be called with mu locked.
+func (b *CircuitBreaker) setState(state types.BreakerState) (types.BreakerState, BreakerStatus) {
+	from := b.status.State
+	b.status.State = state
+	b.status.Since = b.now()
+	return from, b.status
+}
+
+// notify calls onChange, if set.
+func (b *CircuitBreaker) notify(from types.BreakerState, status BreakerStatus) {
+	if b.onChange != nil {
+		b.onChange(from, status)
+	}
+}
+
+// breakerExporter reports the exports of the embedded exporter to a circuit breaker.
+type breakerExporter struct {
+	log.Exporter
+	breaker *CircuitBreaker
+}
+
+// Export exports the records and records the result and duration with the breaker.
+func (e *breakerExporter) Export(ctx context.Context, records []log.Record) error {
+	start := time.Now()
+	err := e.Exporter.Export(ctx, records)
+	e.breaker.Record(err, time.Since(start))
+	return err
+}
@@ -0,0 +1,79 @@
+package outputs
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/wasilak/loggergo/lib/types"
+)
+
+// TestCircuitBreaker_Transitions tests opening after the failure threshold, probing after OpenDuration and closing on success
+func TestCircuitBreaker_Transitions(t *testing.T) {
+	now := time.Unix(0, 0)
+	var changes []string
+	breaker := NewCircuitBreaker(types.FailoverConfig{FailureThreshold: 2, OpenDuration: time.Minute}, func(from types.BreakerState, status BreakerStatus) {
+		changes = append(changes, from.String()+">"+status.State.String())
+	})
+	breaker.now = func() time.Time { return now }
+
+	exportErr := errors.New("connection refused")
+	breaker.Record(exportErr, time.Millisecond)
+	if state := breaker.State(); state != types.BreakerClosed {
+		t.Fatalf("Expected closed below the threshold, got %s", state)
+	}
+	breaker.Record(exportErr, time.Millisecond)
+	status := breaker.Status()
+	if status.State != types.BreakerOpen || status.ConsecutiveFailures != 2 || status.LastError != "connection refused" {
+		t.Fatalf("Expected open after 2 failures, got %+v", status)
+	}
+
+	now = now.Add(59 * time.Second)
+	if state := breaker.State(); state != types.BreakerOpen {
+		t.Fatalf("Expected open before OpenDuration, got %s", state)
+	}
+	now = now.Add(time.Second)
+	if state := breaker.State(); state != types.BreakerHalfOpen {
+		t.Fatalf("Expected half-open after OpenDuration, got %s", state)
+	}
+
+	// A failed probe reopens the breaker at once
+	breaker.Record(exportErr, time.Millisecond)
+	if state := breaker.State(); state != types.BreakerOpen {
+		t.Fatalf("Expected open after a failed probe, got %s", state)
+	}
+
+	now = now.Add(time.Minute)
+	breaker.State()
+	breaker.Record(nil, time.Millisecond)
+	if status := breaker.Status(); status.State != types.BreakerClosed || status.ConsecutiveFailures != 0 || !status.Since.Equal(now) {
+		t.Fatalf("Expected closed after a successful probe, got %+v", status)
+	}
+
+	want := []string{"closed>open", "open>half_open", "half_open>open", "open>half_open", "half_open>closed"}
+	if len(changes) != len(want) {
+		t.Fatalf("Expected changes %v, got %v", want, changes)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("Expected changes %v, got %v", want, changes)
+			break
+		}
+	}
+}
+
+// TestCircuitBreaker_Latency tests that slow exports count as failures
+func TestCircuitBreaker_Latency(t *testing.T) {
+	breaker := NewCircuitBreaker(types.FailoverConfig{FailureThreshold: 1, LatencyThreshold: time.Second}, nil)
+
+	breaker.Record(nil, time.Second)
+	if state := breaker.State(); state != types.BreakerClosed {
+		t.Fatalf("Expected closed at the latency threshold, got %s", state)
+	}
+
+	breaker.Record(nil, 2*time.Second)
+	status := breaker.Status()
+	if status.State != types.BreakerOpen || status.LastError == "" {
+		t.Fatalf("Expected open after a slow export, got %+v", status)
+	}
+}
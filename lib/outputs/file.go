@@ -0,0 +1,364 @@
+package outputs
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wasilak/loggergo/lib/types"
+)
+
+// backupTimeFormat is the timestamp layout used in rotated file names.
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// compressSuffix is appended to rotated files that have been gzipped.
+const compressSuffix = ".gz"
+
+// timeNow is used to get the current time; it is replaced in tests.
+var timeNow = time.Now
+
+// RotatingFile is an io.WriteCloser that writes to a file and rotates it by size and/or time.
+//
+// Rotated files are renamed to <name>-<timestamp><ext> next to the active file. Retention
+// (MaxBackups, MaxAge) and compression of rotated files are handled in a background goroutine
+// that is stopped by Close.
+//
+// Thread Safety:
+//
+// RotatingFile is safe for concurrent use. Each Write is written to the file as a whole,
+// so records from concurrent writers are never interleaved.
+type RotatingFile struct {
+	config types.FileConfig
+
+	mu           sync.Mutex
+	file         *os.File
+	size         int64
+	nextRotation time.Time
+	closed       bool
+
+	millCh   chan struct{}
+	millDone chan struct{}
+}
+
+// NewRotatingFile opens (or creates) the file described by config and returns a RotatingFile writing to it.
+// Parent directories are created if they don't exist.
+func NewRotatingFile(config types.FileConfig) (*RotatingFile, error) {
+	if config.Path == "" {
+		return nil, fmt.Errorf("file output requires a path")
+	}
+
+	r := &RotatingFile{
+		config:   config,
+		millCh:   make(chan struct{}, 1),
+		millDone: make(chan struct{}),
+	}
+
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+
+	go r.mill()
+
+	return r, nil
+}
+
+// Write writes p to the file, rotating it first if the write would exceed MaxSize
+// or if RotationInterval has elapsed.
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return 0, os.ErrClosed
+	}
+
+	// If rotation fails, the record is still written to the current file and the error returned
+	var rotateErr error
+	if r.shouldRotate(int64(len(p))) {
+		rotateErr = r.rotate()
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	if err == nil {
+		err = rotateErr
+	}
+	return n, err
+}
+
+// Rotate renames the current file with a timestamp suffix, opens a new file at Path and closes the old one.
+func (r *RotatingFile) Rotate() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return os.ErrClosed
+	}
+
+	return r.rotate()
+}
+
+// Reopen opens Path again and atomically swaps it in place of the current file.
+//
+// It is meant for external rotation tools (e.g. logrotate without copytruncate) that
+// move the file away: after Reopen, records go to a new file at Path. The new file is
+// opened before the old one is closed, so no record is lost; if opening fails, writing
+// continues to the old file and the error is returned.
+func (r *RotatingFile) Reopen() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return os.ErrClosed
+	}
+
+	old := r.file
+	if err := r.open(); err != nil {
+		return err
+	}
+
+	return old.Close()
+}
+
+// Close closes the file and waits for pending compression and cleanup of rotated files.
+// Calling Close more than once is safe.
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return nil
+	}
+	r.closed = true
+	err := r.file.Close()
+	close(r.millCh)
+	r.mu.Unlock()
+
+	<-r.millDone
+	return err
+}
+
+// open opens the file at Path in append mode and makes it the current file on success.
+// Must be called with mu held.
+func (r *RotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(r.config.Path), 0o755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(r.config.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	r.file = file
+	r.size = info.Size()
+	if r.config.RotationInterval > 0 {
+		r.nextRotation = timeNow().Add(r.config.RotationInterval)
+	}
+
+	return nil
+}
+
+// shouldRotate reports whether the file must be rotated before writing n bytes. Must be called with mu held.
+func (r *RotatingFile) shouldRotate(n int64) bool {
+	if r.config.MaxSize > 0 && r.size > 0 && r.size+n > r.config.MaxSize {
+		return true
+	}
+	if r.config.RotationInterval > 0 && !timeNow().Before(r.nextRotation) {
+		return true
+	}
+	return false
+}
+
+// rotate renames the current file, opens a new one and only then closes the old one, as Reopen
+// does. If renaming or opening fails, the old file stays the current one, so writing continues
+// and the next write retries the rotation. Must be called with mu held.
+func (r *RotatingFile) rotate() error {
+	old := r.file
+	backup := r.backupName(timeNow())
+	if err := os.Rename(r.config.Path, backup); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+
+	if err := r.open(); err != nil {
+		// Move the file back, so records keep going to Path
+		os.Rename(backup, r.config.Path)
+		return err
+	}
+
+	closeErr := old.Close()
+
+	// Signal the mill without blocking; a pending signal covers this rotation too
+	select {
+	case r.millCh <- struct{}{}:
+	default:
+	}
+
+	if closeErr != nil {
+		return fmt.Errorf("failed to close rotated log file: %w", closeErr)
+	}
+	return nil
+}
+
+// backupName returns an unused name for a file rotated at t.
+func (r *RotatingFile) backupName(t time.Time) string {
+	dir, prefix, ext := r.nameParts()
+	for {
+		name := filepath.Join(dir, prefix+t.Format(backupTimeFormat)+ext)
+		if !exists(name) && !exists(name+compressSuffix) {
+			return name
+		}
+		// Several rotations within the same millisecond: move to the next free timestamp
+		t = t.Add(time.Millisecond)
+	}
+}
+
+// nameParts splits Path into its directory, the backup prefix ("app-") and the extension (".log").
+func (r *RotatingFile) nameParts() (dir, prefix, ext string) {
+	dir = filepath.Dir(r.config.Path)
+	base := filepath.Base(r.config.Path)
+	ext = filepath.Ext(base)
+	return dir, strings.TrimSuffix(base, ext) + "-", ext
+}
+
+// mill compresses and removes rotated files each time it is signalled, until millCh is closed.
+func (r *RotatingFile) mill() {
+	defer close(r.millDone)
+	for range r.millCh {
+		if err := r.processBackups(); err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: failed to process rotated log files: %v\n", err)
+		}
+	}
+}
+
+// backup describes a rotated file.
+type backup struct {
+	path      string
+	timestamp time.Time
+}
+
+// processBackups applies compression and the MaxBackups/MaxAge retention to rotated files.
+func (r *RotatingFile) processBackups() error {
+	backups, err := r.listBackups()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	var keep []backup
+
+	cutoff := time.Time{}
+	if r.config.MaxAge > 0 {
+		cutoff = timeNow().Add(-r.config.MaxAge)
+	}
+
+	for i, b := range backups {
+		expired := (r.config.MaxBackups > 0 && i >= r.config.MaxBackups) ||
+			(!cutoff.IsZero() && b.timestamp.Before(cutoff))
+		if expired {
+			if err := os.Remove(b.path); err != nil && !os.IsNotExist(err) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		keep = append(keep, b)
+	}
+
+	if r.config.Compress {
+		for _, b := range keep {
+			if strings.HasSuffix(b.path, compressSuffix) {
+				continue
+			}
+			if err := compressFile(b.path); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%v", errs)
+	}
+	return nil
+}
+
+// listBackups returns the rotated files belonging to Path, newest first.
+func (r *RotatingFile) listBackups() ([]backup, error) {
+	dir, prefix, ext := r.nameParts()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []backup
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), compressSuffix)
+		if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		ts, err := time.ParseInLocation(backupTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext), time.Local)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backup{path: filepath.Join(dir, entry.Name()), timestamp: ts})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].timestamp.After(backups[j].timestamp)
+	})
+
+	return backups, nil
+}
+
+// compressFile gzips path into path.gz and removes the original file.
+func compressFile(path string) (err error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+compressSuffix, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			dst.Close()
+			os.Remove(path + compressSuffix)
+		}
+	}()
+
+	gz := gzip.NewWriter(dst)
+	if _, err = io.Copy(gz, src); err != nil {
+		return err
+	}
+	if err = gz.Close(); err != nil {
+		return err
+	}
+	if err = dst.Close(); err != nil {
+		return err
+	}
+
+	return os.Remove(path)
+}
+
+// exists reports whether a file exists at path.
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
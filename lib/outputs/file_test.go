@@ -0,0 +1,264 @@
+package outputs
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/wasilak/loggergo/lib/types"
+)
+
+// listDir returns the names of the files in dir
+func listDir(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir failed: %v", err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	return names
+}
+
+// TestRotatingFile_SizeRotation tests that the file is rotated when MaxSize would be exceeded
+func TestRotatingFile_SizeRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	r, err := NewRotatingFile(types.FileConfig{Path: path, MaxSize: 20})
+	if err != nil {
+		t.Fatalf("NewRotatingFile failed: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := r.Write([]byte("0123456789abcde\n")); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	names := listDir(t, dir)
+	if len(names) != 3 {
+		t.Fatalf("Expected active file and 2 backups, got %v", names)
+	}
+	for _, name := range names {
+		if name != "app.log" && (!strings.HasPrefix(name, "app-") || !strings.HasSuffix(name, ".log")) {
+			t.Errorf("Unexpected file name %q", name)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if string(data) != "0123456789abcde\n" {
+		t.Errorf("Expected active file to hold only the last record, got %q", data)
+	}
+}
+
+// TestRotatingFile_MaxBackupsAndCompress tests retention by count and gzip compression of rotated files
+func TestRotatingFile_MaxBackupsAndCompress(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	r, err := NewRotatingFile(types.FileConfig{Path: path, MaxBackups: 2, Compress: true})
+	if err != nil {
+		t.Fatalf("NewRotatingFile failed: %v", err)
+	}
+
+	for i := 0; i < 4; i++ {
+		if _, err := r.Write([]byte("record\n")); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+		if err := r.Rotate(); err != nil {
+			t.Fatalf("Rotate failed: %v", err)
+		}
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	names := listDir(t, dir)
+	var compressed []string
+	for _, name := range names {
+		if strings.HasSuffix(name, ".gz") {
+			compressed = append(compressed, name)
+		} else if name != "app.log" {
+			t.Errorf("Expected rotated file %q to be compressed", name)
+		}
+	}
+	if len(compressed) != 2 {
+		t.Fatalf("Expected 2 compressed backups, got %v", names)
+	}
+
+	f, err := os.Open(filepath.Join(dir, compressed[0]))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("gzip.NewReader failed: %v", err)
+	}
+	data, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatalf("ReadAll failed: %v", err)
+	}
+	if string(data) != "record\n" {
+		t.Errorf("Expected compressed content %q, got %q", "record\n", data)
+	}
+}
+
+// TestRotatingFile_TimeRotationAndMaxAge tests rotation by interval and removal of old backups
+func TestRotatingFile_TimeRotationAndMaxAge(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.Local)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	// A backup older than MaxAge that should be removed after the next rotation
+	old := filepath.Join(dir, "app-"+now.Add(-48*time.Hour).Format(backupTimeFormat)+".log")
+	if err := os.WriteFile(old, []byte("old\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	r, err := NewRotatingFile(types.FileConfig{Path: path, RotationInterval: time.Hour, MaxAge: 24 * time.Hour})
+	if err != nil {
+		t.Fatalf("NewRotatingFile failed: %v", err)
+	}
+
+	r.Write([]byte("first\n"))
+	now = now.Add(30 * time.Minute)
+	r.Write([]byte("second\n"))
+	now = now.Add(31 * time.Minute)
+	r.Write([]byte("third\n"))
+
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	names := listDir(t, dir)
+	if len(names) != 2 {
+		t.Fatalf("Expected active file and one backup, got %v", names)
+	}
+	if _, err := os.Stat(old); !os.IsNotExist(err) {
+		t.Error("Expected backup older than MaxAge to be removed")
+	}
+
+	data, _ := os.ReadFile(path)
+	if string(data) != "third\n" {
+		t.Errorf("Expected active file to hold %q, got %q", "third\n", data)
+	}
+}
+
+// TestRotatingFile_ConcurrentWrites tests that concurrent writes are not lost or interleaved
+func TestRotatingFile_ConcurrentWrites(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	r, err := NewRotatingFile(types.FileConfig{Path: path, MaxSize: 1024})
+	if err != nil {
+		t.Fatalf("NewRotatingFile failed: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				r.Write([]byte("abcdefghij\n"))
+			}
+		}()
+	}
+	wg.Wait()
+	r.Close()
+
+	total := 0
+	for _, name := range listDir(t, dir) {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("ReadFile failed: %v", err)
+		}
+		for _, line := range strings.Split(strings.TrimSuffix(string(data), "\n"), "\n") {
+			if line != "abcdefghij" {
+				t.Fatalf("Found corrupted line %q in %s", line, name)
+			}
+			total++
+		}
+	}
+	if total != 1000 {
+		t.Errorf("Expected 1000 lines, got %d", total)
+	}
+}
+
+// TestRotatingFile_Close tests that Close is idempotent and writes after Close fail
+func TestRotatingFile_Close(t *testing.T) {
+	r, err := NewRotatingFile(types.FileConfig{Path: filepath.Join(t.TempDir(), "nested", "app.log")})
+	if err != nil {
+		t.Fatalf("NewRotatingFile failed: %v", err)
+	}
+
+	if err := r.Close(); err != nil {
+		t.Errorf("First Close failed: %v", err)
+	}
+	if err := r.Close(); err != nil {
+		t.Errorf("Second Close failed: %v", err)
+	}
+	if _, err := r.Write([]byte("x")); err == nil {
+		t.Error("Expected Write after Close to fail")
+	}
+}
+
+// TestRotatingFile_RotationFailure tests that records are kept in the old file while rotation fails
+// and that rotation works again once the cause is gone
+func TestRotatingFile_RotationFailure(t *testing.T) {
+	logDir := filepath.Join(t.TempDir(), "logs")
+	path := filepath.Join(logDir, "app.log")
+	r, err := NewRotatingFile(types.FileConfig{Path: path, MaxSize: 20})
+	if err != nil {
+		t.Fatalf("NewRotatingFile failed: %v", err)
+	}
+	defer r.Close()
+
+	if _, err := r.Write([]byte("0123456789abcde\n")); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	// Replacing the directory with a file makes the rename fail with ENOTDIR
+	if err := os.RemoveAll(logDir); err != nil {
+		t.Fatalf("RemoveAll failed: %v", err)
+	}
+	if err := os.WriteFile(logDir, nil, 0o644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	n, err := r.Write([]byte("kept in old file\n"))
+	if err == nil || n != len("kept in old file\n") {
+		t.Fatalf("Expected the record to be written with a rotation error, got %d, %v", n, err)
+	}
+
+	if err := os.Remove(logDir); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if _, err := r.Write([]byte("after recovery\n")); err != nil {
+		t.Fatalf("Expected rotation to work again, got %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if string(data) != "after recovery\n" {
+		t.Errorf("Expected the new file to hold the last record, got %q", data)
+	}
+}
@@ -0,0 +1,199 @@
+package outputs
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"log/slog"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/wasilak/loggergo/lib/types"
+)
+
+// journaldReservedFields are the fields JournaldHandler writes itself. Attributes mapping to
+// one of them are prefixed with X_, so an entry never has two PRIORITY fields.
+var journaldReservedFields = map[string]bool{
+	"MESSAGE":           true,
+	"PRIORITY":          true,
+	"SYSLOG_IDENTIFIER": true,
+	"CODE_FILE":         true,
+	"CODE_LINE":         true,
+	"CODE_FUNC":         true,
+}
+
+// JournaldHandler is a slog.Handler that sends records to systemd-journald using its native protocol.
+//
+// Each record becomes a journal entry with MESSAGE, PRIORITY and SYSLOG_IDENTIFIER fields,
+// CODE_FILE, CODE_LINE and CODE_FUNC when AddSource is enabled, and one field per attribute.
+// Attribute keys are converted to journal field names with JournaldFieldName, with group
+// names joined by underscores (e.g. group "http" and key "status" become HTTP_STATUS).
+// Attributes that would map to one of the fields above are prefixed with X_ (e.g. an
+// attribute "priority" becomes X_PRIORITY).
+//
+// Entries too large for a single datagram are passed to journald through a sealed memfd.
+//
+// Thread Safety:
+//
+// JournaldHandler is safe for concurrent use.
+type JournaldHandler struct {
+	conn       *journaldConn
+	opts       slog.HandlerOptions
+	identifier string
+
+	attrs  []groupedAttr
+	groups []string
+}
+
+// NewJournaldHandler connects to the journald socket described by config and returns a handler sending to it.
+// defaultIdentifier is used when config.SyslogIdentifier is empty.
+func NewJournaldHandler(config types.JournaldConfig, defaultIdentifier string, opts slog.HandlerOptions) (*JournaldHandler, error) {
+	socketPath := config.SocketPath
+	if socketPath == "" {
+		socketPath = types.DefaultJournaldSocket
+	}
+
+	conn, err := dialJournald(socketPath)
+	if err != nil {
+		return nil, err
+	}
+
+	identifier := config.SyslogIdentifier
+	if identifier == "" {
+		identifier = defaultIdentifier
+	}
+
+	return &JournaldHandler{
+		conn:       conn,
+		opts:       opts,
+		identifier: identifier,
+	}, nil
+}
+
+// Close closes the connection to journald.
+func (h *JournaldHandler) Close() error {
+	return h.conn.close()
+}
+
+// Enabled reports whether the handler handles records at the given level.
+func (h *JournaldHandler) Enabled(_ context.Context, level slog.Level) bool {
+	minLevel := slog.LevelInfo
+	if h.opts.Level != nil {
+		minLevel = h.opts.Level.Level()
+	}
+	return level >= minLevel
+}
+
+// Handle encodes the record as a journal entry and sends it to journald.
+func (h *JournaldHandler) Handle(_ context.Context, record slog.Record) error {
+	var buf bytes.Buffer
+
+	writeJournaldField(&buf, "MESSAGE", record.Message)
+	writeJournaldField(&buf, "PRIORITY", strconv.Itoa(SyslogSeverity(record.Level)))
+	if h.identifier != "" {
+		writeJournaldField(&buf, "SYSLOG_IDENTIFIER", h.identifier)
+	}
+
+	if h.opts.AddSource && record.PC != 0 {
+		frames := runtime.CallersFrames([]uintptr{record.PC})
+		frame, _ := frames.Next()
+		writeJournaldField(&buf, "CODE_FILE", frame.File)
+		writeJournaldField(&buf, "CODE_LINE", strconv.Itoa(frame.Line))
+		writeJournaldField(&buf, "CODE_FUNC", frame.Function)
+	}
+
+	add := func(groups []string, key, value string) {
+		name := key
+		if len(groups) > 0 {
+			name = strings.Join(groups, "_") + "_" + key
+		}
+		name = JournaldFieldName(name)
+		if journaldReservedFields[name] {
+			name = "X_" + name
+		}
+		writeJournaldField(&buf, name, value)
+	}
+	for _, ga := range h.attrs {
+		walkAttr(ga.groups, ga.attr, add)
+	}
+	record.Attrs(func(a slog.Attr) bool {
+		walkAttr(h.groups, a, add)
+		return true
+	})
+
+	return h.conn.send(buf.Bytes())
+}
+
+// WithAttrs returns a new handler with the given attributes added.
+func (h *JournaldHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := *h
+	h2.attrs = append([]groupedAttr{}, h.attrs...)
+	for _, a := range attrs {
+		h2.attrs = append(h2.attrs, groupedAttr{groups: h.groups, attr: a})
+	}
+	return &h2
+}
+
+// WithGroup returns a new handler with the given group name.
+func (h *JournaldHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.groups = append(append([]string{}, h.groups...), name)
+	return &h2
+}
+
+// JournaldFieldName converts an attribute key to a valid journal field name.
+//
+// Letters are uppercased and any character other than A-Z, 0-9 and '_' becomes '_'.
+// Leading underscores are removed (they denote trusted fields), names starting with a
+// digit are prefixed with 'X', and names are truncated to 64 characters.
+//
+// Example:
+//
+//	JournaldFieldName("request.id") // "REQUEST_ID"
+func JournaldFieldName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+
+	name = strings.TrimLeft(name, "_")
+	if name == "" {
+		return "X"
+	}
+	if name[0] >= '0' && name[0] <= '9' {
+		name = "X" + name
+	}
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
+
+// writeJournaldField appends a field in the native protocol encoding: NAME=value for single-line values,
+// NAME, a newline, the 64-bit little-endian length and the raw value for values containing newlines.
+func writeJournaldField(buf *bytes.Buffer, name, value string) {
+	buf.WriteString(name)
+	if !strings.ContainsRune(value, '\n') {
+		buf.WriteByte('=')
+		buf.WriteString(value)
+		buf.WriteByte('\n')
+		return
+	}
+	buf.WriteByte('\n')
+	binary.Write(buf, binary.LittleEndian, uint64(len(value)))
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+}
@@ -0,0 +1,93 @@
+//go:build linux
+
+package outputs
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// journaldConn is an unconnected datagram socket sending to the journald socket.
+type journaldConn struct {
+	mu     sync.Mutex
+	conn   *net.UnixConn
+	addr   *net.UnixAddr
+	closed bool
+}
+
+// dialJournald opens a datagram socket for sending to the journald socket at socketPath.
+// The socket is checked to exist so that a missing journald is reported at initialization.
+func dialJournald(socketPath string) (*journaldConn, error) {
+	if _, err := os.Stat(socketPath); err != nil {
+		return nil, fmt.Errorf("failed to connect to journald: %w", err)
+	}
+
+	// An unnamed socket is auto-bound by the kernel; it must stay unconnected to pass file descriptors with WriteMsgUnix
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Net: "unixgram"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journald socket: %w", err)
+	}
+	return &journaldConn{conn: conn, addr: &net.UnixAddr{Name: socketPath, Net: "unixgram"}}, nil
+}
+
+// send sends an encoded entry, falling back to a sealed memfd if it is too large for a datagram.
+func (c *journaldConn) send(entry []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return os.ErrClosed
+	}
+
+	_, _, err := c.conn.WriteMsgUnix(entry, nil, c.addr)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, syscall.EMSGSIZE) && !errors.Is(err, syscall.ENOBUFS) {
+		return err
+	}
+
+	return c.sendMemfd(entry)
+}
+
+// sendMemfd writes the entry to a sealed memfd and passes its file descriptor to journald.
+func (c *journaldConn) sendMemfd(entry []byte) error {
+	fd, err := unix.MemfdCreate("journal-entry", unix.MFD_CLOEXEC|unix.MFD_ALLOW_SEALING)
+	if err != nil {
+		return fmt.Errorf("failed to create memfd for journal entry: %w", err)
+	}
+
+	file := os.NewFile(uintptr(fd), "journal-entry")
+	defer file.Close()
+
+	if _, err := file.Write(entry); err != nil {
+		return fmt.Errorf("failed to write journal entry to memfd: %w", err)
+	}
+
+	// journald only accepts memfds that can no longer be modified
+	seals := unix.F_SEAL_SHRINK | unix.F_SEAL_GROW | unix.F_SEAL_WRITE | unix.F_SEAL_SEAL
+	if _, err := unix.FcntlInt(uintptr(fd), unix.F_ADD_SEALS, seals); err != nil {
+		return fmt.Errorf("failed to seal memfd for journal entry: %w", err)
+	}
+
+	_, _, err = c.conn.WriteMsgUnix(nil, unix.UnixRights(fd), c.addr)
+	return err
+}
+
+// close closes the connection. Calling it more than once is safe.
+func (c *journaldConn) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	return c.conn.Close()
+}
@@ -0,0 +1,201 @@
+//go:build linux
+
+package outputs
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wasilak/loggergo/lib/types"
+	"golang.org/x/sys/unix"
+)
+
+// fakeJournald is a unixgram socket standing in for journald in tests
+type fakeJournald struct {
+	conn *net.UnixConn
+	path string
+}
+
+// newFakeJournald listens on a unixgram socket in a temporary directory
+func newFakeJournald(t *testing.T) *fakeJournald {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "journal.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Fatalf("ListenUnixgram failed: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return &fakeJournald{conn: conn, path: path}
+}
+
+// receive reads one entry, following a passed memfd if the datagram carries one
+func (j *fakeJournald) receive(t *testing.T) []byte {
+	t.Helper()
+	buf := make([]byte, 1<<20)
+	oob := make([]byte, unix.CmsgSpace(4))
+	j.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, oobn, _, _, err := j.conn.ReadMsgUnix(buf, oob)
+	if err != nil {
+		t.Fatalf("ReadMsgUnix failed: %v", err)
+	}
+	if oobn == 0 {
+		return buf[:n]
+	}
+
+	msgs, err := unix.ParseSocketControlMessage(oob[:oobn])
+	if err != nil || len(msgs) != 1 {
+		t.Fatalf("ParseSocketControlMessage failed: %v", err)
+	}
+	fds, err := unix.ParseUnixRights(&msgs[0])
+	if err != nil || len(fds) != 1 {
+		t.Fatalf("ParseUnixRights failed: %v", err)
+	}
+	file := os.NewFile(uintptr(fds[0]), "memfd")
+	defer file.Close()
+	file.Seek(0, io.SeekStart)
+	data, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatalf("Reading memfd failed: %v", err)
+	}
+	return data
+}
+
+// parseJournalEntry decodes the native protocol into a field map
+func parseJournalEntry(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	fields := map[string]string{}
+	for len(data) > 0 {
+		nl := bytes.IndexByte(data, '\n')
+		if nl < 0 {
+			t.Fatalf("Malformed entry: %q", data)
+		}
+		line := string(data[:nl])
+		data = data[nl+1:]
+		if eq := strings.IndexByte(line, '='); eq >= 0 {
+			fields[line[:eq]] = line[eq+1:]
+			continue
+		}
+		size := binary.LittleEndian.Uint64(data[:8])
+		fields[line] = string(data[8 : 8+size])
+		data = data[8+size+1:]
+	}
+	return fields
+}
+
+// TestJournaldFieldName tests conversion of attribute keys to journal field names
+func TestJournaldFieldName(t *testing.T) {
+	tests := map[string]string{
+		"request_id": "REQUEST_ID",
+		"request.id": "REQUEST_ID",
+		"_trusted":   "TRUSTED",
+		"1st":        "X1ST",
+		"":           "X",
+		"user-agent": "USER_AGENT",
+	}
+	for key, want := range tests {
+		if got := JournaldFieldName(key); got != want {
+			t.Errorf("JournaldFieldName(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+// TestJournaldHandler_Fields tests that records are sent with structured journal fields
+func TestJournaldHandler_Fields(t *testing.T) {
+	journal := newFakeJournald(t)
+
+	h, err := NewJournaldHandler(types.JournaldConfig{SocketPath: journal.path}, "my-service", slog.HandlerOptions{AddSource: true})
+	if err != nil {
+		t.Fatalf("NewJournaldHandler failed: %v", err)
+	}
+	defer h.Close()
+
+	logger := slog.New(h).With("request_id", "req-1").WithGroup("http")
+	logger.Warn("multi\nline", "status", 500)
+
+	fields := parseJournalEntry(t, journal.receive(t))
+
+	want := map[string]string{
+		"MESSAGE":           "multi\nline",
+		"PRIORITY":          "4",
+		"SYSLOG_IDENTIFIER": "my-service",
+		"REQUEST_ID":        "req-1",
+		"HTTP_STATUS":       "500",
+	}
+	for k, v := range want {
+		if fields[k] != v {
+			t.Errorf("Expected %s=%q, got %q", k, v, fields[k])
+		}
+	}
+	if !strings.HasSuffix(fields["CODE_FILE"], "journald_linux_test.go") || fields["CODE_LINE"] == "" {
+		t.Errorf("Expected CODE_FILE/CODE_LINE of the caller, got %q:%q", fields["CODE_FILE"], fields["CODE_LINE"])
+	}
+}
+
+// TestJournaldHandler_LargeEntryUsesMemfd tests that entries too large for a datagram are passed through a memfd
+func TestJournaldHandler_LargeEntryUsesMemfd(t *testing.T) {
+	journal := newFakeJournald(t)
+
+	h, err := NewJournaldHandler(types.JournaldConfig{SocketPath: journal.path, SyslogIdentifier: "big"}, "my-service", slog.HandlerOptions{})
+	if err != nil {
+		t.Fatalf("NewJournaldHandler failed: %v", err)
+	}
+	defer h.Close()
+
+	payload := strings.Repeat("x", 4<<20)
+	if err := h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, payload, 0)); err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+
+	fields := parseJournalEntry(t, journal.receive(t))
+	if fields["MESSAGE"] != payload {
+		t.Errorf("Expected %d byte MESSAGE, got %d bytes", len(payload), len(fields["MESSAGE"]))
+	}
+	if fields["SYSLOG_IDENTIFIER"] != "big" {
+		t.Errorf("Expected SYSLOG_IDENTIFIER=big, got %q", fields["SYSLOG_IDENTIFIER"])
+	}
+}
+
+// TestJournaldHandler_ReservedFields tests that attributes colliding with fields written by the handler are renamed
+func TestJournaldHandler_ReservedFields(t *testing.T) {
+	journal := newFakeJournald(t)
+
+	h, err := NewJournaldHandler(types.JournaldConfig{SocketPath: journal.path}, "my-service", slog.HandlerOptions{AddSource: true})
+	if err != nil {
+		t.Fatalf("NewJournaldHandler failed: %v", err)
+	}
+	defer h.Close()
+
+	slog.New(h).Info("hello", "priority", "high", "message", "attr", "syslog_identifier", "other", "code_line", "1")
+
+	data := journal.receive(t)
+	for _, name := range []string{"MESSAGE", "PRIORITY", "SYSLOG_IDENTIFIER", "CODE_LINE"} {
+		if n := bytes.Count(append([]byte("\n"), data...), []byte("\n"+name+"=")); n != 1 {
+			t.Errorf("Expected one %s field, got %d in %q", name, n, data)
+		}
+	}
+
+	fields := parseJournalEntry(t, data)
+	want := map[string]string{
+		"MESSAGE":             "hello",
+		"PRIORITY":            "6",
+		"SYSLOG_IDENTIFIER":   "my-service",
+		"X_PRIORITY":          "high",
+		"X_MESSAGE":           "attr",
+		"X_SYSLOG_IDENTIFIER": "other",
+		"X_CODE_LINE":         "1",
+	}
+	for k, v := range want {
+		if fields[k] != v {
+			t.Errorf("Expected %s=%q, got %q", k, v, fields[k])
+		}
+	}
+}
@@ -0,0 +1,21 @@
+//go:build !linux
+
+package outputs
+
+import "fmt"
+
+// journaldConn is unavailable outside Linux.
+type journaldConn struct{}
+
+// dialJournald always fails outside Linux, where systemd-journald does not exist.
+func dialJournald(socketPath string) (*journaldConn, error) {
+	return nil, fmt.Errorf("journald output is only supported on Linux")
+}
+
+func (c *journaldConn) send(entry []byte) error {
+	return fmt.Errorf("journald output is only supported on Linux")
+}
+
+func (c *journaldConn) close() error {
+	return nil
+}
@@ -5,33 +5,33 @@ import (
 	"log/slog"
 
 	"github.com/wasilak/loggergo/lib"
+	"github.com/wasilak/loggergo/lib/types"
 	"go.opentelemetry.io/contrib/bridges/otelslog"
-	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/stdout/stdoutlog"
 	otellog "go.opentelemetry.io/otel/log"
 	"go.opentelemetry.io/otel/sdk/log"
-	"go.opentelemetry.io/otel/sdk/resource"
 )
 
 const sevOffset = slog.Level(otellog.SeverityDebug) - slog.LevelDebug
 
-// Custom processor to filter logs by level
+// Custom processor to filter logs by level. minLevel is read for every record, so a
+// slog.LevelVar or the logger's component levels changed at runtime take effect at once.
 type levelFilterProcessor struct {
-	minLevel  slog.Level
+	minLevel  slog.Leveler
 	processor log.Processor
 }
 
 // Enabled checks if the log record should be processed based on the minimum level
 func (p *levelFilterProcessor) Enabled(ctx context.Context, record log.EnabledParameters) bool {
 	sev := slog.Level(record.Severity) - sevOffset
-	return sev >= p.minLevel
+	return sev >= p.minLevel.Level()
 }
 
 // OnEmit filters log records by level and delegates to the wrapped processor
 func (p *levelFilterProcessor) OnEmit(ctx context.Context, record *log.Record) error {
 	sev := slog.Level(record.Severity()) - sevOffset
 
-	if sev >= p.minLevel {
+	if sev >= p.minLevel.Level() {
 		return p.processor.OnEmit(ctx, record)
 	}
 	return nil // Ignore logs below the minimum level
@@ -47,46 +47,62 @@ func (p *levelFilterProcessor) ForceFlush(ctx context.Context) error {
 	return p.processor.ForceFlush(ctx)
 }
 
-// setupOtelFormat sets up a slog.Handler for OpenTelemetry format.
-// It merges the default resource with the service name attribute, creates a stdoutlog exporter,
-// and sets up a log processor and logger provider with the merged resource and exporter.
+// setupOtelFormat sets up a slog.Handler for OpenTelemetry format described by config.
+// It creates the resource described by config.Resource with NewResource, creates a stdoutlog exporter
+// writing to config.OutputStream as described by config.OtelFormat, and sets up a simple or batch log
+// processor and logger provider with the resource and exporter. Records below opts.Level are
+// dropped; the leveler is read for every record, so runtime level changes apply.
+// The provider shutdown is registered as a cleanup function on the manager, so pending records are flushed.
 // Returns the handler and any error encountered.
-func SetupOtelFormat() (slog.Handler, error) {
-	defaultResource := resource.Default()
-
-	serviceResource := resource.NewWithAttributes(
-		defaultResource.SchemaURL(),
-		attribute.String("service.name", lib.GetConfig().OtelServiceName),
-	)
-
-	mergedResource, err := resource.Merge(
-		defaultResource,
-		serviceResource,
-	)
+func SetupOtelFormat(manager *lib.ConfigManager, config types.Config, opts slog.HandlerOptions) (slog.Handler, error) {
+	mergedResource, err := NewResource(context.Background(), config)
 	if err != nil {
 		return nil, err
 	}
 
-	exporter, err := stdoutlog.New()
+	var exporterOptions []stdoutlog.Option
+	if config.OutputStream != nil {
+		exporterOptions = append(exporterOptions, stdoutlog.WithWriter(config.OutputStream))
+	}
+	if config.OtelFormat.PrettyPrint {
+		exporterOptions = append(exporterOptions, stdoutlog.WithPrettyPrint())
+	}
+	if config.OtelFormat.OmitTimestamps {
+		exporterOptions = append(exporterOptions, stdoutlog.WithoutTimestamps())
+	}
+
+	exporter, err := stdoutlog.New(exporterOptions...)
 	if err != nil {
 		return nil, err
 	}
 
-	// Wrap the exporter with a simple processor
-	baseProcessor := log.NewSimpleProcessor(exporter)
+	// Wrap the exporter with a simple or batch processor
+	var baseProcessor log.Processor
+	if config.OtelFormat.Batch {
+		baseProcessor = log.NewBatchProcessor(exporter)
+	} else {
+		baseProcessor = log.NewSimpleProcessor(exporter)
+	}
 
 	// Wrap the processor with a level filter
+	minLevel := opts.Level
+	if minLevel == nil {
+		minLevel = slog.LevelInfo
+	}
 	filteredProcessor := &levelFilterProcessor{
-		minLevel:  lib.GetConfig().Level.Level(),
+		minLevel:  minLevel,
 		processor: baseProcessor,
 	}
 
-	// processor := log.NewSimpleProcessor(exporter)
 	stdoutProvider := log.NewLoggerProvider(
 		log.WithResource(mergedResource),
-		// log.WithProcessor(processor),
 		log.WithProcessor(filteredProcessor),
 	)
 
-	return otelslog.NewHandler(lib.GetConfig().OtelLoggerName, otelslog.WithLoggerProvider(stdoutProvider)), nil
+	// Use a background context for shutdown as the original context may be cancelled
+	manager.RegisterCleanup(func() error {
+		return stdoutProvider.Shutdown(context.Background())
+	})
+
+	return otelslog.NewHandler(config.OtelLoggerName, otelslog.WithLoggerProvider(stdoutProvider)), nil
 }
@@ -0,0 +1,100 @@
+package outputs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wasilak/loggergo/lib"
+	"github.com/wasilak/loggergo/lib/types"
+)
+
+// newOtelFormatHandler creates an OTEL format handler writing to buf with its own manager
+func newOtelFormatHandler(t *testing.T, buf *bytes.Buffer, otelFormat types.OtelFormatConfig) (slog.Handler, *lib.ConfigManager) {
+	t.Helper()
+	manager := lib.NewConfigManager()
+	config := types.Config{
+		Level:           slog.LevelInfo,
+		OutputStream:    buf,
+		OtelLoggerName:  "test",
+		OtelServiceName: "otel-format-test",
+		OtelFormat:      otelFormat,
+	}
+
+	handler, err := SetupOtelFormat(manager, config, slog.HandlerOptions{Level: slog.LevelInfo})
+	if err != nil {
+		t.Fatalf("SetupOtelFormat failed: %v", err)
+	}
+	return handler, manager
+}
+
+// TestSetupOtelFormat_OutputStream tests that records are written to OutputStream as compact JSON
+func TestSetupOtelFormat_OutputStream(t *testing.T) {
+	var buf bytes.Buffer
+	handler, manager := newOtelFormatHandler(t, &buf, types.OtelFormatConfig{OmitTimestamps: true})
+	defer manager.Shutdown()
+
+	logger := slog.New(handler)
+	logger.Info("first", "user", "alice")
+	logger.Debug("filtered")
+	logger.Warn("second")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 records written synchronously, got %d: %s", len(lines), buf.String())
+	}
+
+	var record struct {
+		Timestamp    *string
+		SeverityText string
+		Body         struct{ Value string }
+		Attributes   []struct{ Key string }
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &record); err != nil {
+		t.Fatalf("Invalid JSON record %q: %v", lines[0], err)
+	}
+	if record.Body.Value != "first" || record.SeverityText != "INFO" || len(record.Attributes) != 1 || record.Attributes[0].Key != "user" {
+		t.Errorf("Unexpected record: %s", lines[0])
+	}
+	if record.Timestamp != nil {
+		t.Errorf("Expected no timestamp, got %s", lines[0])
+	}
+	if !strings.Contains(lines[0], `"otel-format-test"`) {
+		t.Errorf("Expected the service name in the resource, got %s", lines[0])
+	}
+}
+
+// TestSetupOtelFormat_PrettyPrint tests indented output
+func TestSetupOtelFormat_PrettyPrint(t *testing.T) {
+	var buf bytes.Buffer
+	handler, manager := newOtelFormatHandler(t, &buf, types.OtelFormatConfig{PrettyPrint: true})
+	defer manager.Shutdown()
+
+	slog.New(handler).Info("pretty")
+
+	if !strings.Contains(buf.String(), "\n\t\"Body\": {") {
+		t.Errorf("Expected indented JSON, got %s", buf.String())
+	}
+}
+
+// TestSetupOtelFormat_BatchFlushedOnShutdown tests that batched records are written by the manager's Shutdown
+func TestSetupOtelFormat_BatchFlushedOnShutdown(t *testing.T) {
+	var buf bytes.Buffer
+	handler, manager := newOtelFormatHandler(t, &buf, types.OtelFormatConfig{Batch: true})
+
+	handler.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "batched", 0))
+	if buf.Len() != 0 {
+		t.Fatalf("Expected the record to be batched, got %s", buf.String())
+	}
+
+	if err := manager.Shutdown(); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"batched"`) {
+		t.Errorf("Expected the record to be flushed on Shutdown, got %q", buf.String())
+	}
+}
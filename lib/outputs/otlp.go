@@ -0,0 +1,172 @@
+package outputs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/wasilak/loggergo/lib/types"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/sdk/log"
+	"google.golang.org/grpc/credentials"
+)
+
+// otlpLogsPath is the path of the OTLP/HTTP logs endpoint, used when an endpoint URL has no path.
+const otlpLogsPath = "/v1/logs"
+
+// ExporterWrapper wraps the exporter of the provider created by NewOTLPProvider.
+type ExporterWrapper func(log.Exporter) log.Exporter
+
+// NewOTLPProvider creates a LoggerProvider exporting to the collector described by config.OTLP.
+//
+// Records are batched as described by config.OTLP.Batch and carry the resource created by
+// NewResource from config.Resource and config.OtelServiceName. Settings left empty fall back to
+// the OTEL_EXPORTER_OTLP_* environment variables and then to the exporter defaults.
+// The exporter is wrapped by each of wrappers in turn, e.g. by CircuitBreaker.WrapExporter.
+// The provider must be shut down to flush pending records.
+func NewOTLPProvider(ctx context.Context, config types.Config, wrappers ...ExporterWrapper) (*log.LoggerProvider, error) {
+	exporter, err := newOTLPExporter(ctx, config.OTLP)
+	if err != nil {
+		return nil, err
+	}
+	for _, wrap := range wrappers {
+		exporter = wrap(exporter)
+	}
+
+	res, err := NewResource(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := config.OTLP.Batch
+	var batchOptions []log.BatchProcessorOption
+	if batch.MaxQueueSize > 0 {
+		batchOptions = append(batchOptions, log.WithMaxQueueSize(batch.MaxQueueSize))
+	}
+	if batch.ExportMaxBatchSize > 0 {
+		batchOptions = append(batchOptions, log.WithExportMaxBatchSize(batch.ExportMaxBatchSize))
+	}
+	if batch.ExportInterval > 0 {
+		batchOptions = append(batchOptions, log.WithExportInterval(batch.ExportInterval))
+	}
+	if batch.ExportTimeout > 0 {
+		batchOptions = append(batchOptions, log.WithExportTimeout(batch.ExportTimeout))
+	}
+
+	return log.NewLoggerProvider(
+		log.WithResource(res),
+		log.WithProcessor(log.NewBatchProcessor(exporter, batchOptions...)),
+	), nil
+}
+
+// newOTLPExporter creates the gRPC or HTTP exporter for the OTLP settings.
+func newOTLPExporter(ctx context.Context, config types.OTLPConfig) (log.Exporter, error) {
+	tlsConfig, err := otlpTLSConfig(config.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Protocol == types.OTLPProtocolHTTP {
+		var options []otlploghttp.Option
+		if config.Endpoint != "" {
+			if strings.Contains(config.Endpoint, "://") {
+				endpoint, err := url.Parse(config.Endpoint)
+				if err != nil {
+					return nil, fmt.Errorf("invalid OTLP endpoint: %w", err)
+				}
+				if endpoint.Path == "" || endpoint.Path == "/" {
+					endpoint.Path = otlpLogsPath
+				}
+				options = append(options, otlploghttp.WithEndpointURL(endpoint.String()))
+			} else {
+				options = append(options, otlploghttp.WithEndpoint(config.Endpoint))
+			}
+		}
+		if config.Insecure {
+			options = append(options, otlploghttp.WithInsecure())
+		}
+		if tlsConfig != nil {
+			options = append(options, otlploghttp.WithTLSClientConfig(tlsConfig))
+		}
+		if len(config.Headers) > 0 {
+			options = append(options, otlploghttp.WithHeaders(config.Headers))
+		}
+		switch config.Compression {
+		case types.OTLPCompressionGzip:
+			options = append(options, otlploghttp.WithCompression(otlploghttp.GzipCompression))
+		case types.OTLPCompressionNone:
+			options = append(options, otlploghttp.WithCompression(otlploghttp.NoCompression))
+		}
+		if config.Timeout > 0 {
+			options = append(options, otlploghttp.WithTimeout(config.Timeout))
+		}
+		return otlploghttp.New(ctx, options...)
+	}
+
+	var options []otlploggrpc.Option
+	if config.Endpoint != "" {
+		if strings.Contains(config.Endpoint, "://") {
+			options = append(options, otlploggrpc.WithEndpointURL(config.Endpoint))
+		} else {
+			options = append(options, otlploggrpc.WithEndpoint(config.Endpoint))
+		}
+	}
+	if config.Insecure {
+		options = append(options, otlploggrpc.WithInsecure())
+	}
+	if tlsConfig != nil {
+		options = append(options, otlploggrpc.WithTLSCredentials(credentials.NewTLS(tlsConfig)))
+	}
+	if len(config.Headers) > 0 {
+		options = append(options, otlploggrpc.WithHeaders(config.Headers))
+	}
+	switch config.Compression {
+	case types.OTLPCompressionGzip:
+		options = append(options, otlploggrpc.WithCompressor("gzip"))
+	case types.OTLPCompressionNone:
+		options = append(options, otlploggrpc.WithCompressor("none"))
+	}
+	if config.Timeout > 0 {
+		options = append(options, otlploggrpc.WithTimeout(config.Timeout))
+	}
+	return otlploggrpc.New(ctx, options...)
+}
+
+// otlpTLSConfig loads the certificates of the TLS settings. It returns nil if none are set.
+func otlpTLSConfig(config types.OTLPTLSConfig) (*tls.Config, error) {
+	if config == (types.OTLPTLSConfig{}) {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName:         config.ServerName,
+		InsecureSkipVerify: config.InsecureSkipVerify,
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	if config.CAFile != "" {
+		pem, err := os.ReadFile(config.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read OTLP CA file: %w", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in OTLP CA file %s", config.CAFile)
+		}
+	}
+
+	if config.CertFile != "" {
+		certificate, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load OTLP client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
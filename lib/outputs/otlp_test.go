@@ -0,0 +1,193 @@
+package outputs
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/wasilak/loggergo/lib/types"
+	otellog "go.opentelemetry.io/otel/log"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// otlpReceiver collects the export requests and headers received by a stub collector
+type otlpReceiver struct {
+	collogspb.UnimplementedLogsServiceServer
+
+	mu       sync.Mutex
+	requests []*collogspb.ExportLogsServiceRequest
+	headers  []map[string]string
+}
+
+func (r *otlpReceiver) record(request *collogspb.ExportLogsServiceRequest, headers map[string]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, request)
+	r.headers = append(r.headers, headers)
+}
+
+// Export implements the gRPC logs service
+func (r *otlpReceiver) Export(ctx context.Context, request *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	headers := map[string]string{}
+	for name, values := range md {
+		headers[name] = values[0]
+	}
+	r.record(request, headers)
+	return &collogspb.ExportLogsServiceResponse{}, nil
+}
+
+// ServeHTTP implements the OTLP/HTTP logs endpoint
+func (r *otlpReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path != otlpLogsPath {
+		http.NotFound(w, req)
+		return
+	}
+
+	body := io.Reader(req.Body)
+	if req.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(req.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		body = gz
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	request := &collogspb.ExportLogsServiceRequest{}
+	if err := proto.Unmarshal(data, request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.record(request, map[string]string{
+		"authorization":    req.Header.Get("Authorization"),
+		"content-encoding": req.Header.Get("Content-Encoding"),
+	})
+
+	response, _ := proto.Marshal(&collogspb.ExportLogsServiceResponse{})
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.Write(response)
+}
+
+// emitAndFlush emits one record through a provider created from config and shuts it down
+func emitAndFlush(t *testing.T, config types.Config) {
+	t.Helper()
+	ctx := context.Background()
+
+	provider, err := NewOTLPProvider(ctx, config)
+	if err != nil {
+		t.Fatalf("NewOTLPProvider failed: %v", err)
+	}
+
+	var record otellog.Record
+	record.SetBody(otellog.StringValue("hello collector"))
+	record.SetSeverity(otellog.SeverityInfo)
+	provider.Logger("test").Emit(ctx, record)
+
+	if err := provider.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+}
+
+// checkExported checks that the receiver got the record with the service name and version
+func checkExported(t *testing.T, receiver *otlpReceiver) map[string]string {
+	t.Helper()
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+
+	if len(receiver.requests) != 1 {
+		t.Fatalf("Expected 1 export request, got %d", len(receiver.requests))
+	}
+	resourceLogs := receiver.requests[0].GetResourceLogs()
+	if len(resourceLogs) != 1 {
+		t.Fatalf("Expected 1 resource, got %d", len(resourceLogs))
+	}
+
+	resourceAttrs := map[string]string{}
+	for _, attr := range resourceLogs[0].GetResource().GetAttributes() {
+		resourceAttrs[attr.GetKey()] = attr.GetValue().GetStringValue()
+	}
+	if resourceAttrs["service.name"] != "otlp-test" || resourceAttrs["service.version"] != "1.0.0" {
+		t.Errorf("Expected service.name otlp-test and service.version 1.0.0, got %v", resourceAttrs)
+	}
+
+	records := resourceLogs[0].GetScopeLogs()[0].GetLogRecords()
+	if len(records) != 1 || records[0].GetBody().GetStringValue() != "hello collector" {
+		t.Errorf("Expected the emitted record, got %v", records)
+	}
+	return receiver.headers[0]
+}
+
+// TestNewOTLPProvider_HTTP tests export over HTTP with headers and gzip compression
+func TestNewOTLPProvider_HTTP(t *testing.T) {
+	receiver := &otlpReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	emitAndFlush(t, types.Config{
+		OtelServiceName: "otlp-test",
+		Resource:        types.ResourceConfig{ServiceVersion: "1.0.0"},
+		OTLP: types.OTLPConfig{
+			Endpoint:    server.URL,
+			Protocol:    types.OTLPProtocolHTTP,
+			Headers:     map[string]string{"Authorization": "Bearer token"},
+			Compression: types.OTLPCompressionGzip,
+		},
+	})
+
+	headers := checkExported(t, receiver)
+	if headers["authorization"] != "Bearer token" || headers["content-encoding"] != "gzip" {
+		t.Errorf("Expected authorization header and gzip encoding, got %v", headers)
+	}
+}
+
+// TestNewOTLPProvider_GRPC tests export over gRPC with headers sent as metadata
+func TestNewOTLPProvider_GRPC(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	receiver := &otlpReceiver{}
+	server := grpc.NewServer()
+	collogspb.RegisterLogsServiceServer(server, receiver)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	emitAndFlush(t, types.Config{
+		OtelServiceName: "otlp-test",
+		Resource:        types.ResourceConfig{ServiceVersion: "1.0.0"},
+		OTLP: types.OTLPConfig{
+			Endpoint: listener.Addr().String(),
+			Insecure: true,
+			Headers:  map[string]string{"x-api-key": "secret"},
+		},
+	})
+
+	headers := checkExported(t, receiver)
+	if headers["x-api-key"] != "secret" {
+		t.Errorf("Expected x-api-key metadata, got %v", headers)
+	}
+}
+
+// TestNewOTLPProvider_InvalidTLS tests that unreadable certificates are reported
+func TestNewOTLPProvider_InvalidTLS(t *testing.T) {
+	_, err := NewOTLPProvider(context.Background(), types.Config{
+		OTLP: types.OTLPConfig{TLS: types.OTLPTLSConfig{CAFile: "/nonexistent/ca.pem"}},
+	})
+	if err == nil {
+		t.Error("Expected error for missing CA file")
+	}
+}
@@ -0,0 +1,61 @@
+package outputs
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+)
+
+// Reopener is implemented by writers that can reopen their underlying file, such as RotatingFile.
+type Reopener interface {
+	Reopen() error
+}
+
+// WatchReopenSignal calls r.Reopen each time the process receives a reopen signal (SIGHUP).
+// Reopen failures are reported to stderr and the writer keeps using its current file.
+//
+// It returns a function that stops watching; the stop function is safe to call more than once.
+// On platforms without SIGHUP (Windows) watching is a no-op.
+//
+// Example:
+//
+//	file, _ := outputs.NewRotatingFile(types.FileConfig{Path: "/var/log/app.log"})
+//	stop := outputs.WatchReopenSignal(file)
+//	lib.RegisterCleanup(func() error {
+//	    stop()
+//	    return nil
+//	})
+func WatchReopenSignal(r Reopener) (stop func()) {
+	if len(reopenSignals) == 0 {
+		return func() {}
+	}
+
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	signal.Notify(signals, reopenSignals...)
+
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-signals:
+				if err := r.Reopen(); err != nil {
+					fmt.Fprintf(os.Stderr, "WARNING: failed to reopen log file: %v\n", err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(done)
+			<-stopped
+		})
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !windows
+
+package outputs
+
+import (
+	"os"
+	"syscall"
+)
+
+// reopenSignals are the signals that make WatchReopenSignal reopen the file.
+var reopenSignals = []os.Signal{syscall.SIGHUP}
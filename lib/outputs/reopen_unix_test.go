@@ -0,0 +1,95 @@
+//go:build !windows
+
+package outputs
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/wasilak/loggergo/lib/types"
+)
+
+// TestWatchReopenSignal_SIGHUP tests that the file is reopened after being moved away and SIGHUP is received
+func TestWatchReopenSignal_SIGHUP(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	moved := filepath.Join(dir, "app.log.1")
+
+	r, err := NewRotatingFile(types.FileConfig{Path: path})
+	if err != nil {
+		t.Fatalf("NewRotatingFile failed: %v", err)
+	}
+	defer r.Close()
+
+	stop := WatchReopenSignal(r)
+	defer stop()
+
+	// Keep writing while the file is moved and reopened to make sure no record is lost
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	written := 0
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				if _, err := r.Write([]byte("record\n")); err != nil {
+					t.Errorf("Write failed: %v", err)
+					return
+				}
+				written++
+			}
+		}
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+
+	// Simulate logrotate: move the file away, then signal
+	if err := os.Rename(path, moved); err != nil {
+		t.Fatalf("Rename failed: %v", err)
+	}
+	if err := syscall.Kill(os.Getpid(), syscall.SIGHUP); err != nil {
+		t.Fatalf("Kill failed: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if info, err := os.Stat(path); err == nil && info.Size() > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("File was not reopened after SIGHUP")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	close(done)
+	wg.Wait()
+	stop()
+	r.Close()
+
+	total := 0
+	for _, name := range []string{path, moved} {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatalf("ReadFile failed: %v", err)
+		}
+		for _, line := range strings.Split(strings.TrimSuffix(string(data), "\n"), "\n") {
+			if line != "record" {
+				t.Fatalf("Found corrupted line %q in %s", line, name)
+			}
+			total++
+		}
+	}
+	if total != written {
+		t.Errorf("Expected %d records across both files, got %d", written, total)
+	}
+}
@@ -0,0 +1,8 @@
+//go:build windows
+
+package outputs
+
+import "os"
+
+// reopenSignals is empty on Windows, which has no SIGHUP.
+var reopenSignals []os.Signal
@@ -0,0 +1,148 @@
+package outputs
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/wasilak/loggergo/lib/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// containerIDPattern matches a container ID at the end of a cgroup path, e.g. docker-<id>.scope or kubepods/.../<id>.
+var containerIDPattern = regexp.MustCompile(`^(?:.*[-:])?([0-9a-f]{64})(?:\.scope)?$`)
+
+// mountContainerIDPattern matches a container ID in a mount source, e.g. /var/lib/docker/containers/<id>/hostname.
+var mountContainerIDPattern = regexp.MustCompile(`containers/([0-9a-f]{64})/`)
+
+// NewResource creates the OpenTelemetry resource described by config.Resource.
+//
+// The resource starts from the SDK defaults, which include OTEL_RESOURCE_ATTRIBUTES and
+// OTEL_SERVICE_NAME. Detected attributes are applied on top, then config.Resource.Attributes,
+// then service.name from config.OtelServiceName, service.version and deployment.environment.
+// Detectors that can only find some of their attributes don't cause an error.
+func NewResource(ctx context.Context, config types.Config) (*resource.Resource, error) {
+	var options []resource.Option
+
+	for _, detector := range config.Resource.Detectors {
+		switch detector {
+		case types.ResourceDetectorHost:
+			options = append(options, resource.WithHost(), resource.WithHostID())
+		case types.ResourceDetectorProcess:
+			// Command line arguments are left out as they may contain secrets
+			options = append(options,
+				resource.WithProcessPID(),
+				resource.WithProcessExecutableName(),
+				resource.WithProcessExecutablePath(),
+				resource.WithProcessOwner(),
+				resource.WithProcessRuntimeName(),
+				resource.WithProcessRuntimeVersion(),
+				resource.WithProcessRuntimeDescription(),
+			)
+		case types.ResourceDetectorOS:
+			options = append(options, resource.WithOS())
+		case types.ResourceDetectorContainer:
+			options = append(options, resource.WithDetectors(newContainerDetector()))
+		}
+	}
+
+	// Keys are sorted so the resource doesn't depend on map iteration order
+	keys := make([]string, 0, len(config.Resource.Attributes))
+	for key := range config.Resource.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	attrs := make([]attribute.KeyValue, 0, len(keys))
+	for _, key := range keys {
+		attrs = append(attrs, attribute.String(key, config.Resource.Attributes[key]))
+	}
+	options = append(options, resource.WithAttributes(attrs...))
+
+	serviceAttrs := []attribute.KeyValue{attribute.String("service.name", config.OtelServiceName)}
+	if config.Resource.ServiceVersion != "" {
+		serviceAttrs = append(serviceAttrs, attribute.String("service.version", config.Resource.ServiceVersion))
+	}
+	if config.Resource.Environment != "" {
+		serviceAttrs = append(serviceAttrs, attribute.String("deployment.environment", config.Resource.Environment))
+	}
+	options = append(options, resource.WithAttributes(serviceAttrs...))
+
+	configured, err := resource.New(ctx, options...)
+	if err != nil && !errors.Is(err, resource.ErrPartialResource) {
+		return nil, err
+	}
+
+	return resource.Merge(resource.Default(), configured)
+}
+
+// containerDetector detects container.id from the cgroup and mount information of the process.
+type containerDetector struct {
+	cgroupPath    string
+	mountinfoPath string
+}
+
+// newContainerDetector returns a containerDetector reading the files of the current process.
+func newContainerDetector() containerDetector {
+	return containerDetector{
+		cgroupPath:    "/proc/self/cgroup",
+		mountinfoPath: "/proc/self/mountinfo",
+	}
+}
+
+// Detect implements resource.Detector. It returns an empty resource outside of a container.
+func (d containerDetector) Detect(ctx context.Context) (*resource.Resource, error) {
+	id := d.containerID()
+	if id == "" {
+		return resource.Empty(), nil
+	}
+	return resource.NewSchemaless(attribute.String("container.id", id)), nil
+}
+
+// containerID returns the container ID from the cgroup paths (cgroup v1) or, with cgroup v2
+// where the path is usually "/", from the mounts the runtime creates for the container.
+func (d containerDetector) containerID() string {
+	id := scanLines(d.cgroupPath, func(line string) string {
+		// hierarchy-ID:controller-list:cgroup-path
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			return ""
+		}
+		segments := strings.Split(parts[2], "/")
+		if match := containerIDPattern.FindStringSubmatch(segments[len(segments)-1]); match != nil {
+			return match[1]
+		}
+		return ""
+	})
+	if id != "" {
+		return id
+	}
+
+	return scanLines(d.mountinfoPath, func(line string) string {
+		if match := mountContainerIDPattern.FindStringSubmatch(line); match != nil {
+			return match[1]
+		}
+		return ""
+	})
+}
+
+// scanLines returns the first non-empty result of match for the lines of the file, or "" if the file can't be read.
+func scanLines(path string, match func(line string) string) string {
+	file, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if result := match(scanner.Text()); result != "" {
+			return result
+		}
+	}
+	return ""
+}
@@ -0,0 +1,133 @@
+package outputs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wasilak/loggergo/lib/types"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const testContainerID = "4bf92f3577b34da6a3ce929d0e0e47364bf92f3577b34da6a3ce929d0e0e4736"
+
+// TestNewResource tests the precedence of configured resource attributes
+func TestNewResource(t *testing.T) {
+	res, err := NewResource(context.Background(), types.Config{
+		OtelServiceName: "myapp",
+		Resource: types.ResourceConfig{
+			ServiceVersion: "1.2.3",
+			Environment:    "staging",
+			Attributes:     map[string]string{"team": "payments", "service.name": "ignored"},
+			Detectors:      []types.ResourceDetector{types.ResourceDetectorHost, types.ResourceDetectorProcess, types.ResourceDetectorOS},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewResource failed: %v", err)
+	}
+
+	want := map[attribute.Key]string{
+		"service.name":           "myapp",
+		"service.version":        "1.2.3",
+		"deployment.environment": "staging",
+		"team":                   "payments",
+		"telemetry.sdk.name":     "opentelemetry",
+	}
+	for key, value := range want {
+		if got, ok := res.Set().Value(key); !ok || got.AsString() != value {
+			t.Errorf("Expected %s=%q, got %q", key, value, got.AsString())
+		}
+	}
+	for _, key := range []attribute.Key{"host.name", "process.pid", "os.type"} {
+		if _, ok := res.Set().Value(key); !ok {
+			t.Errorf("Expected detected attribute %s in %v", key, res.Attributes())
+		}
+	}
+	if _, ok := res.Set().Value("process.command_args"); ok {
+		t.Error("Expected command line arguments to be left out")
+	}
+}
+
+// TestNewResource_NoDetectors tests that nothing is detected unless requested
+func TestNewResource_NoDetectors(t *testing.T) {
+	res, err := NewResource(context.Background(), types.Config{OtelServiceName: "myapp"})
+	if err != nil {
+		t.Fatalf("NewResource failed: %v", err)
+	}
+	if _, ok := res.Set().Value("host.name"); ok {
+		t.Errorf("Expected no host attributes, got %v", res.Attributes())
+	}
+}
+
+// TestContainerDetector tests container ID parsing for cgroup v1 and v2 layouts
+func TestContainerDetector(t *testing.T) {
+	tests := []struct {
+		name      string
+		cgroup    string
+		mountinfo string
+		want      string
+	}{
+		{
+			name:   "docker cgroup v1",
+			cgroup: "12:cpu,cpuacct:/docker/" + testContainerID + "\n1:name=systemd:/docker/" + testContainerID + "\n",
+			want:   testContainerID,
+		},
+		{
+			name:   "systemd scope",
+			cgroup: "0::/system.slice/docker-" + testContainerID + ".scope\n",
+			want:   testContainerID,
+		},
+		{
+			name:   "kubernetes containerd",
+			cgroup: "0::/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod1234.slice/cri-containerd-" + testContainerID + ".scope\n",
+			want:   testContainerID,
+		},
+		{
+			name:      "cgroup v2 namespace",
+			cgroup:    "0::/\n",
+			mountinfo: "655 650 254:1 /docker/containers/" + testContainerID + "/hostname /etc/hostname rw,relatime - ext4 /dev/vda1 rw\n",
+			want:      testContainerID,
+		},
+		{
+			name:      "not a container",
+			cgroup:    "0::/user.slice/user-1000.slice/session-2.scope\n",
+			mountinfo: "22 1 254:1 / / rw,relatime - ext4 /dev/vda1 rw\n",
+			want:      "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			detector := containerDetector{
+				cgroupPath:    filepath.Join(dir, "cgroup"),
+				mountinfoPath: filepath.Join(dir, "mountinfo"),
+			}
+			os.WriteFile(detector.cgroupPath, []byte(tt.cgroup), 0o644)
+			os.WriteFile(detector.mountinfoPath, []byte(tt.mountinfo), 0o644)
+
+			res, err := detector.Detect(context.Background())
+			if err != nil {
+				t.Fatalf("Detect failed: %v", err)
+			}
+			got, _ := res.Set().Value("container.id")
+			if got.AsString() != tt.want {
+				t.Errorf("Expected container.id %q, got %q", tt.want, got.AsString())
+			}
+		})
+	}
+}
+
+// TestContainerDetector_MissingFiles tests that unreadable files yield an empty resource
+func TestContainerDetector_MissingFiles(t *testing.T) {
+	detector := containerDetector{cgroupPath: "/nonexistent/cgroup", mountinfoPath: "/nonexistent/mountinfo"}
+	res, err := detector.Detect(context.Background())
+	if err != nil || res.Len() != 0 {
+		t.Errorf("Expected empty resource, got %v, %v", res, err)
+	}
+	if strings.Contains(res.String(), "container") {
+		t.Errorf("Unexpected container attribute in %s", res)
+	}
+}
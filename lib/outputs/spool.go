@@ -0,0 +1,618 @@
+package outputs
+
+import (
+	"cmp"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wasilak/loggergo/lib/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/log"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Defaults of SpoolConfig.
+const (
+	defaultSpoolMaxSize       = 100 << 20
+	defaultSpoolSegmentSize   = 8 << 20
+	defaultSpoolRetryInterval = 5 * time.Second
+)
+
+// defaultSpoolExportTimeout bounds replayed exports when OTLPBatchConfig.ExportTimeout is not
+// set, as the batch processor bounds the other ones.
+const defaultSpoolExportTimeout = 30 * time.Second
+
+// spoolReplayBatchSize is the maximum number of records exported at once when replaying.
+const spoolReplayBatchSize = 512
+
+// spoolSegmentExt is the extension of segment files, which are named by their sequence number.
+const spoolSegmentExt = ".seg"
+
+// spoolLockFile is the name of the file locked by the spool using a directory.
+const spoolLockFile = ".lock"
+
+// errSpoolLocked is returned by NewSpool when another spool uses the directory.
+var errSpoolLocked = errors.New("spool directory is in use by another spool")
+
+// httpExportFailure matches the error otlploghttp returns for a response status it does not
+// retry, e.g. "failed to send logs to http://collector/v1/logs: 400 Bad Request (body: ...)".
+var httpExportFailure = regexp.MustCompile(`failed to send logs to \S+: (\d{3}) `)
+
+// SpoolStats is a snapshot of the metrics of a Spool.
+type SpoolStats struct {
+	Records      int64  `json:"records"`       // Records is the number of spooled records waiting to be exported.
+	Bytes        int64  `json:"bytes"`         // Bytes is the size of the spooled records waiting to be exported.
+	Segments     int    `json:"segments"`      // Segments is the number of segment files.
+	DroppedBytes uint64 `json:"dropped_bytes"` // DroppedBytes is the size of the records dropped because of MaxSize, non-retryable export errors or unreadable segment files.
+}
+
+// spoolSegment is a segment file in the spool directory.
+type spoolSegment struct {
+	seq     uint64
+	size    int64 // bytes in the file
+	records int64 // complete records in the file
+}
+
+// Spool keeps the records an OTLP exporter fails to export in segment files, as described by
+// types.SpoolConfig, and exports them again in order.
+//
+// Each record is stored as a length-prefixed OTLP ScopeLogs message with a single log record,
+// so segment files survive restarts and can be inspected with standard protobuf tools. Spooled
+// records are replayed with the resource of the current process and without scope attributes.
+// Records are exported at least once: those of a partly replayed segment may be exported again
+// after a restart. Only records failing with a retryable error, as defined by the OTLP
+// specification, are spooled; those the collector rejects otherwise are dropped.
+//
+// A spool locks its directory, so it cannot be used by two spools at the same time.
+//
+// The spool reports its Stats as the loggergo.spool.records, loggergo.spool.size and
+// loggergo.spool.dropped metrics of the global MeterProvider.
+type Spool struct {
+	dir           string
+	maxSize       int64
+	segmentSize   int64
+	retryInterval time.Duration
+	exportTimeout time.Duration
+
+	// replay rebuilds SDK records, with the resource and scope, from spooled ones
+	replay  *log.LoggerProvider
+	capture *captureProcessor
+	loggers map[spoolScope]otellog.Logger
+
+	exportMu sync.Mutex // serializes exports, so spooled records are exported in order
+	exporter log.Exporter
+
+	lock *os.File // lock file of the directory, held until Close
+
+	mu              sync.Mutex
+	segments        []*spoolSegment // oldest first
+	file            *os.File        // last segment, opened for appending, or nil
+	offset          int64           // bytes of the first segment already exported
+	exportedRecords int64           // records of the first segment already exported
+	size            int64           // total size of the segment files
+	records         int64           // records not yet exported
+	dropped         uint64
+
+	registration metric.Registration
+	ctx          context.Context
+	cancel       context.CancelFunc
+	done         chan struct{}
+	closeOnce    sync.Once
+}
+
+// spoolScope identifies the logger records are replayed with.
+type spoolScope struct {
+	name, version, schemaURL string
+}
+
+// NewSpool opens the spool in config.Spool.Dir, creating the directory if needed, locks it and
+// loads the segments left by a previous process. It fails if another spool, in this process or
+// another one, uses the directory. Replayed records carry the resource created by NewResource
+// from config. The spool is used by wrapping an exporter with WrapExporter.
+func NewSpool(ctx context.Context, config types.Config) (_ *Spool, err error) {
+	s := &Spool{
+		dir:           config.Spool.Dir,
+		maxSize:       config.Spool.MaxSize,
+		segmentSize:   config.Spool.SegmentSize,
+		retryInterval: config.Spool.RetryInterval,
+		exportTimeout: config.OTLP.Batch.ExportTimeout,
+		capture:       &captureProcessor{},
+		loggers:       make(map[spoolScope]otellog.Logger),
+		done:          make(chan struct{}),
+	}
+	if s.maxSize == 0 {
+		s.maxSize = defaultSpoolMaxSize
+	}
+	if s.segmentSize == 0 {
+		s.segmentSize = min(defaultSpoolSegmentSize, s.maxSize)
+	}
+	if s.retryInterval == 0 {
+		s.retryInterval = defaultSpoolRetryInterval
+	}
+	if s.exportTimeout == 0 {
+		s.exportTimeout = defaultSpoolExportTimeout
+	}
+
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create spool directory: %w", err)
+	}
+	if s.lock, err = os.OpenFile(filepath.Join(s.dir, spoolLockFile), os.O_CREATE|os.O_RDWR, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to open spool lock file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			s.lock.Close()
+		}
+	}()
+	if err := lockSpoolDir(s.lock); err != nil {
+		return nil, fmt.Errorf("failed to lock spool directory %s: %w", s.dir, err)
+	}
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	res, err := NewResource(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	s.replay = log.NewLoggerProvider(log.WithResource(res), log.WithProcessor(s.capture))
+
+	if err := s.registerMetrics(); err != nil {
+		return nil, fmt.Errorf("failed to register spool metrics: %w", err)
+	}
+
+	s.ctx, s.cancel = context.WithCancel(context.WithoutCancel(ctx))
+	return s, nil
+}
+
+// WrapExporter returns an exporter that spools the records exporter fails to export and
+// starts replaying spooled records with exporter. It must be called once.
+func (s *Spool) WrapExporter(exporter log.Exporter) log.Exporter {
+	s.exporter = exporter
+	go s.run()
+	return &spoolExporter{Exporter: exporter, spool: s}
+}
+
+// Stats returns the current metrics of the spool.
+func (s *Spool) Stats() SpoolStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return SpoolStats{
+		Records:      s.records,
+		Bytes:        s.size - s.offset,
+		Segments:     len(s.segments),
+		DroppedBytes: s.dropped,
+	}
+}
+
+// Close stops replaying, closes the segment being written and unlocks the directory. Spooled
+// records stay on disk. It is safe to call more than once.
+func (s *Spool) Close() error {
+	var err error
+	s.closeOnce.Do(func() {
+		if s.cancel != nil {
+			s.cancel()
+		}
+		if s.exporter != nil {
+			<-s.done
+		}
+		if s.registration != nil {
+			err = s.registration.Unregister()
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.file != nil {
+			err = errors.Join(err, s.file.Close())
+			s.file = nil
+		}
+		err = errors.Join(err, s.lock.Close())
+	})
+	return err
+}
+
+// export exports the records with the wrapped exporter, or spools them if that fails with a
+// retryable error or if there are spooled records waiting to be exported before them. Records
+// failing with another error are dropped and the error is returned.
+func (s *Spool) export(ctx context.Context, records []log.Record) error {
+	s.exportMu.Lock()
+	defer s.exportMu.Unlock()
+
+	frames, err := encodeSpoolFrames(records)
+	if err != nil {
+		return err
+	}
+	if s.Stats().Records == 0 {
+		err := s.exporter.Export(ctx, records)
+		if err == nil {
+			return nil
+		}
+		if !isRetryable(err) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			for _, frame := range frames {
+				s.dropped += uint64(len(frame))
+			}
+			return err
+		}
+	}
+	return s.append(frames)
+}
+
+// append writes the frames to the last segment, starting a new one when it is full and
+// dropping the oldest segments, or the frames, if the spool would grow beyond maxSize.
+func (s *Spool) append(frames [][]byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, frame := range frames {
+		n := int64(len(frame))
+		for s.size+n > s.maxSize && (len(s.segments) > 1 || (len(s.segments) == 1 && s.file == nil)) {
+			s.dropOldest()
+		}
+		if s.size+n > s.maxSize {
+			s.dropped += uint64(n)
+			continue
+		}
+
+		if s.file == nil || (s.last().size > 0 && s.last().size+n > s.segmentSize) {
+			if err := s.rotate(); err != nil {
+				return err
+			}
+		}
+		if _, err := s.file.Write(frame); err != nil {
+			return fmt.Errorf("failed to write spool segment: %w", err)
+		}
+		segment := s.last()
+		segment.size += n
+		segment.records++
+		s.size += n
+		s.records++
+	}
+
+	if s.file == nil {
+		return nil
+	}
+	return s.file.Sync()
+}
+
+// run replays spooled records every retryInterval until the spool is closed.
+func (s *Spool) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.retryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			for {
+				more, err := s.replayBatch()
+				if err != nil || !more {
+					break
+				}
+			}
+		}
+	}
+}
+
+// replayBatch exports the next spooled records of the first segment and removes the segment
+// once all of them have been exported. Records failing with a non-retryable error, and segments
+// removed from the directory, are dropped. It reports whether there are more records to replay.
+func (s *Spool) replayBatch() (bool, error) {
+	s.exportMu.Lock()
+	defer s.exportMu.Unlock()
+
+	s.mu.Lock()
+	if len(s.segments) == 0 {
+		s.mu.Unlock()
+		return false, nil
+	}
+	segment, offset := s.segments[0], s.offset
+	data, err := os.ReadFile(s.segmentPath(segment.seq))
+	if os.IsNotExist(err) {
+		defer s.mu.Unlock()
+		if s.file != nil && len(s.segments) == 1 {
+			s.file.Close()
+			s.file = nil
+		}
+		s.dropOldest()
+		return len(s.segments) > 0, nil
+	}
+	s.mu.Unlock()
+	if err != nil {
+		return false, fmt.Errorf("failed to read spool segment: %w", err)
+	}
+
+	frames, consumed := parseSpoolFrames(data[min(offset, int64(len(data))):], spoolReplayBatchSize)
+	records, dropped := s.decode(frames)
+	if len(records) > 0 {
+		ctx, cancel := context.WithTimeout(s.ctx, s.exportTimeout)
+		err := s.exporter.Export(ctx, records)
+		cancel()
+		if err != nil {
+			if isRetryable(err) {
+				return false, err
+			}
+			dropped = uint64(consumed)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Segments are only dropped by append, which also holds exportMu, so segment is still the first one
+	s.dropped += dropped
+	s.offset += consumed
+	s.exportedRecords += int64(len(frames))
+	s.records -= int64(len(frames))
+
+	active := len(s.segments) == 1 && s.file != nil
+	if len(frames) == 0 && !active {
+		// An incomplete record at the end of a segment written by a crashed process
+		s.dropped += uint64(segment.size - s.offset)
+		s.offset = segment.size
+	}
+	if s.offset < segment.size {
+		return true, nil
+	}
+
+	if active {
+		s.file.Close()
+		s.file = nil
+	}
+	s.removeFirst()
+	return len(s.segments) > 0, nil
+}
+
+// decode rebuilds SDK records from spooled frames, dropping those that cannot be decoded, and
+// returns the records and the size of the dropped frames. Must be called with exportMu locked.
+func (s *Spool) decode(frames [][]byte) ([]log.Record, uint64) {
+	var dropped uint64
+	s.capture.records = nil
+	for _, frame := range frames {
+		scopeLogs := &logspb.ScopeLogs{}
+		if err := proto.Unmarshal(frame, scopeLogs); err != nil || len(scopeLogs.LogRecords) != 1 {
+			dropped += uint64(len(frame))
+			continue
+		}
+
+		scope := spoolScope{scopeLogs.GetScope().GetName(), scopeLogs.GetScope().GetVersion(), scopeLogs.SchemaUrl}
+		logger, ok := s.loggers[scope]
+		if !ok {
+			logger = s.replay.Logger(scope.name, otellog.WithInstrumentationVersion(scope.version), otellog.WithSchemaURL(scope.schemaURL))
+			s.loggers[scope] = logger
+		}
+		ctx, record := decodeSpoolRecord(scopeLogs.LogRecords[0])
+		logger.Emit(ctx, record)
+	}
+	return s.capture.records, dropped
+}
+
+// load reads the segments in the spool directory, counting their complete records.
+func (s *Spool) load() error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read spool directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), spoolSegmentExt)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		seq, err := strconv.ParseUint(name, 10, 64)
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.dir, entry.Name()))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read spool segment: %w", err)
+		}
+
+		frames, _ := parseSpoolFrames(data, -1)
+		segment := &spoolSegment{seq: seq, size: int64(len(data)), records: int64(len(frames))}
+		s.segments = append(s.segments, segment)
+		s.size += segment.size
+		s.records += segment.records
+	}
+
+	slices.SortFunc(s.segments, func(a, b *spoolSegment) int { return cmp.Compare(a.seq, b.seq) })
+	return nil
+}
+
+// rotate closes the last segment and starts a new one. Must be called with mu locked.
+func (s *Spool) rotate() error {
+	if s.file != nil {
+		if err := s.file.Close(); err != nil {
+			return fmt.Errorf("failed to close spool segment: %w", err)
+		}
+		s.file = nil
+	}
+
+	seq := uint64(1)
+	if len(s.segments) > 0 {
+		seq = s.last().seq + 1
+	}
+	file, err := os.OpenFile(s.segmentPath(seq), os.O_CREATE|os.O_EXCL|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create spool segment: %w", err)
+	}
+	s.file = file
+	s.segments = append(s.segments, &spoolSegment{seq: seq})
+	return nil
+}
+
+// dropOldest removes the first segment, counting its records that were not exported as dropped.
+// It must not be the segment being written. Must be called with mu locked.
+func (s *Spool) dropOldest() {
+	segment := s.segments[0]
+	s.dropped += uint64(segment.size - s.offset)
+	s.records -= segment.records - s.exportedRecords
+	s.removeFirst()
+}
+
+// removeFirst deletes the first segment file. Must be called with mu locked.
+func (s *Spool) removeFirst() {
+	segment := s.segments[0]
+	os.Remove(s.segmentPath(segment.seq))
+	s.size -= segment.size
+	s.segments = s.segments[1:]
+	s.offset = 0
+	s.exportedRecords = 0
+}
+
+// last returns the last segment. Must be called with mu locked.
+func (s *Spool) last() *spoolSegment {
+	return s.segments[len(s.segments)-1]
+}
+
+// segmentPath returns the path of the segment with the given sequence number.
+func (s *Spool) segmentPath(seq uint64) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%020d%s", seq, spoolSegmentExt))
+}
+
+// registerMetrics reports the stats of the spool with the global MeterProvider.
+func (s *Spool) registerMetrics() error {
+	meter := otel.Meter("github.com/wasilak/loggergo")
+	records, err := meter.Int64ObservableGauge("loggergo.spool.records",
+		metric.WithDescription("Number of spooled log records waiting to be exported"), metric.WithUnit("{record}"))
+	if err != nil {
+		return err
+	}
+	size, err := meter.Int64ObservableGauge("loggergo.spool.size",
+		metric.WithDescription("Size of the spooled log records waiting to be exported"), metric.WithUnit("By"))
+	if err != nil {
+		return err
+	}
+	dropped, err := meter.Int64ObservableCounter("loggergo.spool.dropped",
+		metric.WithDescription("Size of the spooled log records dropped"), metric.WithUnit("By"))
+	if err != nil {
+		return err
+	}
+
+	attrs := metric.WithAttributes(attribute.String("spool.dir", s.dir))
+	s.registration, err = meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		stats := s.Stats()
+		o.ObserveInt64(records, stats.Records, attrs)
+		o.ObserveInt64(size, stats.Bytes, attrs)
+		o.ObserveInt64(dropped, int64(stats.DroppedBytes), attrs)
+		return nil
+	}, records, size, dropped)
+	return err
+}
+
+// encodeSpoolFrames encodes records as length-prefixed frames to be written to a segment.
+func encodeSpoolFrames(records []log.Record) ([][]byte, error) {
+	frames := make([][]byte, 0, len(records))
+	for i := range records {
+		data, err := proto.Marshal(encodeSpoolRecord(&records[i]))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode spooled record: %w", err)
+		}
+		frames = append(frames, append(binary.AppendUvarint(nil, uint64(len(data))), data...))
+	}
+	return frames, nil
+}
+
+// isRetryable reports whether an export failing with err may succeed later. Following the OTLP
+// specification, gRPC status codes and HTTP response statuses other than the retryable ones
+// are not; any other error, such as a network error or a timeout, is.
+func isRetryable(err error) bool {
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.Canceled, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted,
+			codes.OutOfRange, codes.Unavailable, codes.DataLoss:
+			return true
+		}
+		return false
+	}
+	if match := httpExportFailure.FindStringSubmatch(err.Error()); match != nil {
+		switch match[1] {
+		case "429", "502", "503", "504":
+			return true
+		}
+		return false
+	}
+	return true
+}
+
+// parseSpoolFrames returns up to limit (or all, if limit is negative) complete length-prefixed
+// frames at the start of data and the number of bytes they take.
+func parseSpoolFrames(data []byte, limit int) ([][]byte, int64) {
+	var frames [][]byte
+	var consumed int64
+	for limit < 0 || len(frames) < limit {
+		length, n := binary.Uvarint(data)
+		if n <= 0 || uint64(len(data)-n) < length {
+			break
+		}
+		frames = append(frames, data[n:n+int(length)])
+		data = data[n+int(length):]
+		consumed += int64(n) + int64(length)
+	}
+	return frames, consumed
+}
+
+// spoolExporter spools the records the embedded exporter fails to export.
+type spoolExporter struct {
+	log.Exporter
+	spool *Spool
+}
+
+// Export exports the records, or spools them.
+func (e *spoolExporter) Export(ctx context.Context, records []log.Record) error {
+	return e.spool.export(ctx, records)
+}
+
+// Shutdown closes the spool and shuts down the embedded exporter.
+func (e *spoolExporter) Shutdown(ctx context.Context) error {
+	return errors.Join(e.spool.Close(), e.Exporter.Shutdown(ctx))
+}
+
+// captureProcessor collects the records emitted to the replay provider of a spool.
+type captureProcessor struct {
+	records []log.Record
+}
+
+// OnEmit keeps a copy of the record.
+func (p *captureProcessor) OnEmit(ctx context.Context, record *log.Record) error {
+	p.records = append(p.records, record.Clone())
+	return nil
+}
+
+// Enabled reports that every record is processed.
+func (p *captureProcessor) Enabled(ctx context.Context, param log.EnabledParameters) bool {
+	return true
+}
+
+// Shutdown does nothing.
+func (p *captureProcessor) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+// ForceFlush does nothing.
+func (p *captureProcessor) ForceFlush(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,132 @@
+package outputs
+
+import (
+	"context"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/trace"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+)
+
+// encodeSpoolRecord converts an SDK record to the OTLP message stored in a spool segment.
+func encodeSpoolRecord(r *log.Record) *logspb.ScopeLogs {
+	record := &logspb.LogRecord{
+		TimeUnixNano:         unixNano(r.Timestamp()),
+		ObservedTimeUnixNano: unixNano(r.ObservedTimestamp()),
+		SeverityNumber:       logspb.SeverityNumber(r.Severity()),
+		SeverityText:         r.SeverityText(),
+		Body:                 encodeSpoolValue(r.Body()),
+		EventName:            r.EventName(),
+		Flags:                uint32(r.TraceFlags()),
+	}
+	if traceID := r.TraceID(); traceID.IsValid() {
+		record.TraceId = traceID[:]
+	}
+	if spanID := r.SpanID(); spanID.IsValid() {
+		record.SpanId = spanID[:]
+	}
+	r.WalkAttributes(func(kv otellog.KeyValue) bool {
+		record.Attributes = append(record.Attributes, &commonpb.KeyValue{Key: kv.Key, Value: encodeSpoolValue(kv.Value)})
+		return true
+	})
+
+	scope := r.InstrumentationScope()
+	return &logspb.ScopeLogs{
+		Scope:      &commonpb.InstrumentationScope{Name: scope.Name, Version: scope.Version},
+		SchemaUrl:  scope.SchemaURL,
+		LogRecords: []*logspb.LogRecord{record},
+	}
+}
+
+// decodeSpoolRecord converts a spooled OTLP record to an API record and a context carrying its span.
+func decodeSpoolRecord(record *logspb.LogRecord) (context.Context, otellog.Record) {
+	var r otellog.Record
+	if record.TimeUnixNano != 0 {
+		r.SetTimestamp(time.Unix(0, int64(record.TimeUnixNano)))
+	}
+	if record.ObservedTimeUnixNano != 0 {
+		r.SetObservedTimestamp(time.Unix(0, int64(record.ObservedTimeUnixNano)))
+	}
+	r.SetSeverity(otellog.Severity(record.SeverityNumber))
+	r.SetSeverityText(record.SeverityText)
+	r.SetBody(decodeSpoolValue(record.Body))
+	r.SetEventName(record.EventName)
+	for _, kv := range record.Attributes {
+		r.AddAttributes(otellog.KeyValue{Key: kv.Key, Value: decodeSpoolValue(kv.Value)})
+	}
+
+	var config trace.SpanContextConfig
+	copy(config.TraceID[:], record.TraceId)
+	copy(config.SpanID[:], record.SpanId)
+	config.TraceFlags = trace.TraceFlags(record.Flags)
+	return trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(config)), r
+}
+
+// encodeSpoolValue converts a log value to its OTLP representation. The empty value is nil.
+func encodeSpoolValue(v otellog.Value) *commonpb.AnyValue {
+	switch v.Kind() {
+	case otellog.KindBool:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: v.AsBool()}}
+	case otellog.KindInt64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: v.AsInt64()}}
+	case otellog.KindFloat64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: v.AsFloat64()}}
+	case otellog.KindString:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v.AsString()}}
+	case otellog.KindBytes:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BytesValue{BytesValue: v.AsBytes()}}
+	case otellog.KindSlice:
+		values := make([]*commonpb.AnyValue, 0, len(v.AsSlice()))
+		for _, value := range v.AsSlice() {
+			values = append(values, encodeSpoolValue(value))
+		}
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{ArrayValue: &commonpb.ArrayValue{Values: values}}}
+	case otellog.KindMap:
+		kvs := make([]*commonpb.KeyValue, 0, len(v.AsMap()))
+		for _, kv := range v.AsMap() {
+			kvs = append(kvs, &commonpb.KeyValue{Key: kv.Key, Value: encodeSpoolValue(kv.Value)})
+		}
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_KvlistValue{KvlistValue: &commonpb.KeyValueList{Values: kvs}}}
+	}
+	return nil
+}
+
+// decodeSpoolValue converts an OTLP value to a log value. Nil is the empty value.
+func decodeSpoolValue(v *commonpb.AnyValue) otellog.Value {
+	switch v := v.GetValue().(type) {
+	case *commonpb.AnyValue_BoolValue:
+		return otellog.BoolValue(v.BoolValue)
+	case *commonpb.AnyValue_IntValue:
+		return otellog.Int64Value(v.IntValue)
+	case *commonpb.AnyValue_DoubleValue:
+		return otellog.Float64Value(v.DoubleValue)
+	case *commonpb.AnyValue_StringValue:
+		return otellog.StringValue(v.StringValue)
+	case *commonpb.AnyValue_BytesValue:
+		return otellog.BytesValue(v.BytesValue)
+	case *commonpb.AnyValue_ArrayValue:
+		values := make([]otellog.Value, 0, len(v.ArrayValue.GetValues()))
+		for _, value := range v.ArrayValue.GetValues() {
+			values = append(values, decodeSpoolValue(value))
+		}
+		return otellog.SliceValue(values...)
+	case *commonpb.AnyValue_KvlistValue:
+		kvs := make([]otellog.KeyValue, 0, len(v.KvlistValue.GetValues()))
+		for _, kv := range v.KvlistValue.GetValues() {
+			kvs = append(kvs, otellog.KeyValue{Key: kv.Key, Value: decodeSpoolValue(kv.Value)})
+		}
+		return otellog.MapValue(kvs...)
+	}
+	return otellog.Value{}
+}
+
+// unixNano returns t in nanoseconds since the Unix epoch, or 0 for the zero time.
+func unixNano(t time.Time) uint64 {
+	if t.IsZero() {
+		return 0
+	}
+	return uint64(t.UnixNano())
+}
@@ -0,0 +1,10 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package outputs
+
+import "os"
+
+// lockSpoolDir does nothing on platforms without flock, where spool directories are not locked.
+func lockSpoolDir(file *os.File) error {
+	return nil
+}
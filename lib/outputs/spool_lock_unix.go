@@ -0,0 +1,19 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package outputs
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// lockSpoolDir takes an exclusive lock on the lock file of a spool directory. The lock is held
+// until the file is closed, by this process or when it exits.
+func lockSpoolDir(file *os.File) error {
+	err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if errors.Is(err, syscall.EWOULDBLOCK) {
+		return errSpoolLocked
+	}
+	return err
+}
@@ -0,0 +1,453 @@
+package outputs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/wasilak/loggergo/lib/types"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toggleReceiver is a stub OTLP/HTTP collector that fails exports while it is down or rejecting
+type toggleReceiver struct {
+	otlpReceiver
+	down      atomic.Bool
+	rejecting atomic.Bool
+}
+
+// ServeHTTP answers 503 (retryable) while down, 400 (not retryable) while rejecting and records
+// the request otherwise
+func (r *toggleReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if r.down.Load() {
+		http.Error(w, "collector down", http.StatusServiceUnavailable)
+		return
+	}
+	if r.rejecting.Load() {
+		http.Error(w, "invalid records", http.StatusBadRequest)
+		return
+	}
+	r.otlpReceiver.ServeHTTP(w, req)
+}
+
+// bodies returns the bodies of the received records in the order they were exported
+func (r *toggleReceiver) bodies() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var bodies []string
+	for _, request := range r.requests {
+		for _, resourceLogs := range request.GetResourceLogs() {
+			for _, scopeLogs := range resourceLogs.GetScopeLogs() {
+				for _, record := range scopeLogs.GetLogRecords() {
+					bodies = append(bodies, record.GetBody().GetStringValue())
+				}
+			}
+		}
+	}
+	return bodies
+}
+
+// newSpoolProvider creates an OTLP provider exporting to url through a spool in dir
+func newSpoolProvider(t *testing.T, url, dir string) (*log.LoggerProvider, *Spool) {
+	t.Helper()
+	config := types.Config{
+		OtelServiceName: "spool-test",
+		OTLP: types.OTLPConfig{
+			Endpoint: url,
+			Protocol: types.OTLPProtocolHTTP,
+			// Give up retrying a failed export early
+			Batch: types.OTLPBatchConfig{ExportTimeout: 200 * time.Millisecond},
+		},
+		Spool: types.SpoolConfig{Dir: dir, RetryInterval: 20 * time.Millisecond},
+	}
+	spool, err := NewSpool(context.Background(), config)
+	if err != nil {
+		t.Fatalf("NewSpool failed: %v", err)
+	}
+	provider, err := NewOTLPProvider(context.Background(), config, spool.WrapExporter)
+	if err != nil {
+		t.Fatalf("NewOTLPProvider failed: %v", err)
+	}
+	return provider, spool
+}
+
+// segmentFiles returns the names of the segment files in dir
+func segmentFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	var names []string
+	for _, name := range listDir(t, dir) {
+		if strings.HasSuffix(name, spoolSegmentExt) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// emit emits a record with the given body and flushes it to the exporter
+func emit(t *testing.T, provider *log.LoggerProvider, body string) {
+	t.Helper()
+	var record otellog.Record
+	record.SetBody(otellog.StringValue(body))
+	record.SetSeverity(otellog.SeverityInfo)
+	provider.Logger("test").Emit(context.Background(), record)
+	if err := provider.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("ForceFlush failed: %v", err)
+	}
+}
+
+// waitFor polls condition until it is true or the timeout expires
+func waitFor(t *testing.T, what string, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestSpool_ReplaysInOrder tests spooling records while the collector is down and replaying them in order once it is up
+func TestSpool_ReplaysInOrder(t *testing.T) {
+	receiver := &toggleReceiver{}
+	receiver.down.Store(true)
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	dir := t.TempDir()
+	provider, spool := newSpoolProvider(t, server.URL, dir)
+	defer provider.Shutdown(context.Background())
+
+	emit(t, provider, "one")
+	emit(t, provider, "two")
+	emit(t, provider, "three")
+	if stats := spool.Stats(); stats.Records != 3 || stats.Bytes == 0 || stats.Segments != 1 {
+		t.Fatalf("Expected 3 spooled records in 1 segment, got %+v", stats)
+	}
+
+	receiver.down.Store(false)
+	// Queued behind the spooled records, even though the collector is up
+	emit(t, provider, "four")
+	waitFor(t, "the spool to drain", func() bool { return spool.Stats().Records == 0 })
+
+	want := []string{"one", "two", "three", "four"}
+	if bodies := receiver.bodies(); !slices.Equal(bodies, want) {
+		t.Errorf("Expected records %v, got %v", want, bodies)
+	}
+	if files := segmentFiles(t, dir); len(files) != 0 {
+		t.Errorf("Expected the exported segments to be removed, got %v", files)
+	}
+
+	emit(t, provider, "five")
+	if bodies := receiver.bodies(); len(bodies) != 5 || bodies[4] != "five" {
+		t.Errorf("Expected the record to be exported directly, got %v", bodies)
+	}
+}
+
+// TestSpool_SurvivesRestart tests replaying the records spooled by a previous provider with the current resource
+func TestSpool_SurvivesRestart(t *testing.T) {
+	receiver := &toggleReceiver{}
+	receiver.down.Store(true)
+	server := httptest.NewServer(receiver)
+
+	dir := t.TempDir()
+	provider, _ := newSpoolProvider(t, server.URL, dir)
+	emit(t, provider, "before restart")
+	if err := provider.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	// Wait for a replay canceled by Shutdown to be answered while the collector is still down
+	server.Close()
+	if files := segmentFiles(t, dir); len(files) != 1 {
+		t.Fatalf("Expected 1 segment left on disk, got %v", files)
+	}
+
+	receiver.down.Store(false)
+	server = httptest.NewServer(receiver)
+	defer server.Close()
+	provider, spool := newSpoolProvider(t, server.URL, dir)
+	defer provider.Shutdown(context.Background())
+	if stats := spool.Stats(); stats.Records != 1 {
+		t.Fatalf("Expected the spooled record to be loaded, got %+v", stats)
+	}
+	waitFor(t, "the spool to drain", func() bool { return spool.Stats().Records == 0 })
+
+	if bodies := receiver.bodies(); !slices.Equal(bodies, []string{"before restart"}) {
+		t.Errorf("Expected the spooled record, got %v", bodies)
+	}
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+	var serviceName string
+	for _, attr := range receiver.requests[0].GetResourceLogs()[0].GetResource().GetAttributes() {
+		if attr.GetKey() == "service.name" {
+			serviceName = attr.GetValue().GetStringValue()
+		}
+	}
+	if serviceName != "spool-test" {
+		t.Errorf("Expected the replayed record to carry service.name spool-test, got %q", serviceName)
+	}
+}
+
+// TestSpool_IgnoresTruncatedRecord tests that an incomplete record left by a crash is counted as dropped
+func TestSpool_IgnoresTruncatedRecord(t *testing.T) {
+	receiver := &toggleReceiver{}
+	receiver.down.Store(true)
+	server := httptest.NewServer(receiver)
+
+	dir := t.TempDir()
+	provider, _ := newSpoolProvider(t, server.URL, dir)
+	emit(t, provider, "complete")
+	provider.Shutdown(context.Background())
+	server.Close()
+
+	files := segmentFiles(t, dir)
+	file, err := os.OpenFile(filepath.Join(dir, files[0]), os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	file.Write([]byte{100, 1, 2})
+	file.Close()
+
+	receiver.down.Store(false)
+	server = httptest.NewServer(receiver)
+	defer server.Close()
+	provider, spool := newSpoolProvider(t, server.URL, dir)
+	defer provider.Shutdown(context.Background())
+	waitFor(t, "the spool to drain", func() bool { return spool.Stats().Segments == 0 })
+
+	if bodies := receiver.bodies(); !slices.Equal(bodies, []string{"complete"}) {
+		t.Errorf("Expected the complete record, got %v", bodies)
+	}
+	if stats := spool.Stats(); stats.DroppedBytes != 3 {
+		t.Errorf("Expected the 3 bytes of the truncated record to be dropped, got %+v", stats)
+	}
+}
+
+// TestSpool_DropsRejectedRecords tests dropping the records the collector rejects instead of spooling them
+func TestSpool_DropsRejectedRecords(t *testing.T) {
+	receiver := &toggleReceiver{}
+	receiver.rejecting.Store(true)
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	dir := t.TempDir()
+	provider, spool := newSpoolProvider(t, server.URL, dir)
+	defer provider.Shutdown(context.Background())
+
+	emit(t, provider, "rejected")
+	stats := spool.Stats()
+	if stats.Records != 0 || stats.Segments != 0 || stats.DroppedBytes == 0 {
+		t.Fatalf("Expected the rejected record to be dropped, got %+v", stats)
+	}
+
+	// Spooled while the collector is down, rejected when replayed
+	receiver.rejecting.Store(false)
+	receiver.down.Store(true)
+	emit(t, provider, "spooled")
+	if spool.Stats().Records != 1 {
+		t.Fatalf("Expected the record to be spooled, got %+v", spool.Stats())
+	}
+	receiver.rejecting.Store(true)
+	receiver.down.Store(false)
+	waitFor(t, "the spool to drain", func() bool { return spool.Stats().Segments == 0 })
+	if dropped := spool.Stats().DroppedBytes; dropped <= stats.DroppedBytes {
+		t.Errorf("Expected the replayed record to be dropped, got %d dropped bytes", dropped)
+	}
+
+	receiver.rejecting.Store(false)
+	emit(t, provider, "accepted")
+	if bodies := receiver.bodies(); !slices.Equal(bodies, []string{"accepted"}) {
+		t.Errorf("Expected only the accepted record, got %v", bodies)
+	}
+}
+
+// TestSpool_SkipsMissingSegment tests dropping a segment removed from the directory and replaying the next ones
+func TestSpool_SkipsMissingSegment(t *testing.T) {
+	record := func(body string) log.Record {
+		var r log.Record
+		r.SetBody(otellog.StringValue(body))
+		return r
+	}
+	frameSize := int64(len(spoolFrame(t, record("record-0"))))
+
+	dir := t.TempDir()
+	spool, err := NewSpool(context.Background(), types.Config{Spool: types.SpoolConfig{
+		Dir:           dir,
+		SegmentSize:   2 * frameSize,
+		RetryInterval: 20 * time.Millisecond,
+	}})
+	if err != nil {
+		t.Fatalf("NewSpool failed: %v", err)
+	}
+	inner := &failingExporter{}
+	inner.failing.Store(true)
+	exporter := spool.WrapExporter(inner)
+	defer exporter.Shutdown(context.Background())
+
+	for _, body := range []string{"record-0", "record-1", "record-2"} {
+		if err := exporter.Export(context.Background(), []log.Record{record(body)}); err != nil {
+			t.Fatalf("Export failed: %v", err)
+		}
+	}
+	if err := os.Remove(spool.segmentPath(1)); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+
+	inner.failing.Store(false)
+	waitFor(t, "the spool to drain", func() bool { return spool.Stats().Records == 0 })
+
+	inner.mu.Lock()
+	defer inner.mu.Unlock()
+	if !slices.Equal(inner.bodies, []string{"record-2"}) {
+		t.Errorf("Expected the records of the remaining segment, got %v", inner.bodies)
+	}
+	if stats := spool.Stats(); stats.DroppedBytes != uint64(2*frameSize) {
+		t.Errorf("Expected the missing segment to be dropped, got %+v", stats)
+	}
+}
+
+// TestNewSpool_LocksDir tests that two spools cannot use the same directory at the same time
+func TestNewSpool_LocksDir(t *testing.T) {
+	config := types.Config{Spool: types.SpoolConfig{Dir: t.TempDir()}}
+	spool, err := NewSpool(context.Background(), config)
+	if err != nil {
+		t.Fatalf("NewSpool failed: %v", err)
+	}
+
+	if _, err := NewSpool(context.Background(), config); !errors.Is(err, errSpoolLocked) {
+		t.Fatalf("Expected the directory to be locked, got %v", err)
+	}
+
+	if err := spool.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	spool, err = NewSpool(context.Background(), config)
+	if err != nil {
+		t.Fatalf("Expected the directory to be unlocked by Close, got %v", err)
+	}
+	spool.Close()
+}
+
+// TestIsRetryable tests classifying export errors as the OTLP specification does
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{status.Error(codes.Unavailable, "connection refused"), true},
+		{fmt.Errorf("max retry time elapsed: %w", status.Error(codes.ResourceExhausted, "slow down")), true},
+		{status.Error(codes.InvalidArgument, "bad record"), false},
+		{status.Error(codes.Unauthenticated, "no token"), false},
+		{errors.New("failed to send logs to http://collector/v1/logs: 400 Bad Request (body: invalid)"), false},
+		{errors.New("failed to send logs to http://collector/v1/logs: 413 Request Entity Too Large (body: (empty))"), false},
+		{errors.New("max retry time elapsed: retry-able request failure: body: overloaded"), true},
+		{context.DeadlineExceeded, true},
+		{errors.New("dial tcp: connection refused"), true},
+	}
+	for _, tt := range tests {
+		if got := isRetryable(tt.err); got != tt.want {
+			t.Errorf("isRetryable(%q) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+// failingExporter records the bodies it exports and fails while failing is set
+type failingExporter struct {
+	failing atomic.Bool
+
+	mu     sync.Mutex
+	bodies []string
+}
+
+func (e *failingExporter) Export(ctx context.Context, records []log.Record) error {
+	if e.failing.Load() {
+		return errors.New("export failed")
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, record := range records {
+		e.bodies = append(e.bodies, record.Body().AsString())
+	}
+	return nil
+}
+
+func (e *failingExporter) Shutdown(ctx context.Context) error   { return nil }
+func (e *failingExporter) ForceFlush(ctx context.Context) error { return nil }
+
+// TestSpool_MaxSizeDropsOldest tests dropping the oldest segments when the spool is full
+func TestSpool_MaxSizeDropsOldest(t *testing.T) {
+	record := func(body string) log.Record {
+		var r log.Record
+		r.SetBody(otellog.StringValue(body))
+		return r
+	}
+	frameSize := int64(len(spoolFrame(t, record("record-0"))))
+
+	dir := t.TempDir()
+	spool, err := NewSpool(context.Background(), types.Config{Spool: types.SpoolConfig{
+		Dir:           dir,
+		MaxSize:       4 * frameSize,
+		SegmentSize:   2 * frameSize,
+		RetryInterval: 20 * time.Millisecond,
+	}})
+	if err != nil {
+		t.Fatalf("NewSpool failed: %v", err)
+	}
+	inner := &failingExporter{}
+	inner.failing.Store(true)
+	exporter := spool.WrapExporter(inner)
+	defer exporter.Shutdown(context.Background())
+
+	for _, body := range []string{"record-0", "record-1", "record-2", "record-3", "record-4", "record-5"} {
+		if err := exporter.Export(context.Background(), []log.Record{record(body)}); err != nil {
+			t.Fatalf("Export failed: %v", err)
+		}
+	}
+	stats := spool.Stats()
+	if stats.Records != 4 || stats.Segments != 2 || stats.Bytes != 4*frameSize || stats.DroppedBytes != uint64(2*frameSize) {
+		t.Fatalf("Expected 4 records in 2 segments and the first segment dropped, got %+v", stats)
+	}
+
+	inner.failing.Store(false)
+	waitFor(t, "the spool to drain", func() bool { return spool.Stats().Records == 0 })
+
+	inner.mu.Lock()
+	defer inner.mu.Unlock()
+	want := []string{"record-2", "record-3", "record-4", "record-5"}
+	if !slices.Equal(inner.bodies, want) {
+		t.Errorf("Expected records %v, got %v", want, inner.bodies)
+	}
+}
+
+// spoolFrame returns the encoded frame of a record as written to a segment
+func spoolFrame(t *testing.T, r log.Record) []byte {
+	t.Helper()
+	dir := t.TempDir()
+	spool := &Spool{dir: dir, maxSize: 1 << 20, segmentSize: 1 << 20}
+	frames, err := encodeSpoolFrames([]log.Record{r})
+	if err != nil {
+		t.Fatalf("encodeSpoolFrames failed: %v", err)
+	}
+	if err := spool.append(frames); err != nil {
+		t.Fatalf("append failed: %v", err)
+	}
+	spool.file.Close()
+	data, err := os.ReadFile(spool.segmentPath(1))
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	return data
+}
@@ -0,0 +1,459 @@
+package outputs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wasilak/loggergo/lib/types"
+)
+
+// defaultEnterpriseID is the private enterprise number reserved for documentation (RFC 5612).
+const defaultEnterpriseID = 32473
+
+// localSyslogSockets are the unix sockets tried when no network is configured.
+var localSyslogSockets = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// SyslogSeverity maps a slog.Level to a syslog severity.
+//
+// Levels below Info map to debug (7), below Warn to informational (6), below Error to
+// warning (4), below Error+4 to error (3) and anything above to critical (2).
+func SyslogSeverity(level slog.Level) int {
+	switch {
+	case level < slog.LevelInfo:
+		return 7
+	case level < slog.LevelWarn:
+		return 6
+	case level < slog.LevelError:
+		return 4
+	case level < slog.LevelError+4:
+		return 3
+	default:
+		return 2
+	}
+}
+
+// syslogConn is a connection to a syslog server shared by a SyslogHandler and its derived handlers.
+type syslogConn struct {
+	network string
+	address string
+
+	mu     sync.Mutex
+	conn   net.Conn
+	closed bool
+}
+
+// dial connects to the configured server, trying the local syslog sockets if no network is set.
+func (c *syslogConn) dial() error {
+	if c.network != "" {
+		conn, err := net.Dial(c.network, c.address)
+		if err != nil {
+			return err
+		}
+		c.conn = conn
+		return nil
+	}
+
+	addresses := localSyslogSockets
+	if c.address != "" {
+		addresses = []string{c.address}
+	}
+	for _, address := range addresses {
+		for _, network := range []string{"unixgram", "unix"} {
+			conn, err := net.Dial(network, address)
+			if err == nil {
+				c.conn = conn
+				c.network = network
+				c.address = address
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("unable to connect to local syslog daemon")
+}
+
+// write sends a single framed message, reconnecting once if the write fails.
+func (c *syslogConn) write(msg []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return os.ErrClosed
+	}
+
+	if c.conn != nil {
+		if _, err := c.conn.Write(msg); err == nil {
+			return nil
+		}
+		c.conn.Close()
+		c.conn = nil
+	}
+
+	if err := c.dial(); err != nil {
+		return err
+	}
+	_, err := c.conn.Write(msg)
+	return err
+}
+
+// close closes the connection. Calling it more than once is safe.
+func (c *syslogConn) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	if c.conn != nil {
+		return c.conn.Close()
+	}
+	return nil
+}
+
+// groupedAttr is an attribute added with WithAttrs, together with the groups open at that time.
+type groupedAttr struct {
+	groups []string
+	attr   slog.Attr
+}
+
+// SyslogHandler is a slog.Handler that sends records to a syslog server.
+//
+// In RFC 5424 format, attributes are rendered as STRUCTURED-DATA: top-level attributes go to
+// an element with SD-ID "attrs@<EnterpriseID>" and each group becomes its own element named
+// after the dotted group path (e.g. "http.request@<EnterpriseID>"). In RFC 3164 format,
+// attributes are appended to the message as key=value pairs, quoted if they contain spaces,
+// quotes, '=' or line breaks.
+//
+// Over TCP, RFC 5424 messages are framed by octet counting (RFC 6587). RFC 3164 messages over
+// TCP and all messages over a "unix" stream socket end with a newline instead, so line breaks
+// in them are escaped as \r and \n.
+//
+// Thread Safety:
+//
+// SyslogHandler is safe for concurrent use. Each record is sent as one message.
+type SyslogHandler struct {
+	conn     *syslogConn
+	opts     slog.HandlerOptions
+	format   types.SyslogFormat
+	facility int
+	appName  string
+	hostname string
+	sdSuffix string
+	pid      string
+
+	attrs  []groupedAttr
+	groups []string
+}
+
+// NewSyslogHandler connects to the syslog server described by config and returns a handler sending to it.
+// defaultAppName is used when config.AppName is empty.
+func NewSyslogHandler(config types.SyslogConfig, defaultAppName string, opts slog.HandlerOptions) (*SyslogHandler, error) {
+	conn := &syslogConn{network: config.Network, address: config.Address}
+	if err := conn.dial(); err != nil {
+		return nil, fmt.Errorf("failed to connect to syslog: %w", err)
+	}
+
+	h := &SyslogHandler{
+		conn:     conn,
+		opts:     opts,
+		format:   config.Format,
+		facility: types.SyslogFacilities["user"],
+		appName:  config.AppName,
+		hostname: config.Hostname,
+		sdSuffix: "@" + strconv.Itoa(defaultEnterpriseID),
+		pid:      strconv.Itoa(os.Getpid()),
+	}
+
+	if h.format == (types.SyslogFormat{}) {
+		h.format = types.SyslogRFC5424
+	}
+	if config.Facility != "" {
+		facility, ok := types.SyslogFacilities[strings.ToLower(config.Facility)]
+		if !ok {
+			conn.close()
+			return nil, fmt.Errorf("unknown syslog facility: %q", config.Facility)
+		}
+		h.facility = facility
+	}
+	if h.appName == "" {
+		h.appName = defaultAppName
+	}
+	if h.hostname == "" {
+		h.hostname, _ = os.Hostname()
+	}
+	if config.EnterpriseID > 0 {
+		h.sdSuffix = "@" + strconv.Itoa(config.EnterpriseID)
+	}
+
+	return h, nil
+}
+
+// Close closes the connection to the syslog server.
+func (h *SyslogHandler) Close() error {
+	return h.conn.close()
+}
+
+// Enabled reports whether the handler handles records at the given level.
+func (h *SyslogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	minLevel := slog.LevelInfo
+	if h.opts.Level != nil {
+		minLevel = h.opts.Level.Level()
+	}
+	return level >= minLevel
+}
+
+// Handle formats the record and sends it to the syslog server.
+func (h *SyslogHandler) Handle(_ context.Context, record slog.Record) error {
+	var msg []byte
+	if h.format == types.SyslogRFC3164 {
+		msg = h.formatRFC3164(record)
+	} else {
+		msg = h.formatRFC5424(record)
+	}
+
+	// Stream transports need framing: octet counting (RFC 6587) for RFC 5424 over TCP, a trailing
+	// newline otherwise, in which case line breaks inside the message are escaped so that a
+	// multi-line record is not split into several messages
+	switch {
+	case h.conn.network == "tcp" && h.format != types.SyslogRFC3164:
+		msg = append([]byte(strconv.Itoa(len(msg))+" "), msg...)
+	case h.conn.network == "tcp" || h.conn.network == "unix":
+		msg = append(escapeLineBreaks(msg), '\n')
+	}
+
+	return h.conn.write(msg)
+}
+
+// WithAttrs returns a new handler with the given attributes added.
+func (h *SyslogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := *h
+	h2.attrs = append([]groupedAttr{}, h.attrs...)
+	for _, a := range attrs {
+		h2.attrs = append(h2.attrs, groupedAttr{groups: h.groups, attr: a})
+	}
+	return &h2
+}
+
+// WithGroup returns a new handler with the given group name.
+func (h *SyslogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.groups = append(append([]string{}, h.groups...), name)
+	return &h2
+}
+
+// sdParam is a single PARAM-NAME="PARAM-VALUE" pair of a STRUCTURED-DATA element.
+type sdParam struct {
+	name  string
+	value string
+}
+
+// sdElement is a STRUCTURED-DATA element with its parameters.
+type sdElement struct {
+	id     string
+	params []sdParam
+}
+
+// collectElements flattens the handler and record attributes into STRUCTURED-DATA elements keyed by group path.
+func (h *SyslogHandler) collectElements(record slog.Record) []*sdElement {
+	var elements []*sdElement
+	index := map[string]*sdElement{}
+
+	add := func(groups []string, key, value string) {
+		id := "attrs"
+		if len(groups) > 0 {
+			id = strings.Join(groups, ".")
+		}
+		element, ok := index[id]
+		if !ok {
+			element = &sdElement{id: sdName(id, 32-len(h.sdSuffix)) + h.sdSuffix}
+			index[id] = element
+			elements = append(elements, element)
+		}
+		element.params = append(element.params, sdParam{name: sdName(key, 32), value: value})
+	}
+
+	for _, ga := range h.attrs {
+		walkAttr(ga.groups, ga.attr, add)
+	}
+	record.Attrs(func(a slog.Attr) bool {
+		walkAttr(h.groups, a, add)
+		return true
+	})
+
+	return elements
+}
+
+// walkAttr resolves a and calls fn for each leaf attribute, expanding groups into group paths.
+func walkAttr(groups []string, a slog.Attr, fn func(groups []string, key, value string)) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		nested := groups
+		if a.Key != "" {
+			nested = append(append([]string{}, groups...), a.Key)
+		}
+		for _, ga := range a.Value.Group() {
+			walkAttr(nested, ga, fn)
+		}
+		return
+	}
+	fn(groups, a.Key, a.Value.String())
+}
+
+// formatRFC5424 renders the record as an RFC 5424 message.
+func (h *SyslogHandler) formatRFC5424(record slog.Record) []byte {
+	var buf bytes.Buffer
+
+	pri := h.facility*8 + SyslogSeverity(record.Level)
+	fmt.Fprintf(&buf, "<%d>1 ", pri)
+
+	if record.Time.IsZero() {
+		buf.WriteString("-")
+	} else {
+		buf.WriteString(record.Time.Format("2006-01-02T15:04:05.000000Z07:00"))
+	}
+	buf.WriteByte(' ')
+	buf.WriteString(headerField(h.hostname, 255))
+	buf.WriteByte(' ')
+	buf.WriteString(headerField(h.appName, 48))
+	buf.WriteByte(' ')
+	buf.WriteString(h.pid)
+	buf.WriteString(" - ")
+
+	elements := h.collectElements(record)
+	if len(elements) == 0 {
+		buf.WriteString("-")
+	}
+	for _, element := range elements {
+		buf.WriteByte('[')
+		buf.WriteString(element.id)
+		for _, p := range element.params {
+			buf.WriteByte(' ')
+			buf.WriteString(p.name)
+			buf.WriteString(`="`)
+			buf.WriteString(sdEscape(p.value))
+			buf.WriteByte('"')
+		}
+		buf.WriteByte(']')
+	}
+
+	if record.Message != "" {
+		buf.WriteByte(' ')
+		buf.WriteString(record.Message)
+	}
+
+	return buf.Bytes()
+}
+
+// formatRFC3164 renders the record as an RFC 3164 message with attributes appended as key=value pairs.
+func (h *SyslogHandler) formatRFC3164(record slog.Record) []byte {
+	var buf bytes.Buffer
+
+	pri := h.facility*8 + SyslogSeverity(record.Level)
+	timestamp := record.Time
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	fmt.Fprintf(&buf, "<%d>%s %s %s[%s]: %s", pri, timestamp.Format(time.Stamp), headerField(h.hostname, 255), tagField(h.appName), h.pid, record.Message)
+
+	add := func(groups []string, key, value string) {
+		buf.WriteByte(' ')
+		for _, g := range groups {
+			buf.WriteString(g)
+			buf.WriteByte('.')
+		}
+		buf.WriteString(key)
+		buf.WriteByte('=')
+		if strings.ContainsAny(value, " \"=\r\n") {
+			value = strconv.Quote(value)
+		}
+		buf.WriteString(value)
+	}
+	for _, ga := range h.attrs {
+		walkAttr(ga.groups, ga.attr, add)
+	}
+	record.Attrs(func(a slog.Attr) bool {
+		walkAttr(h.groups, a, add)
+		return true
+	})
+
+	return buf.Bytes()
+}
+
+// headerField returns s as a valid RFC 5424 header field: printable ASCII without spaces, "-" if empty.
+func headerField(s string, maxLen int) string {
+	s = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return -1
+		}
+		return r
+	}, s)
+	if len(s) > maxLen {
+		s = s[:maxLen]
+	}
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// tagField returns s as a valid RFC 3164 TAG: alphanumeric ASCII only, at most 32 characters, "-" if empty.
+func tagField(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return -1
+		}
+		return r
+	}, s)
+	if len(s) > 32 {
+		s = s[:32]
+	}
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// escapeLineBreaks replaces CR and LF in msg with the escape sequences \r and \n.
+func escapeLineBreaks(msg []byte) []byte {
+	msg = bytes.ReplaceAll(msg, []byte("\r"), []byte(`\r`))
+	return bytes.ReplaceAll(msg, []byte("\n"), []byte(`\n`))
+}
+
+// sdName returns s as a valid SD-NAME: printable ASCII except '=', ' ', ']' and '"', at most maxLen characters.
+func sdName(s string, maxLen int) string {
+	s = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 || r == '=' || r == ']' || r == '"' || r == '@' {
+			return '_'
+		}
+		return r
+	}, s)
+	if len(s) > maxLen {
+		s = s[:maxLen]
+	}
+	if s == "" {
+		return "_"
+	}
+	return s
+}
+
+// sdEscape escapes '"', '\' and ']' in a PARAM-VALUE.
+func sdEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(s)
+}
@@ -0,0 +1,271 @@
+package outputs
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wasilak/loggergo/lib/types"
+)
+
+// readPacket reads a single datagram from conn
+func readPacket(t *testing.T, conn net.PacketConn) string {
+	t.Helper()
+	buf := make([]byte, 64*1024)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("ReadFrom failed: %v", err)
+	}
+	return string(buf[:n])
+}
+
+// TestSyslogSeverity tests mapping of slog levels to syslog severities
+func TestSyslogSeverity(t *testing.T) {
+	tests := []struct {
+		level slog.Level
+		want  int
+	}{
+		{slog.LevelDebug, 7},
+		{slog.LevelInfo, 6},
+		{slog.LevelInfo + 2, 6},
+		{slog.LevelWarn, 4},
+		{slog.LevelError, 3},
+		{slog.LevelError + 4, 2},
+	}
+
+	for _, tt := range tests {
+		if got := SyslogSeverity(tt.level); got != tt.want {
+			t.Errorf("SyslogSeverity(%v) = %d, want %d", tt.level, got, tt.want)
+		}
+	}
+}
+
+// TestSyslogHandler_UDP_RFC5424 tests RFC 5424 messages with STRUCTURED-DATA over UDP
+func TestSyslogHandler_UDP_RFC5424(t *testing.T) {
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("ListenPacket failed: %v", err)
+	}
+	defer listener.Close()
+
+	h, err := NewSyslogHandler(types.SyslogConfig{
+		Network:  "udp",
+		Address:  listener.LocalAddr().String(),
+		Facility: "local0",
+		Hostname: "host1",
+	}, "my-service", slog.HandlerOptions{Level: slog.LevelInfo})
+	if err != nil {
+		t.Fatalf("NewSyslogHandler failed: %v", err)
+	}
+	defer h.Close()
+
+	logger := slog.New(h).With("service", "api").WithGroup("http")
+	logger.Warn("request failed", "method", "GET", slog.Group("request", "path", `/a"b]`))
+
+	msg := readPacket(t, listener)
+
+	// local0 (16) * 8 + warning (4) = 132
+	prefix := "<132>1 "
+	if !strings.HasPrefix(msg, prefix) {
+		t.Fatalf("Expected message to start with %q, got %q", prefix, msg)
+	}
+	for _, want := range []string{
+		" host1 my-service " + strconv.Itoa(os.Getpid()) + " - ",
+		`[attrs@32473 service="api"]`,
+		`[http@32473 method="GET"]`,
+		`[http.request@32473 path="/a\"b\]"]`,
+		" request failed",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("Expected message to contain %q, got %q", want, msg)
+		}
+	}
+}
+
+// TestSyslogHandler_TCP_OctetCounting tests RFC 6587 octet-counting framing over TCP
+func TestSyslogHandler_TCP_OctetCounting(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	defer listener.Close()
+
+	received := make(chan []string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		var msgs []string
+		for i := 0; i < 2; i++ {
+			lengthStr, err := reader.ReadString(' ')
+			if err != nil {
+				break
+			}
+			length, _ := strconv.Atoi(strings.TrimSpace(lengthStr))
+			buf := make([]byte, length)
+			if _, err := io.ReadFull(reader, buf); err != nil {
+				break
+			}
+			msgs = append(msgs, string(buf))
+		}
+		received <- msgs
+	}()
+
+	h, err := NewSyslogHandler(types.SyslogConfig{
+		Network: "tcp",
+		Address: listener.Addr().String(),
+	}, "my-service", slog.HandlerOptions{Level: slog.LevelDebug})
+	if err != nil {
+		t.Fatalf("NewSyslogHandler failed: %v", err)
+	}
+	defer h.Close()
+
+	logger := slog.New(h)
+	logger.Debug("first")
+	logger.Error("second\nline")
+
+	select {
+	case msgs := <-received:
+		if len(msgs) != 2 {
+			t.Fatalf("Expected 2 messages, got %v", msgs)
+		}
+		// user (1) * 8 + debug (7) = 15, user (1) * 8 + err (3) = 11
+		if !strings.HasPrefix(msgs[0], "<15>1 ") || !strings.HasSuffix(msgs[0], " - first") {
+			t.Errorf("Unexpected first message %q", msgs[0])
+		}
+		if !strings.HasPrefix(msgs[1], "<11>1 ") || !strings.HasSuffix(msgs[1], " - second\nline") {
+			t.Errorf("Unexpected second message %q", msgs[1])
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for TCP messages")
+	}
+}
+
+// TestSyslogHandler_TCP_RFC3164 tests that multi-line records stay one newline-framed message over TCP
+func TestSyslogHandler_TCP_RFC3164(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	defer listener.Close()
+
+	received := make(chan []string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		var lines []string
+		for i := 0; i < 2; i++ {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				break
+			}
+			lines = append(lines, line)
+		}
+		received <- lines
+	}()
+
+	h, err := NewSyslogHandler(types.SyslogConfig{
+		Network:  "tcp",
+		Address:  listener.Addr().String(),
+		Format:   types.SyslogRFC3164,
+		AppName:  "my app: v2",
+		Hostname: "host1",
+	}, "my-service", slog.HandlerOptions{})
+	if err != nil {
+		t.Fatalf("NewSyslogHandler failed: %v", err)
+	}
+	defer h.Close()
+
+	logger := slog.New(h)
+	logger.Error("panic: boom\r\ngoroutine 1 [running]:", "error", "first\nsecond")
+	logger.Info("next")
+
+	select {
+	case lines := <-received:
+		if len(lines) != 2 {
+			t.Fatalf("Expected 2 messages, got %q", lines)
+		}
+		want := " host1 myappv2[" + strconv.Itoa(os.Getpid()) + `]: panic: boom\r\ngoroutine 1 [running]: error="first\nsecond"` + "\n"
+		if !strings.HasPrefix(lines[0], "<11>") || !strings.HasSuffix(lines[0], want) {
+			t.Errorf("Expected the first message to end with %q, got %q", want, lines[0])
+		}
+		if !strings.HasSuffix(lines[1], "]: next\n") {
+			t.Errorf("Unexpected second message %q", lines[1])
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for TCP messages")
+	}
+}
+
+// TestSyslogHandler_Unixgram_RFC3164 tests RFC 3164 messages over a unix datagram socket
+func TestSyslogHandler_Unixgram_RFC3164(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.sock")
+	listener, err := net.ListenPacket("unixgram", path)
+	if err != nil {
+		t.Skipf("unixgram sockets not supported: %v", err)
+	}
+	defer listener.Close()
+
+	h, err := NewSyslogHandler(types.SyslogConfig{
+		Address:  path,
+		Format:   types.SyslogRFC3164,
+		Facility: "daemon",
+		AppName:  "legacy",
+		Hostname: "host1",
+	}, "my-service", slog.HandlerOptions{})
+	if err != nil {
+		t.Fatalf("NewSyslogHandler failed: %v", err)
+	}
+	defer h.Close()
+
+	slog.New(h).Info("hello", "user", "alice smith", slog.Group("req", "id", 7))
+
+	msg := readPacket(t, listener)
+
+	// daemon (3) * 8 + info (6) = 30
+	if !strings.HasPrefix(msg, "<30>") {
+		t.Errorf("Expected priority <30>, got %q", msg)
+	}
+	want := " host1 legacy[" + strconv.Itoa(os.Getpid()) + `]: hello user="alice smith" req.id=7`
+	if !strings.HasSuffix(msg, want) {
+		t.Errorf("Expected message to end with %q, got %q", want, msg)
+	}
+}
+
+// TestSyslogHandler_Enabled tests that records below the configured level are skipped
+func TestSyslogHandler_Enabled(t *testing.T) {
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("ListenPacket failed: %v", err)
+	}
+	defer listener.Close()
+
+	h, err := NewSyslogHandler(types.SyslogConfig{Network: "udp", Address: listener.LocalAddr().String()}, "svc", slog.HandlerOptions{Level: slog.LevelWarn})
+	if err != nil {
+		t.Fatalf("NewSyslogHandler failed: %v", err)
+	}
+	defer h.Close()
+
+	if h.Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("Expected Info to be disabled at Warn level")
+	}
+	if !h.Enabled(context.Background(), slog.LevelError) {
+		t.Error("Expected Error to be enabled at Warn level")
+	}
+}
@@ -0,0 +1,86 @@
+package outputs
+
+import (
+	"context"
+	"encoding/binary"
+	"hash/fnv"
+	"log/slog"
+	"math"
+
+	"github.com/wasilak/loggergo/lib/types"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceSamplingHandler is a slog.Handler that keeps Debug and Info records only for sampled spans.
+//
+// Records below slog.LevelWarn are passed to the wrapped handler if the span in their context
+// is sampled and dropped if it is not, so every kept trace has its logs and dropped traces
+// produce none. Records without a valid span are kept with probability FallbackRatio, all of
+// them if it is nil. The decision for those is derived from the record's time and message
+// rather than drawn at random, so handlers in a fanout agree on which records are kept.
+//
+// Thread Safety:
+//
+// TraceSamplingHandler is safe for concurrent use.
+type TraceSamplingHandler struct {
+	handler   slog.Handler
+	threshold uint64
+}
+
+// NewTraceSamplingHandler creates a new TraceSamplingHandler wrapping the given handler.
+func NewTraceSamplingHandler(handler slog.Handler, config types.TraceSamplingConfig) *TraceSamplingHandler {
+	var threshold uint64
+	switch ratio := config.FallbackRatio; {
+	case ratio == nil, *ratio >= 1:
+		threshold = math.MaxUint64
+	case *ratio > 0:
+		threshold = uint64(*ratio * math.MaxUint64)
+	}
+
+	return &TraceSamplingHandler{handler: handler, threshold: threshold}
+}
+
+// Enabled reports whether the handler handles records at the given level.
+// It delegates the check to the inner handler.
+func (h *TraceSamplingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+// Handle passes the record to the inner handler unless it is sampled out.
+func (h *TraceSamplingHandler) Handle(ctx context.Context, record slog.Record) error {
+	if record.Level >= slog.LevelWarn || h.keep(ctx, record) {
+		return h.handler.Handle(ctx, record)
+	}
+	return nil
+}
+
+// WithAttrs returns a new handler with the given attributes added.
+func (h *TraceSamplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &TraceSamplingHandler{handler: h.handler.WithAttrs(attrs), threshold: h.threshold}
+}
+
+// WithGroup returns a new handler with the given group name.
+func (h *TraceSamplingHandler) WithGroup(name string) slog.Handler {
+	return &TraceSamplingHandler{handler: h.handler.WithGroup(name), threshold: h.threshold}
+}
+
+// keep reports whether a record below slog.LevelWarn is kept.
+func (h *TraceSamplingHandler) keep(ctx context.Context, record slog.Record) bool {
+	if ctx != nil {
+		if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+			return spanContext.IsSampled()
+		}
+	}
+
+	switch h.threshold {
+	case 0:
+		return false
+	case math.MaxUint64:
+		return true
+	}
+
+	hash := fnv.New64a()
+	binary.Write(hash, binary.LittleEndian, record.Time.UnixNano())
+	hash.Write([]byte(record.Message))
+	return hash.Sum64() < h.threshold
+}
@@ -0,0 +1,108 @@
+package outputs
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wasilak/loggergo/lib/types"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// fallbackRatio returns a pointer to ratio for TraceSamplingConfig.FallbackRatio
+func fallbackRatio(ratio float64) *float64 {
+	return &ratio
+}
+
+// spanContext returns a context carrying a valid span context with the given sampled flag
+func spanContext(sampled bool) context.Context {
+	config := trace.SpanContextConfig{
+		TraceID: trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanID:  trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+	}
+	if sampled {
+		config.TraceFlags = trace.FlagsSampled
+	}
+	return trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(config))
+}
+
+// TestTraceSamplingHandler_FollowsSpan tests that Debug and Info records follow the span's sampling decision
+func TestTraceSamplingHandler_FollowsSpan(t *testing.T) {
+	var buf bytes.Buffer
+	handler := NewTraceSamplingHandler(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}), types.TraceSamplingConfig{Enabled: true, FallbackRatio: fallbackRatio(0)})
+	logger := slog.New(handler).With("component", "test")
+
+	logger.InfoContext(spanContext(true), "sampled info")
+	logger.DebugContext(spanContext(true), "sampled debug")
+	logger.InfoContext(spanContext(false), "unsampled info")
+	logger.WarnContext(spanContext(false), "unsampled warn")
+	logger.ErrorContext(spanContext(false), "unsampled error")
+	logger.Info("no span")
+
+	output := buf.String()
+	for _, want := range []string{"sampled info", "sampled debug", "unsampled warn", "unsampled error"} {
+		if !strings.Contains(output, "msg=\""+want+"\"") {
+			t.Errorf("Expected %q to be kept, got %q", want, output)
+		}
+	}
+	for _, unwanted := range []string{"unsampled info", "no span"} {
+		if strings.Contains(output, "msg=\""+unwanted+"\"") {
+			t.Errorf("Expected %q to be dropped, got %q", unwanted, output)
+		}
+	}
+}
+
+// TestTraceSamplingHandler_FallbackRatio tests the fraction of records without a span that are kept
+func TestTraceSamplingHandler_FallbackRatio(t *testing.T) {
+	tests := []struct {
+		name     string
+		ratio    *float64
+		min, max int
+	}{
+		{name: "unset keeps all", ratio: nil, min: 1000, max: 1000},
+		{name: "none", ratio: fallbackRatio(0), min: 0, max: 0},
+		{name: "tiny", ratio: fallbackRatio(0.0001), min: 0, max: 10},
+		{name: "quarter", ratio: fallbackRatio(0.25), min: 150, max: 350},
+		{name: "all", ratio: fallbackRatio(1), min: 1000, max: 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			handler := NewTraceSamplingHandler(slog.NewTextHandler(&buf, nil), types.TraceSamplingConfig{Enabled: true, FallbackRatio: tt.ratio})
+
+			start := time.Now()
+			for i := 0; i < 1000; i++ {
+				record := slog.NewRecord(start.Add(time.Duration(i)*time.Microsecond), slog.LevelInfo, "no span", 0)
+				handler.Handle(context.Background(), record)
+			}
+
+			got := strings.Count(buf.String(), "no span")
+			if got < tt.min || got > tt.max {
+				t.Errorf("Expected between %d and %d records, got %d", tt.min, tt.max, got)
+			}
+		})
+	}
+}
+
+// TestTraceSamplingHandler_ConsistentDecision tests that handlers in a fanout keep the same records without a span
+func TestTraceSamplingHandler_ConsistentDecision(t *testing.T) {
+	var buf1, buf2 bytes.Buffer
+	config := types.TraceSamplingConfig{Enabled: true, FallbackRatio: fallbackRatio(0.5)}
+	handler1 := NewTraceSamplingHandler(slog.NewTextHandler(&buf1, nil), config)
+	handler2 := NewTraceSamplingHandler(slog.NewTextHandler(&buf2, nil), config)
+
+	start := time.Now()
+	for i := 0; i < 100; i++ {
+		record := slog.NewRecord(start.Add(time.Duration(i)*time.Millisecond), slog.LevelInfo, "no span", 0)
+		handler1.Handle(context.Background(), record)
+		handler2.Handle(context.Background(), record)
+	}
+
+	if buf1.String() != buf2.String() {
+		t.Error("Expected both handlers to keep the same records")
+	}
+}
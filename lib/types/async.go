@@ -0,0 +1,87 @@
+package types
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/xybor-x/enum"
+)
+
+// OverflowPolicy represents what the asynchronous handler does when its queue is full.
+type overflowPolicy int
+type OverflowPolicy struct{ enum.SafeEnum[overflowPolicy] }
+
+var (
+	// OverflowBlock makes the caller wait until there is room in the queue.
+	OverflowBlock = enum.NewExtended[OverflowPolicy]("block")
+	// OverflowDropNewest drops the record being logged.
+	OverflowDropNewest = enum.NewExtended[OverflowPolicy]("drop_newest")
+	// OverflowDropOldest drops the oldest queued record to make room for the new one.
+	OverflowDropOldest = enum.NewExtended[OverflowPolicy]("drop_oldest")
+	// OverflowDropBelowLevel drops records below AsyncConfig.DropLevel and blocks for the others.
+	OverflowDropBelowLevel = enum.NewExtended[OverflowPolicy]("drop_below_level")
+	_                      = enum.Finalize[OverflowPolicy]() // still required internally
+)
+
+// AllOverflowPolicies returns all defined OverflowPolicy values.
+func AllOverflowPolicies() []OverflowPolicy {
+	return enum.All[OverflowPolicy]()
+}
+
+// OverflowPolicyFromString parses a string to an OverflowPolicy, returning a fallback if not found.
+func OverflowPolicyFromString(name string) OverflowPolicy {
+	if v, ok := enum.FromString[OverflowPolicy](strings.ToLower(name)); ok {
+		return v
+	}
+	slog.Warn(fmt.Sprintf("Unknown overflow policy: %q, defaulting to %s", name, OverflowBlock))
+	return OverflowBlock
+}
+
+// ParseOverflowPolicy parses a case-insensitive string to an OverflowPolicy, returning an error if it is unknown.
+func ParseOverflowPolicy(name string) (OverflowPolicy, error) {
+	return parseEnum[OverflowPolicy]("overflow policy", name)
+}
+
+// AsyncConfig represents the settings of the asynchronous buffered handler.
+//
+// When Enabled, records are put on a bounded queue and written by Workers background
+// goroutines, so slow outputs don't stall the logging goroutine. Shutdown drains the queue.
+//
+// Example:
+//
+//	async := loggergo.AsyncConfig{
+//	    Enabled:        true,
+//	    QueueSize:      4096,
+//	    OverflowPolicy: loggergo.OverflowDropBelowLevel,
+//	    DropLevel:      slog.LevelWarn,
+//	}
+type AsyncConfig struct {
+	Enabled        bool           `json:"enabled"`         // Enabled specifies whether records are written asynchronously. Default: false.
+	QueueSize      int            `json:"queue_size"`      // QueueSize specifies the maximum number of queued records. Default: 1024.
+	Workers        int            `json:"workers"`         // Workers specifies the number of writer goroutines. With more than one, records may be written out of order. Default: 1.
+	OverflowPolicy OverflowPolicy `json:"overflow_policy"` // OverflowPolicy specifies what happens when the queue is full. Default: loggergo.OverflowBlock.
+	DropLevel      slog.Level     `json:"drop_level"`      // DropLevel specifies the level below which records are dropped with OverflowDropBelowLevel. Default: slog.LevelInfo.
+}
+
+// validate checks the async settings and returns field errors prefixed with the given field path.
+func (a *AsyncConfig) validate(field string) []FieldError {
+	var fieldErrors []FieldError
+
+	if a.QueueSize < 0 {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".QueueSize",
+			Value:  a.QueueSize,
+			Reason: "cannot be negative",
+		})
+	}
+	if a.Workers < 0 {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".Workers",
+			Value:  a.Workers,
+			Reason: "cannot be negative",
+		})
+	}
+
+	return fieldErrors
+}
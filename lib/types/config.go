@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"time"
 )
 
 // ValidationError represents configuration validation failures.
@@ -32,7 +33,7 @@ func (e *ValidationError) Error() string {
 	if len(e.Errors) == 1 {
 		return fmt.Sprintf("configuration validation failed: %s", e.Errors[0].Error())
 	}
-	return fmt.Sprintf("configuration validation failed with %d errors: %s (and %d more)", 
+	return fmt.Sprintf("configuration validation failed with %d errors: %s (and %d more)",
 		len(e.Errors), e.Errors[0].Error(), len(e.Errors)-1)
 }
 
@@ -123,27 +124,88 @@ func (e *InitError) Unwrap() error {
 //	    OtelServiceName:    "myapp",
 //	    OtelTracingEnabled: true,
 //	}
+//
+// File Example:
+//
+//	config := loggergo.Config{
+//	    Level:  slog.LevelInfo,
+//	    Output: loggergo.OutputFile,
+//	    File: loggergo.FileConfig{
+//	        Path:       "/var/log/myapp/app.log",
+//	        MaxSize:    100 << 20, // 100 MiB
+//	        MaxBackups: 5,
+//	        Compress:   true,
+//	    },
+//	}
 type Config struct {
-	Level              slog.Leveler  `json:"level"`                // Level specifies the log level. Valid values are any of the slog.Level constants (e.g., slog.LevelInfo, slog.LevelError). Default: slog.LevelInfo.
-	Format             LogFormat     `json:"format"`               // Format specifies the log format. Valid values are loggergo.LogFormatText, loggergo.LogFormatJSON, and loggergo.LogFormatOtel. Default: loggergo.LogFormatJSON.
-	DevMode            bool          `json:"dev_mode"`             // DevMode indicates whether the logger is running in development mode. Default: false. WARNING: When using MergeConfig, false will override true. To preserve a true value, explicitly set DevMode to true in the override config.
-	DevFlavor          DevFlavor     `json:"dev_flavor"`           // DevFlavor specifies the development flavor. Valid values are loggergo.DevFlavorTint, loggergo.DevFlavorSlogor, and loggergo.DevFlavorDevslog. Default: loggergo.DevFlavorTint.
-	OutputStream       io.Writer     `json:"output_stream"`        // OutputStream specifies the output stream for the logger. Default: os.Stdout.
-	OtelTracingEnabled bool          `json:"otel_enabled"`         // OtelTracingEnabled specifies whether OpenTelemetry support is enabled. Default: true. WARNING: When using MergeConfig, false will override true. To preserve a true value, explicitly set OtelTracingEnabled to true in the override config.
-	OtelLoggerName     string        `json:"otel_logger_name"`     // OtelLoggerName specifies the name of the logger for OpenTelemetry. Default: "my/pkg/name". Required when Output is OutputOtel or OutputFanout.
-	Output             OutputType    `json:"output"`               // Output specifies the type of output for the logger. Valid values are loggergo.OutputConsole, loggergo.OutputOtel, and loggergo.OutputFanout. Default: loggergo.OutputConsole.
-	OtelServiceName    string        `json:"otel_service_name"`    // OtelServiceName specifies the service name for OpenTelemetry. Default: "my-service". Required when Output is OutputOtel or OutputFanout.
-	SetAsDefault       bool          `json:"set_as_default"`       // SetAsDefault specifies whether the logger should be set as the default logger. Default: true. WARNING: When using MergeConfig, false will override true. To preserve a true value, explicitly set SetAsDefault to true in the override config.
-	ContextKeys        []interface{} `json:"context_keys"`         // ContextKeys specifies the keys to be added to log from context. Default: empty slice.
-	ContextKeysDefault interface{}   `json:"context_keys_default"` // ContextKeysDefault specifies the default value for the context keys if not found in the context. Default: nil.
+	Level              slog.Leveler          `json:"level"`                // Level specifies the log level. Valid values are any of the slog.Level constants (e.g., slog.LevelInfo, slog.LevelError). Default: slog.LevelInfo.
+	Format             LogFormat             `json:"format"`               // Format specifies the log format. Valid values are loggergo.LogFormatText, loggergo.LogFormatJSON, and loggergo.LogFormatOtel. Default: loggergo.LogFormatJSON.
+	DevMode            bool                  `json:"dev_mode"`             // DevMode indicates whether the logger is running in development mode. Default: false. WARNING: When using MergeConfig, false will override true. To preserve a true value, explicitly set DevMode to true in the override config.
+	DevFlavor          DevFlavor             `json:"dev_flavor"`           // DevFlavor specifies the development flavor. Valid values are loggergo.DevFlavorTint, loggergo.DevFlavorSlogor, and loggergo.DevFlavorDevslog. Default: loggergo.DevFlavorTint.
+	OutputStream       io.Writer             `json:"output_stream"`        // OutputStream specifies the output stream for the logger. Default: os.Stdout.
+	OtelTracingEnabled bool                  `json:"otel_enabled"`         // OtelTracingEnabled specifies whether OpenTelemetry support is enabled. Default: true. WARNING: When using MergeConfig, false will override true. To preserve a true value, explicitly set OtelTracingEnabled to true in the override config.
+	OtelLoggerName     string                `json:"otel_logger_name"`     // OtelLoggerName specifies the name of the logger for OpenTelemetry. Default: "my/pkg/name". Required when Output is OutputOtel or OutputFanout.
+	Output             OutputType            `json:"output"`               // Output specifies the type of output for the logger. Valid values are loggergo.OutputConsole, loggergo.OutputOtel, loggergo.OutputFanout, loggergo.OutputFile, loggergo.OutputSyslog, and loggergo.OutputJournald. Default: loggergo.OutputConsole.
+	OtelServiceName    string                `json:"otel_service_name"`    // OtelServiceName specifies the service name for OpenTelemetry. Default: "my-service". Required when Output is OutputOtel or OutputFanout.
+	SetAsDefault       bool                  `json:"set_as_default"`       // SetAsDefault specifies whether the logger should be set as the default logger. Default: true. WARNING: When using MergeConfig, false will override true. To preserve a true value, explicitly set SetAsDefault to true in the override config.
+	ContextKeys        []interface{}         `json:"context_keys"`         // ContextKeys specifies the keys to be added to log from context. Default: empty slice.
+	ContextKeysDefault interface{}           `json:"context_keys_default"` // ContextKeysDefault specifies the default value for the context keys if not found in the context. Default: nil.
+	ContextExtractors  []ContextExtractor    `json:"-"`                    // ContextExtractors specifies extractors adding attributes taken from the context, such as baggage members or span attributes, after ContextKeys. Not read from configuration files. Default: none.
+	ContextGroup       string                `json:"context_group"`        // ContextGroup specifies a group that the attributes taken from the context by ContextKeys, ContextExtractors and loggergo.ContextWith are placed in. Default: empty, attributes are added at the top level.
+	File               FileConfig            `json:"file"`                 // File specifies the rotating file settings used when Output is OutputFile. Path is required in that case.
+	Syslog             SyslogConfig          `json:"syslog"`               // Syslog specifies the syslog settings used when Output is OutputSyslog. Default: local syslog socket, RFC 5424, facility "user".
+	Journald           JournaldConfig        `json:"journald"`             // Journald specifies the systemd-journald settings used when Output is OutputJournald. Default: native socket, SYSLOG_IDENTIFIER from OtelServiceName.
+	Async              AsyncConfig           `json:"async"`                // Async specifies whether and how records are written asynchronously. Default: disabled.
+	Sampling           SamplingConfig        `json:"sampling"`             // Sampling specifies whether and how repeated records are sampled. Default: disabled.
+	TraceSampling      TraceSamplingConfig   `json:"trace_sampling"`       // TraceSampling specifies whether Debug and Info records are kept only for sampled spans. Default: disabled.
+	Redaction          RedactionConfig       `json:"redaction"`            // Redaction specifies rules for removing secrets and PII from attributes. Default: no rules.
+	Sinks              []SinkConfig          `json:"sinks"`                // Sinks specifies several outputs, each with its own format, writer, level and attribute filter, that every record is offered to. When set, Output is ignored. Default: none, Output is used.
+	Routes             []RouteConfig         `json:"routes"`               // Routes specifies rules sending records to some of the Sinks by level, message prefix, component or attribute values. Requires named Sinks. Default: none, every record goes to all sinks.
+	OTLP               OTLPConfig            `json:"otlp"`                 // OTLP specifies the endpoint, protocol, headers, TLS, compression, timeout and batching of the OTLP exporter used when Output is OutputOtel or OutputFanout. Default: empty, configured by OTEL_EXPORTER_OTLP_* environment variables.
+	Resource           ResourceConfig        `json:"resource"`             // Resource specifies the service version, environment, extra attributes and detectors of the OpenTelemetry resource used by the OTLP output and LogFormatOtel. Default: SDK defaults and service.name.
+	Failover           FailoverConfig        `json:"failover"`             // Failover specifies whether and when the OTEL output fails over to the console while exports fail. Default: disabled.
+	Spool              SpoolConfig           `json:"spool"`                // Spool specifies a directory where records that fail to export over OTLP are kept and from which they are exported again. Default: none, such records are dropped.
+	OtelFormat         OtelFormatConfig      `json:"otel_format"`          // OtelFormat specifies pretty printing, timestamps and batching of LogFormatOtel. Default: compact, with timestamps, written synchronously.
+	ComponentLevels    map[string]slog.Level `json:"component_levels"`     // ComponentLevels specifies levels of loggers created with loggergo.Named, by dot-separated component name; "*" matches all other components. Default: none, Level applies.
+}
+
+// FileConfig represents the settings of the rotating file output.
+//
+// A file is rotated when it would grow beyond MaxSize or when RotationInterval has elapsed
+// since it was opened, whichever comes first. Rotated files are renamed with a timestamp
+// suffix (e.g. app-2024-01-02T15-04-05.000.log) and, if Compress is set, gzipped.
+//
+// Example:
+//
+//	file := loggergo.FileConfig{
+//	    Path:             "/var/log/myapp/app.log",
+//	    MaxSize:          50 << 20,
+//	    RotationInterval: 24 * time.Hour,
+//	    MaxAge:           7 * 24 * time.Hour,
+//	}
+type FileConfig struct {
+	Path             string        `json:"path"`              // Path specifies the file to write logs to. Parent directories are created if missing.
+	MaxSize          int64         `json:"max_size"`          // MaxSize specifies the maximum size in bytes of the file before it gets rotated. Default: 0 (no size-based rotation).
+	RotationInterval time.Duration `json:"rotation_interval"` // RotationInterval specifies how often the file is rotated regardless of its size. Default: 0 (no time-based rotation).
+	MaxBackups       int           `json:"max_backups"`       // MaxBackups specifies the maximum number of rotated files to keep. Default: 0 (keep all).
+	MaxAge           time.Duration `json:"max_age"`           // MaxAge specifies how long rotated files are kept. Default: 0 (no age-based removal).
+	Compress         bool          `json:"compress"`          // Compress specifies whether rotated files are gzipped. Default: false.
+	ReopenOnSignal   bool          `json:"reopen_on_signal"`  // ReopenOnSignal specifies whether the file is reopened on SIGHUP, for use with external tools like logrotate. Ignored on Windows. Default: false.
 }
 
 // Validate checks if the configuration is valid and returns an error if not.
 //
 // It validates:
 //   - Required fields (Level, Output)
-//   - Mode-specific requirements (OTEL fields when using OTEL or Fanout output)
+//   - Mode-specific requirements (OTEL fields when using OTEL or Fanout output, File/Syslog/Journald settings when using the matching output)
+//   - Each sink's output and settings instead, if Sinks is set
+//   - Routes (sink names and components), which require Sinks
+//   - OTLP exporter settings (endpoint format, TLS files, timeouts and batch sizes)
+//   - Resource settings (attribute keys and detectors)
+//   - Failover settings (thresholds and durations)
+//   - Spool settings (sizes, retry interval, and a single OTEL sink using the directory)
 //   - Field conflicts (ContextKeysDefault without ContextKeys)
+//   - Context extractors (no nil entries)
 //
 // Returns:
 //   - nil if the configuration is valid
@@ -167,12 +229,110 @@ func (c *Config) Validate() error {
 		})
 	}
 
+	// Validate the output, or each sink if Sinks is set
+	if len(c.Sinks) == 0 {
+		fieldErrors = append(fieldErrors, c.validateOutput()...)
+	} else {
+		for i := range c.Sinks {
+			fieldErrors = append(fieldErrors, c.Sinks[i].validate(fmt.Sprintf("Sinks[%d]", i), c)...)
+		}
+	}
+
+	// Validate routes against the sinks they name
+	if len(c.Routes) > 0 && len(c.Sinks) == 0 {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  "Routes",
+			Value:  len(c.Routes),
+			Reason: "cannot be set without defining Sinks",
+		})
+	} else {
+		for i := range c.Routes {
+			fieldErrors = append(fieldErrors, c.Routes[i].validate(fmt.Sprintf("Routes[%d]", i), c)...)
+		}
+	}
+
+	// Validate OTLP exporter settings
+	fieldErrors = append(fieldErrors, c.OTLP.validate("OTLP")...)
+
+	// Validate OpenTelemetry resource settings
+	fieldErrors = append(fieldErrors, c.Resource.validate("Resource")...)
+
+	// Validate failover settings
+	fieldErrors = append(fieldErrors, c.Failover.validate("Failover")...)
+
+	// Validate spool settings; a spool directory can only be used by one exporter
+	fieldErrors = append(fieldErrors, c.Spool.validate("Spool")...)
+	if c.Spool.Dir != "" {
+		otelSinks := 0
+		for i := range c.Sinks {
+			if c.Sinks[i].Output == OutputOtel {
+				otelSinks++
+			}
+		}
+		if otelSinks > 1 {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:  "Spool.Dir",
+				Value:  c.Spool.Dir,
+				Reason: fmt.Sprintf("cannot be shared by %d OTEL sinks", otelSinks),
+			})
+		}
+	}
+
+	// Validate async and sampling settings
+	fieldErrors = append(fieldErrors, c.Async.validate("Async")...)
+	fieldErrors = append(fieldErrors, c.Sampling.validate("Sampling")...)
+	fieldErrors = append(fieldErrors, c.TraceSampling.validate("TraceSampling")...)
+
+	// Validate redaction rules
+	fieldErrors = append(fieldErrors, c.Redaction.validate("Redaction")...)
+
+	// Validate component names
+	for name := range c.ComponentLevels {
+		if !validateComponentName(name) {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:  "ComponentLevels",
+				Value:  name,
+				Reason: `must be "*" or a dot-separated name without empty segments`,
+			})
+		}
+	}
+
+	// Validate context keys
+	if c.ContextKeysDefault != nil && len(c.ContextKeys) == 0 {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  "ContextKeysDefault",
+			Value:  c.ContextKeysDefault,
+			Reason: "cannot be set without defining ContextKeys",
+		})
+	}
+
+	for i, extractor := range c.ContextExtractors {
+		if extractor == nil {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:  fmt.Sprintf("ContextExtractors[%d]", i),
+				Value:  nil,
+				Reason: "cannot be nil",
+			})
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return &ValidationError{Errors: fieldErrors}
+	}
+
+	return nil
+}
+
+// validateOutput checks the settings required by Output and returns field errors.
+func (c *Config) validateOutput() []FieldError {
+	var fieldErrors []FieldError
+
 	// Validate output mode
 	if c.Output == (OutputType{}) {
 		fieldErrors = append(fieldErrors, FieldError{
 			Field:  "Output",
 			Value:  c.Output,
-			Reason: "must be specified (Console, OTEL, or Fanout)",
+			Reason: "must be specified (Console, OTEL, Fanout, File, Syslog, or Journald)",
 		})
 	}
 
@@ -194,18 +354,63 @@ func (c *Config) Validate() error {
 		}
 	}
 
-	// Validate context keys
-	if c.ContextKeysDefault != nil && len(c.ContextKeys) == 0 {
+	// Validate file output settings
+	if c.Output.String() == OutputFile.String() {
+		fieldErrors = append(fieldErrors, c.File.validate("File")...)
+	}
+
+	// Validate syslog output settings
+	if c.Output.String() == OutputSyslog.String() {
+		fieldErrors = append(fieldErrors, c.Syslog.validate("Syslog")...)
+	}
+
+	// Validate journald output settings
+	if c.Output.String() == OutputJournald.String() {
+		fieldErrors = append(fieldErrors, c.Journald.validate("Journald")...)
+	}
+
+	return fieldErrors
+}
+
+// validate checks the file output settings and returns field errors prefixed with the given field path.
+func (f *FileConfig) validate(field string) []FieldError {
+	var fieldErrors []FieldError
+
+	if f.Path == "" {
 		fieldErrors = append(fieldErrors, FieldError{
-			Field:  "ContextKeysDefault",
-			Value:  c.ContextKeysDefault,
-			Reason: "cannot be set without defining ContextKeys",
+			Field:  field + ".Path",
+			Value:  f.Path,
+			Reason: "required when Output is File",
 		})
 	}
-
-	if len(fieldErrors) > 0 {
-		return &ValidationError{Errors: fieldErrors}
+	if f.MaxSize < 0 {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".MaxSize",
+			Value:  f.MaxSize,
+			Reason: "cannot be negative",
+		})
+	}
+	if f.RotationInterval < 0 {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".RotationInterval",
+			Value:  f.RotationInterval,
+			Reason: "cannot be negative",
+		})
+	}
+	if f.MaxBackups < 0 {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".MaxBackups",
+			Value:  f.MaxBackups,
+			Reason: "cannot be negative",
+		})
+	}
+	if f.MaxAge < 0 {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".MaxAge",
+			Value:  f.MaxAge,
+			Reason: "cannot be negative",
+		})
 	}
 
-	return nil
+	return fieldErrors
 }
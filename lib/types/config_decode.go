@@ -0,0 +1,147 @@
+package types
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	levelerType         = reflect.TypeFor[slog.Leveler]()
+	writerType          = reflect.TypeFor[io.Writer]()
+	durationType        = reflect.TypeFor[time.Duration]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// DecodeConfig decodes a JSON configuration document over config.
+//
+// Fields missing from the document keep their values in config. Unlike json.Unmarshal, which
+// stops at the first problem, DecodeConfig checks the whole document first and reports every
+// unknown key and invalid value as a FieldError in a *ValidationError, with the field path
+// using Go field names (e.g. "Syslog.Format" or "Redaction.Rules[1].Strategy").
+//
+// DecodeConfig does not call Validate.
+func DecodeConfig(data []byte, config *Config) error {
+	var fieldErrors []FieldError
+	checkJSONValue(data, reflect.TypeFor[Config](), "", &fieldErrors)
+	if len(fieldErrors) > 0 {
+		return &ValidationError{Errors: fieldErrors}
+	}
+
+	return json.Unmarshal(data, config)
+}
+
+// checkJSONValue checks that raw can be decoded into a value of type t, recursing into structs,
+// slices and maps, and appends a FieldError for every problem found.
+func checkJSONValue(raw json.RawMessage, t reflect.Type, path string, fieldErrors *[]FieldError) {
+	if string(raw) == "null" {
+		return
+	}
+
+	var err error
+	switch {
+	case t == levelerType:
+		var name string
+		if err = json.Unmarshal(raw, &name); err == nil {
+			_, err = ParseLogLevel(name)
+		}
+	case t == writerType:
+		var name string
+		err = json.Unmarshal(raw, &name)
+	case t == durationType:
+		var d duration
+		err = json.Unmarshal(raw, &d)
+	case t.Kind() == reflect.Struct && !reflect.PointerTo(t).Implements(textUnmarshalerType):
+		var fields map[string]json.RawMessage
+		if err = json.Unmarshal(raw, &fields); err == nil {
+			checkJSONFields(fields, t, path, fieldErrors)
+			return
+		}
+	case t.Kind() == reflect.Slice:
+		var items []json.RawMessage
+		if err = json.Unmarshal(raw, &items); err == nil {
+			for i, item := range items {
+				checkJSONValue(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i), fieldErrors)
+			}
+			return
+		}
+	case t.Kind() == reflect.Map:
+		var items map[string]json.RawMessage
+		if err = json.Unmarshal(raw, &items); err == nil {
+			for _, key := range sortedKeys(items) {
+				checkJSONValue(items[key], t.Elem(), fmt.Sprintf("%s[%s]", path, key), fieldErrors)
+			}
+			return
+		}
+	default:
+		err = json.Unmarshal(raw, reflect.New(t).Interface())
+	}
+
+	if err != nil {
+		*fieldErrors = append(*fieldErrors, FieldError{
+			Field:  path,
+			Value:  string(raw),
+			Reason: decodeErrorReason(err),
+		})
+	}
+}
+
+// checkJSONFields checks the fields of a JSON object against the json tags of struct type t.
+func checkJSONFields(fields map[string]json.RawMessage, t reflect.Type, path string, fieldErrors *[]FieldError) {
+	prefix := ""
+	if path != "" {
+		prefix = path + "."
+	}
+
+	// Keys are matched case-insensitively, as encoding/json does
+	known := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "-" || name == "" {
+			continue
+		}
+
+		for _, key := range sortedKeys(fields) {
+			if strings.EqualFold(key, name) {
+				known[key] = true
+				checkJSONValue(fields[key], field.Type, prefix+field.Name, fieldErrors)
+			}
+		}
+	}
+
+	for _, key := range sortedKeys(fields) {
+		if !known[key] {
+			*fieldErrors = append(*fieldErrors, FieldError{
+				Field:  prefix + key,
+				Reason: "unknown field",
+			})
+		}
+	}
+}
+
+// decodeErrorReason returns a short description of a decoding error.
+func decodeErrorReason(err error) string {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return fmt.Sprintf("expected %s, got %s", typeErr.Type, typeErr.Value)
+	}
+	return err.Error()
+}
+
+// sortedKeys returns the keys of m in sorted order, so errors are reported deterministically.
+func sortedKeys(m map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
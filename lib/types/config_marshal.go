@@ -0,0 +1,426 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MarshalJSON implements json.Marshaler.
+//
+// Level is written as its name (e.g. "debug") and OutputStream as its target name
+// ("stdout", "stderr" or a file path, see OutputTargetName). Writers without a name
+// are omitted, so they don't survive a round trip.
+func (c Config) MarshalJSON() ([]byte, error) {
+	type plain Config
+	aux := struct {
+		plain
+		Level        string `json:"level,omitempty"`
+		OutputStream string `json:"output_stream,omitempty"`
+	}{
+		plain:        plain(c),
+		OutputStream: OutputTargetName(c.OutputStream),
+	}
+	if c.Level != nil {
+		aux.Level = strings.ToLower(c.Level.Level().String())
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// Fields missing from the input keep their current values, so a configuration can be
+// decoded over DefaultConfig. Level is parsed with ParseLogLevel and OutputStream with
+// OutputTarget.
+func (c *Config) UnmarshalJSON(data []byte) error {
+	type plain Config
+	aux := struct {
+		*plain
+		Level        *string `json:"level"`
+		OutputStream *string `json:"output_stream"`
+	}{
+		plain: (*plain)(c),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if aux.Level != nil {
+		level, err := ParseLogLevel(*aux.Level)
+		if err != nil {
+			return fmt.Errorf("level: %w", err)
+		}
+		c.Level = level
+	}
+	if aux.OutputStream != nil {
+		c.OutputStream = OutputTarget(*aux.OutputStream)
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, writing Level and OutputStream as Config.MarshalJSON does.
+func (s SinkConfig) MarshalJSON() ([]byte, error) {
+	type plain SinkConfig
+	aux := struct {
+		plain
+		Level        string `json:"level,omitempty"`
+		OutputStream string `json:"output_stream,omitempty"`
+	}{
+		plain:        plain(s),
+		OutputStream: OutputTargetName(s.OutputStream),
+	}
+	if s.Level != nil {
+		aux.Level = strings.ToLower(s.Level.Level().String())
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, reading Level and OutputStream as Config.UnmarshalJSON does.
+func (s *SinkConfig) UnmarshalJSON(data []byte) error {
+	type plain SinkConfig
+	aux := struct {
+		*plain
+		Level        *string `json:"level"`
+		OutputStream *string `json:"output_stream"`
+	}{
+		plain: (*plain)(s),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if aux.Level != nil {
+		level, err := ParseLogLevel(*aux.Level)
+		if err != nil {
+			return fmt.Errorf("level: %w", err)
+		}
+		s.Level = level
+	}
+	if aux.OutputStream != nil {
+		s.OutputStream = OutputTarget(*aux.OutputStream)
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, writing Level as Config.MarshalJSON does.
+func (r RouteConfig) MarshalJSON() ([]byte, error) {
+	type plain RouteConfig
+	aux := struct {
+		plain
+		Level string `json:"level,omitempty"`
+	}{
+		plain: plain(r),
+	}
+	if r.Level != nil {
+		aux.Level = strings.ToLower(r.Level.Level().String())
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, reading Level as Config.UnmarshalJSON does.
+func (r *RouteConfig) UnmarshalJSON(data []byte) error {
+	type plain RouteConfig
+	aux := struct {
+		*plain
+		Level *string `json:"level"`
+	}{
+		plain: (*plain)(r),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if aux.Level != nil {
+		level, err := ParseLogLevel(*aux.Level)
+		if err != nil {
+			return fmt.Errorf("level: %w", err)
+		}
+		r.Level = level
+	}
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler. The YAML document has the same keys and values as MarshalJSON.
+func (c Config) MarshalYAML() (interface{}, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON is valid YAML; decoding it into a node keeps the field order
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	resetYAMLStyle(&node)
+	return node.Content[0], nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. It accepts the same keys and values as UnmarshalJSON.
+func (c *Config) UnmarshalYAML(value *yaml.Node) error {
+	data, err := YAMLToJSON(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, c)
+}
+
+// YAMLToJSON converts a YAML node to the equivalent JSON document.
+func YAMLToJSON(value *yaml.Node) ([]byte, error) {
+	var v interface{}
+	if err := value.Decode(&v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// resetYAMLStyle switches a node decoded from JSON to block style with unquoted scalars where possible.
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
+
+// MarshalJSON implements json.Marshaler, writing durations as strings such as "24h".
+func (f FileConfig) MarshalJSON() ([]byte, error) {
+	type plain FileConfig
+	return json.Marshal(struct {
+		plain
+		RotationInterval duration `json:"rotation_interval"`
+		MaxAge           duration `json:"max_age"`
+	}{
+		plain:            plain(f),
+		RotationInterval: duration(f.RotationInterval),
+		MaxAge:           duration(f.MaxAge),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting durations as strings such as "24h" or as nanoseconds.
+func (f *FileConfig) UnmarshalJSON(data []byte) error {
+	type plain FileConfig
+	aux := struct {
+		*plain
+		RotationInterval *duration `json:"rotation_interval"`
+		MaxAge           *duration `json:"max_age"`
+	}{
+		plain: (*plain)(f),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if aux.RotationInterval != nil {
+		f.RotationInterval = time.Duration(*aux.RotationInterval)
+	}
+	if aux.MaxAge != nil {
+		f.MaxAge = time.Duration(*aux.MaxAge)
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, writing durations as strings such as "1s".
+func (s SamplingConfig) MarshalJSON() ([]byte, error) {
+	type plain SamplingConfig
+	return json.Marshal(struct {
+		plain
+		Tick            duration `json:"tick"`
+		SummaryInterval duration `json:"summary_interval"`
+	}{
+		plain:           plain(s),
+		Tick:            duration(s.Tick),
+		SummaryInterval: duration(s.SummaryInterval),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting durations as strings such as "1s" or as nanoseconds.
+func (s *SamplingConfig) UnmarshalJSON(data []byte) error {
+	type plain SamplingConfig
+	aux := struct {
+		*plain
+		Tick            *duration `json:"tick"`
+		SummaryInterval *duration `json:"summary_interval"`
+	}{
+		plain: (*plain)(s),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if aux.Tick != nil {
+		s.Tick = time.Duration(*aux.Tick)
+	}
+	if aux.SummaryInterval != nil {
+		s.SummaryInterval = time.Duration(*aux.SummaryInterval)
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, writing durations as strings such as "30s".
+func (f FailoverConfig) MarshalJSON() ([]byte, error) {
+	type plain FailoverConfig
+	return json.Marshal(struct {
+		plain
+		LatencyThreshold duration `json:"latency_threshold"`
+		OpenDuration     duration `json:"open_duration"`
+	}{
+		plain:            plain(f),
+		LatencyThreshold: duration(f.LatencyThreshold),
+		OpenDuration:     duration(f.OpenDuration),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting durations as strings such as "30s" or as nanoseconds.
+func (f *FailoverConfig) UnmarshalJSON(data []byte) error {
+	type plain FailoverConfig
+	aux := struct {
+		*plain
+		LatencyThreshold *duration `json:"latency_threshold"`
+		OpenDuration     *duration `json:"open_duration"`
+	}{
+		plain: (*plain)(f),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if aux.LatencyThreshold != nil {
+		f.LatencyThreshold = time.Duration(*aux.LatencyThreshold)
+	}
+	if aux.OpenDuration != nil {
+		f.OpenDuration = time.Duration(*aux.OpenDuration)
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, writing durations as strings such as "5s".
+func (s SpoolConfig) MarshalJSON() ([]byte, error) {
+	type plain SpoolConfig
+	return json.Marshal(struct {
+		plain
+		RetryInterval duration `json:"retry_interval"`
+	}{
+		plain:         plain(s),
+		RetryInterval: duration(s.RetryInterval),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting durations as strings such as "5s" or as nanoseconds.
+func (s *SpoolConfig) UnmarshalJSON(data []byte) error {
+	type plain SpoolConfig
+	aux := struct {
+		*plain
+		RetryInterval *duration `json:"retry_interval"`
+	}{
+		plain: (*plain)(s),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if aux.RetryInterval != nil {
+		s.RetryInterval = time.Duration(*aux.RetryInterval)
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, writing durations as strings such as "5s".
+func (o OTLPConfig) MarshalJSON() ([]byte, error) {
+	type plain OTLPConfig
+	return json.Marshal(struct {
+		plain
+		Timeout duration `json:"timeout"`
+	}{
+		plain:   plain(o),
+		Timeout: duration(o.Timeout),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting durations as strings such as "5s" or as nanoseconds.
+func (o *OTLPConfig) UnmarshalJSON(data []byte) error {
+	type plain OTLPConfig
+	aux := struct {
+		*plain
+		Timeout *duration `json:"timeout"`
+	}{
+		plain: (*plain)(o),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if aux.Timeout != nil {
+		o.Timeout = time.Duration(*aux.Timeout)
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, writing durations as strings such as "1s".
+func (b OTLPBatchConfig) MarshalJSON() ([]byte, error) {
+	type plain OTLPBatchConfig
+	return json.Marshal(struct {
+		plain
+		ExportInterval duration `json:"export_interval"`
+		ExportTimeout  duration `json:"export_timeout"`
+	}{
+		plain:          plain(b),
+		ExportInterval: duration(b.ExportInterval),
+		ExportTimeout:  duration(b.ExportTimeout),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting durations as strings such as "1s" or as nanoseconds.
+func (b *OTLPBatchConfig) UnmarshalJSON(data []byte) error {
+	type plain OTLPBatchConfig
+	aux := struct {
+		*plain
+		ExportInterval *duration `json:"export_interval"`
+		ExportTimeout  *duration `json:"export_timeout"`
+	}{
+		plain: (*plain)(b),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if aux.ExportInterval != nil {
+		b.ExportInterval = time.Duration(*aux.ExportInterval)
+	}
+	if aux.ExportTimeout != nil {
+		b.ExportTimeout = time.Duration(*aux.ExportTimeout)
+	}
+	return nil
+}
+
+// duration is a time.Duration that marshals to a string such as "1m30s".
+type duration time.Duration
+
+// MarshalText implements encoding.TextMarshaler.
+func (d duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting a string parsed by time.ParseDuration or a number of nanoseconds.
+func (d *duration) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	switch value := value.(type) {
+	case float64:
+		*d = duration(value)
+	case string:
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", value)
+		}
+		*d = duration(parsed)
+	default:
+		return fmt.Errorf("expected a duration such as \"30s\", got %s", data)
+	}
+	return nil
+}
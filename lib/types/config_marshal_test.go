@@ -0,0 +1,204 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fullConfig returns a Config with every field set to a value that survives a round trip
+func fullConfig() Config {
+	fallbackRatio := 0.5
+	return Config{
+		Level:              slog.LevelWarn,
+		Format:             LogFormatText,
+		OtelFormat:         OtelFormatConfig{PrettyPrint: true, Batch: true},
+		DevMode:            true,
+		DevFlavor:          DevFlavorSlogor,
+		OutputStream:       os.Stderr,
+		OtelTracingEnabled: true,
+		OtelLoggerName:     "myapp/logger",
+		Output:             OutputSyslog,
+		OtelServiceName:    "myapp",
+		SetAsDefault:       true,
+		ContextKeys:        []interface{}{"request_id", "user_id"},
+		ContextKeysDefault: "unknown",
+		ContextGroup:       "ctx",
+		File:               FileConfig{Path: "/var/log/app.log", MaxSize: 1 << 20, RotationInterval: 24 * time.Hour, MaxAge: 7 * 24 * time.Hour, Compress: true},
+		Syslog:             SyslogConfig{Network: "tcp", Address: "localhost:514", Format: SyslogRFC3164, Facility: "local0"},
+		Journald:           JournaldConfig{SyslogIdentifier: "myapp"},
+		Async:              AsyncConfig{Enabled: true, QueueSize: 10, OverflowPolicy: OverflowDropBelowLevel, DropLevel: slog.LevelWarn},
+		Sampling:           SamplingConfig{Enabled: true, Tick: time.Second, First: 10, Thereafter: 100, SummaryInterval: time.Minute},
+		TraceSampling:      TraceSamplingConfig{Enabled: true, FallbackRatio: &fallbackRatio},
+		Redaction: RedactionConfig{
+			HashKey: "secret",
+			Rules:   []RedactionRule{{Keys: []string{"password"}, Strategy: RedactDrop}, {ValuePattern: `\d+`, Strategy: RedactHash}},
+		},
+		ComponentLevels: map[string]slog.Level{"db": slog.LevelDebug, "*": slog.LevelInfo},
+		OTLP: OTLPConfig{
+			Endpoint:    "https://collector:4318",
+			Protocol:    OTLPProtocolHTTP,
+			Headers:     map[string]string{"Authorization": "Bearer token"},
+			TLS:         OTLPTLSConfig{CAFile: "/etc/ssl/ca.pem"},
+			Compression: OTLPCompressionGzip,
+			Timeout:     5 * time.Second,
+			Batch:       OTLPBatchConfig{MaxQueueSize: 100, ExportInterval: 2 * time.Second},
+		},
+		Sinks: []SinkConfig{
+			{Name: "terminal", Output: OutputConsole, DevMode: true, DevFlavor: DevFlavorTint, OutputStream: os.Stderr, Level: slog.LevelDebug},
+			{Output: OutputFile, Format: LogFormatJSON, Level: slog.LevelInfo, File: FileConfig{Path: "/var/log/app.json"}, Attributes: AttributeFilter{Exclude: []string{"payload"}}},
+		},
+		Failover: FailoverConfig{Enabled: true, FailureThreshold: 5, LatencyThreshold: 2 * time.Second, OpenDuration: 30 * time.Second},
+		Spool:    SpoolConfig{Dir: "/var/spool/app", MaxSize: 64 << 20, RetryInterval: 10 * time.Second},
+		Routes: []RouteConfig{
+			{Level: slog.LevelError, Component: "billing", Attributes: map[string]string{"audit": "true"}, Sinks: []string{"terminal"}, Continue: true},
+		},
+		Resource: ResourceConfig{
+			ServiceVersion: "1.2.3",
+			Environment:    "production",
+			Attributes:     map[string]string{"team": "payments"},
+			Detectors:      []ResourceDetector{ResourceDetectorHost, ResourceDetectorContainer},
+		},
+	}
+}
+
+// TestConfig_JSONRoundTrip tests that a Config survives JSON marshalling with readable values
+func TestConfig_JSONRoundTrip(t *testing.T) {
+	original := fullConfig()
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	for _, want := range []string{`"level":"warn"`, `"format":"text"`, `"output":"syslog"`, `"output_stream":"stderr"`, `"rotation_interval":"24h0m0s"`, `"overflow_policy":"drop_below_level"`, `"drop_level":"WARN"`, `"strategy":"drop"`, `"db":"DEBUG"`, `"protocol":"http/protobuf"`, `"export_interval":"2s"`, `"detectors":["host","container"]`, `"level":"debug","output_stream":"stderr"`, `"continue":true,"level":"error"`, `"open_duration":"30s"`, `"retry_interval":"10s"`, `"context_group":"ctx"`} {
+		if !bytes.Contains(data, []byte(want)) {
+			t.Errorf("Expected %s in %s", want, data)
+		}
+	}
+
+	var decoded Config
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, original) {
+		t.Errorf("Round trip mismatch:\n got: %+v\nwant: %+v", decoded, original)
+	}
+}
+
+// TestConfig_YAMLRoundTrip tests that a Config survives YAML marshalling
+func TestConfig_YAMLRoundTrip(t *testing.T) {
+	original := fullConfig()
+
+	data, err := yaml.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(data), "level: warn\n") || !strings.Contains(string(data), "    path: /var/log/app.log\n") {
+		t.Errorf("Expected block style YAML, got:\n%s", data)
+	}
+
+	var decoded Config
+	if err := yaml.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, original) {
+		t.Errorf("Round trip mismatch:\n got: %+v\nwant: %+v", decoded, original)
+	}
+}
+
+// TestConfig_UnmarshalOutputStream tests the named output targets
+func TestConfig_UnmarshalOutputStream(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "app.log")
+
+	var config Config
+	if err := json.Unmarshal([]byte(`{"output_stream":"`+path+`","file":{"max_age":3600000000000}}`), &config); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if config.File.MaxAge != time.Hour {
+		t.Errorf("Expected durations in nanoseconds to be accepted, got %v", config.File.MaxAge)
+	}
+
+	target, ok := config.OutputStream.(*FileTarget)
+	if !ok || target.Path != path {
+		t.Fatalf("Expected FileTarget for %s, got %#v", path, config.OutputStream)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Expected the file not to be created before the first write")
+	}
+
+	target.Write([]byte("hello\n"))
+	target.Close()
+	if _, err := target.Write([]byte("lost\n")); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("Expected a write after Close to fail with os.ErrClosed, got %v", err)
+	}
+	if err := target.Open(); err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	target.Write([]byte("again\n"))
+	target.Close()
+
+	data, _ := os.ReadFile(path)
+	if string(data) != "hello\nagain\n" {
+		t.Errorf("Expected appended writes, got %q", data)
+	}
+
+	for _, name := range []string{"STDOUT", "Stderr"} {
+		if err := json.Unmarshal([]byte(`{"output_stream":"`+name+`"}`), &config); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+		if _, ok := config.OutputStream.(*os.File); !ok {
+			t.Errorf("Expected %s to name a standard stream, got %#v", name, config.OutputStream)
+		}
+	}
+}
+
+// TestDecodeConfig_FieldErrors tests that every invalid value is reported with its field path
+func TestDecodeConfig_FieldErrors(t *testing.T) {
+	data := []byte(`{
+		"level": "verbose",
+		"format": "xml",
+		"dev_mode": "yes",
+		"syslog": {"format": "rfc1", "adress": "x"},
+		"async": {"queue_size": "big"},
+		"sampling": {"tick": "soon"},
+		"redaction": {"rules": [{"keys": ["a"]}, {"keys": ["b"], "strategy": "shred"}]},
+		"component_levels": {"db": "loud"},
+		"colour": true
+	}`)
+
+	config := Config{OtelServiceName: "kept"}
+	err := DecodeConfig(data, &config)
+
+	var valErr *ValidationError
+	if !errors.As(err, &valErr) {
+		t.Fatalf("Expected *ValidationError, got %v", err)
+	}
+
+	var fields []string
+	for _, fieldErr := range valErr.Errors {
+		fields = append(fields, fieldErr.Field)
+	}
+	want := []string{
+		"Level", "Format", "DevMode",
+		"Syslog.Format", "Syslog.adress",
+		"Async.QueueSize", "Sampling.Tick",
+		"Redaction.Rules[1].Strategy",
+		"ComponentLevels[db]",
+		"colour",
+	}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("Expected errors for\n%v\ngot\n%v", want, fields)
+	}
+	if config.OtelServiceName != "kept" {
+		t.Error("Expected config to be left unchanged on error")
+	}
+}
@@ -1,9 +1,11 @@
 package types
 
 import (
+	"context"
 	"log/slog"
 	"strings"
 	"testing"
+	"time"
 )
 
 // TestConfig_Validate_NilLevel tests validation when Level is nil
@@ -307,3 +309,473 @@ func TestFieldError_Error(t *testing.T) {
 		})
 	}
 }
+
+// TestConfig_Validate_FileOutput tests validation of File settings when Output is File
+func TestConfig_Validate_FileOutput(t *testing.T) {
+	config := Config{
+		Level:  slog.LevelInfo,
+		Output: OutputFile,
+		File:   FileConfig{MaxSize: -1},
+	}
+
+	err := config.Validate()
+	if err == nil {
+		t.Fatal("Expected validation error for File output without path, but got nil")
+	}
+
+	valErr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("Expected *ValidationError, got %T", err)
+	}
+	if len(valErr.Errors) != 2 || valErr.Errors[0].Field != "File.Path" || valErr.Errors[1].Field != "File.MaxSize" {
+		t.Errorf("Expected File.Path and File.MaxSize errors, got %v", valErr.Errors)
+	}
+
+	config.File = FileConfig{Path: "/var/log/app.log", MaxSize: 1 << 20}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected valid File config, got: %v", err)
+	}
+}
+
+// TestConfig_Validate_SyslogOutput tests validation of Syslog settings when Output is Syslog
+func TestConfig_Validate_SyslogOutput(t *testing.T) {
+	config := Config{
+		Level:  slog.LevelInfo,
+		Output: OutputSyslog,
+		Syslog: SyslogConfig{Network: "tcp", Facility: "nope"},
+	}
+
+	err := config.Validate()
+	valErr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("Expected *ValidationError, got %v", err)
+	}
+	if len(valErr.Errors) != 2 || valErr.Errors[0].Field != "Syslog.Address" || valErr.Errors[1].Field != "Syslog.Facility" {
+		t.Errorf("Expected Syslog.Address and Syslog.Facility errors, got %v", valErr.Errors)
+	}
+
+	// The local syslog socket needs no settings at all
+	config.Syslog = SyslogConfig{}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected valid Syslog config, got: %v", err)
+	}
+}
+
+// TestConfig_Validate_JournaldOutput tests validation of Journald settings when Output is Journald
+func TestConfig_Validate_JournaldOutput(t *testing.T) {
+	config := Config{
+		Level:    slog.LevelInfo,
+		Output:   OutputJournald,
+		Journald: JournaldConfig{SocketPath: "relative/socket"},
+	}
+
+	err := config.Validate()
+	if err == nil || !strings.Contains(err.Error(), "Journald.SocketPath") {
+		t.Errorf("Expected Journald.SocketPath error, got: %v", err)
+	}
+
+	config.Journald = JournaldConfig{}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected valid Journald config, got: %v", err)
+	}
+}
+
+// TestConfig_Validate_Async tests validation of Async settings
+func TestConfig_Validate_Async(t *testing.T) {
+	config := Config{
+		Level:  slog.LevelInfo,
+		Output: OutputConsole,
+		Async:  AsyncConfig{Enabled: true, QueueSize: -1, Workers: -2},
+	}
+
+	err := config.Validate()
+	valErr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("Expected *ValidationError, got %v", err)
+	}
+	if len(valErr.Errors) != 2 || valErr.Errors[0].Field != "Async.QueueSize" || valErr.Errors[1].Field != "Async.Workers" {
+		t.Errorf("Expected Async.QueueSize and Async.Workers errors, got %v", valErr.Errors)
+	}
+
+	config.Async = AsyncConfig{Enabled: true}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected valid Async config, got: %v", err)
+	}
+}
+
+// TestConfig_Validate_Sampling tests validation of Sampling settings
+func TestConfig_Validate_Sampling(t *testing.T) {
+	config := Config{
+		Level:    slog.LevelInfo,
+		Output:   OutputConsole,
+		Sampling: SamplingConfig{Enabled: true, Tick: -time.Second, Thereafter: -1},
+	}
+
+	err := config.Validate()
+	valErr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("Expected *ValidationError, got %v", err)
+	}
+	if len(valErr.Errors) != 2 || valErr.Errors[0].Field != "Sampling.Tick" || valErr.Errors[1].Field != "Sampling.Thereafter" {
+		t.Errorf("Expected Sampling.Tick and Sampling.Thereafter errors, got %v", valErr.Errors)
+	}
+
+	config.Sampling = SamplingConfig{Enabled: true}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected valid Sampling config, got: %v", err)
+	}
+}
+
+// TestConfig_Validate_TraceSampling tests validation of TraceSampling settings
+func TestConfig_Validate_TraceSampling(t *testing.T) {
+	config := Config{
+		Level:         slog.LevelInfo,
+		Output:        OutputConsole,
+		TraceSampling: TraceSamplingConfig{Enabled: true},
+	}
+
+	for _, ratio := range []float64{1.5, -1} {
+		config.TraceSampling.FallbackRatio = &ratio
+		err := config.Validate()
+		if err == nil || !strings.Contains(err.Error(), "TraceSampling.FallbackRatio") {
+			t.Errorf("Expected TraceSampling.FallbackRatio error for %v, got: %v", ratio, err)
+		}
+	}
+
+	config.TraceSampling.FallbackRatio = nil
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected valid TraceSampling config without FallbackRatio, got: %v", err)
+	}
+	for _, ratio := range []float64{0.1, 0, 1} {
+		config.TraceSampling.FallbackRatio = &ratio
+		if err := config.Validate(); err != nil {
+			t.Errorf("Expected valid TraceSampling config with FallbackRatio %v, got: %v", ratio, err)
+		}
+	}
+}
+
+// TestConfig_Validate_Redaction tests validation of Redaction rules
+func TestConfig_Validate_Redaction(t *testing.T) {
+	config := Config{
+		Level:  slog.LevelInfo,
+		Output: OutputConsole,
+		Redaction: RedactionConfig{
+			Rules: []RedactionRule{
+				{},
+				{Keys: []string{"[unclosed"}},
+				{ValuePattern: "("},
+				{Keys: []string{"email"}, Strategy: RedactHash},
+			},
+		},
+	}
+
+	err := config.Validate()
+	valErr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("Expected *ValidationError, got %v", err)
+	}
+	want := []string{"Redaction.Rules[0]", "Redaction.Rules[1].Keys", "Redaction.Rules[2].ValuePattern", "Redaction.HashKey"}
+	if len(valErr.Errors) != len(want) {
+		t.Fatalf("Expected %d errors, got %v", len(want), valErr.Errors)
+	}
+	for i, field := range want {
+		if valErr.Errors[i].Field != field {
+			t.Errorf("Expected error %d for %s, got %s", i, field, valErr.Errors[i].Field)
+		}
+	}
+
+	config.Redaction = RedactionConfig{
+		HashKey: "secret",
+		Rules:   []RedactionRule{{Keys: []string{"*token*"}, Strategy: RedactHash}},
+	}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected valid Redaction config, got: %v", err)
+	}
+}
+
+// TestParseComponentLevels tests parsing of component level specifications
+func TestParseComponentLevels(t *testing.T) {
+	levels, err := ParseComponentLevels("db=debug, db.pool=WARN ,*=info,")
+	if err != nil {
+		t.Fatalf("ParseComponentLevels failed: %v", err)
+	}
+	if len(levels) != 3 || levels["db"] != slog.LevelDebug || levels["db.pool"] != slog.LevelWarn || levels["*"] != slog.LevelInfo {
+		t.Errorf("Unexpected levels: %v", levels)
+	}
+
+	for _, spec := range []string{"db", "db=verbose"} {
+		if _, err := ParseComponentLevels(spec); err == nil {
+			t.Errorf("Expected error for %q", spec)
+		}
+	}
+}
+
+// TestConfig_Validate_ComponentLevels tests validation of component names
+func TestConfig_Validate_ComponentLevels(t *testing.T) {
+	config := Config{
+		Level:           slog.LevelInfo,
+		Output:          OutputConsole,
+		ComponentLevels: map[string]slog.Level{"db..pool": slog.LevelDebug},
+	}
+
+	err := config.Validate()
+	if err == nil || !strings.Contains(err.Error(), "ComponentLevels") {
+		t.Errorf("Expected ComponentLevels error, got: %v", err)
+	}
+
+	config.ComponentLevels = map[string]slog.Level{"db.pool": slog.LevelDebug, "*": slog.LevelWarn}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected valid ComponentLevels, got: %v", err)
+	}
+}
+
+// TestParseEnums tests the strict, case-insensitive enum parsers
+func TestParseEnums(t *testing.T) {
+	if v, err := ParseLogFormat("JSON"); err != nil || v != LogFormatJSON {
+		t.Errorf("ParseLogFormat(JSON) = %v, %v", v, err)
+	}
+	if v, err := ParseLogFormat("plain"); err != nil || v != LogFormatText {
+		t.Errorf("ParseLogFormat(plain) = %v, %v", v, err)
+	}
+	if v, err := ParseOutputType("Journald"); err != nil || v != OutputJournald {
+		t.Errorf("ParseOutputType(Journald) = %v, %v", v, err)
+	}
+	if v, err := ParseDevFlavor("devslog"); err != nil || v != DevFlavorDevslog {
+		t.Errorf("ParseDevFlavor(devslog) = %v, %v", v, err)
+	}
+	if v, err := ParseLogLevel("warn"); err != nil || v != slog.LevelWarn {
+		t.Errorf("ParseLogLevel(warn) = %v, %v", v, err)
+	}
+
+	_, err := ParseOutputType("stdout")
+	if err == nil || !strings.Contains(err.Error(), "console, otel, fanout") {
+		t.Errorf("Expected error listing valid output types, got %v", err)
+	}
+	if _, err := ParseLogLevel("verbose"); err == nil {
+		t.Error("Expected error for unknown level")
+	}
+}
+
+// TestConfig_Validate_OTLP tests validation of OTLP exporter settings
+func TestConfig_Validate_OTLP(t *testing.T) {
+	config := Config{
+		Level:           slog.LevelInfo,
+		Output:          OutputOtel,
+		OtelLoggerName:  "test",
+		OtelServiceName: "test",
+		OTLP: OTLPConfig{
+			Endpoint: "collector",
+			Headers:  map[string]string{"Bad Header": "x"},
+			Insecure: true,
+			TLS:      OTLPTLSConfig{CertFile: "client.pem"},
+			Timeout:  -time.Second,
+			Batch:    OTLPBatchConfig{MaxQueueSize: 10, ExportMaxBatchSize: 20},
+		},
+	}
+
+	err := config.Validate()
+	valErr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("Expected *ValidationError, got %v", err)
+	}
+	var fields []string
+	for _, fieldErr := range valErr.Errors {
+		fields = append(fields, fieldErr.Field)
+	}
+	want := "OTLP.Endpoint,OTLP.Headers,OTLP.Insecure,OTLP.TLS,OTLP.Timeout,OTLP.Batch.ExportMaxBatchSize"
+	if strings.Join(fields, ",") != want {
+		t.Errorf("Expected errors for %s, got %v", want, valErr.Errors)
+	}
+
+	for _, endpoint := range []string{"ftp://collector:21", "https://", "collector:"} {
+		config.OTLP = OTLPConfig{Endpoint: endpoint}
+		if err := config.Validate(); err == nil || !strings.Contains(err.Error(), "OTLP.Endpoint") {
+			t.Errorf("Expected OTLP.Endpoint error for %q, got: %v", endpoint, err)
+		}
+	}
+
+	for _, endpoint := range []string{"collector:4317", "http://collector:4318/custom/logs", "https://collector"} {
+		config.OTLP = OTLPConfig{Endpoint: endpoint, Compression: OTLPCompressionGzip, Headers: map[string]string{"Authorization": "Bearer x"}}
+		if err := config.Validate(); err != nil {
+			t.Errorf("Expected valid OTLP endpoint %q, got: %v", endpoint, err)
+		}
+	}
+}
+
+// TestConfig_Validate_Resource tests validation of resource settings
+func TestConfig_Validate_Resource(t *testing.T) {
+	config := Config{
+		Level:    slog.LevelInfo,
+		Output:   OutputConsole,
+		Resource: ResourceConfig{Attributes: map[string]string{" ": "x"}, Detectors: []ResourceDetector{ResourceDetectorOS, {}}},
+	}
+
+	err := config.Validate()
+	valErr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("Expected *ValidationError, got %v", err)
+	}
+	if len(valErr.Errors) != 2 || valErr.Errors[0].Field != "Resource.Attributes" || valErr.Errors[1].Field != "Resource.Detectors[1]" {
+		t.Errorf("Expected Resource.Attributes and Resource.Detectors[1] errors, got %v", valErr.Errors)
+	}
+
+	config.Resource = ResourceConfig{Attributes: map[string]string{"team": "payments"}, Detectors: []ResourceDetector{ResourceDetectorContainer}}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected valid Resource config, got: %v", err)
+	}
+}
+
+// TestConfig_Validate_Sinks tests that each sink is validated like the single output
+func TestConfig_Validate_Sinks(t *testing.T) {
+	config := Config{
+		Level:  slog.LevelInfo,
+		Output: OutputFile, // ignored when Sinks is set
+		Sinks: []SinkConfig{
+			{},
+			{Output: OutputFanout},
+			{Output: OutputFile, File: FileConfig{MaxSize: -1}},
+			{Output: OutputSyslog},
+			{Output: OutputOtel, Attributes: AttributeFilter{Exclude: []string{"[a"}}},
+		},
+	}
+
+	err := config.Validate()
+	valErr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("Expected *ValidationError, got %v", err)
+	}
+	var fields []string
+	for _, fieldErr := range valErr.Errors {
+		fields = append(fields, fieldErr.Field)
+	}
+	want := "Sinks[0].Output,Sinks[1].Output,Sinks[2].File.Path,Sinks[2].File.MaxSize,OtelLoggerName,OtelServiceName,Sinks[4].Attributes.Exclude"
+	if strings.Join(fields, ",") != want {
+		t.Errorf("Expected errors for %s, got %v", want, valErr.Errors)
+	}
+
+	config.OtelLoggerName = "test"
+	config.OtelServiceName = "test"
+	config.Sinks = []SinkConfig{
+		{Output: OutputConsole, Level: slog.LevelDebug},
+		{Output: OutputOtel, Level: slog.LevelWarn, Attributes: AttributeFilter{Include: []string{"http.*"}}},
+	}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected valid Sinks, got: %v", err)
+	}
+}
+
+// TestConfig_Validate_Routes tests that routes name existing, unique sinks and valid components
+func TestConfig_Validate_Routes(t *testing.T) {
+	config := Config{
+		Level:  slog.LevelInfo,
+		Output: OutputConsole,
+		Routes: []RouteConfig{{Sinks: []string{"audit"}}},
+	}
+	err := config.Validate()
+	if valErr, ok := err.(*ValidationError); !ok || len(valErr.Errors) != 1 || valErr.Errors[0].Field != "Routes" {
+		t.Fatalf("Expected an error for Routes without Sinks, got %v", err)
+	}
+
+	config.Sinks = []SinkConfig{
+		{Name: "audit", Output: OutputConsole},
+		{Name: "dup", Output: OutputConsole},
+		{Name: "dup", Output: OutputConsole},
+	}
+	config.Routes = []RouteConfig{
+		{},
+		{Sinks: []string{"audit", "missing", "dup"}, Component: "db..pool", Attributes: map[string]string{"": "x"}},
+	}
+
+	err = config.Validate()
+	valErr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("Expected *ValidationError, got %v", err)
+	}
+	var fields []string
+	for _, fieldErr := range valErr.Errors {
+		fields = append(fields, fieldErr.Field)
+	}
+	want := "Routes[0].Sinks,Routes[1].Sinks[1],Routes[1].Sinks[2],Routes[1].Component,Routes[1].Attributes"
+	if strings.Join(fields, ",") != want {
+		t.Errorf("Expected errors for %s, got %v", want, valErr.Errors)
+	}
+
+	config.Routes = []RouteConfig{
+		{Component: "security", Sinks: []string{"audit"}},
+		{Level: slog.LevelWarn, MessagePrefix: "audit:", Attributes: map[string]string{"audit": "true"}, Sinks: []string{"audit"}},
+	}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected valid Routes, got: %v", err)
+	}
+}
+
+// TestConfig_Validate_Failover tests that negative failover thresholds and durations are rejected
+func TestConfig_Validate_Failover(t *testing.T) {
+	config := Config{
+		Level:    slog.LevelInfo,
+		Output:   OutputConsole,
+		Failover: FailoverConfig{Enabled: true, FailureThreshold: -1, LatencyThreshold: -time.Second, OpenDuration: -time.Second},
+	}
+
+	err := config.Validate()
+	valErr, ok := err.(*ValidationError)
+	if !ok || len(valErr.Errors) != 3 || valErr.Errors[0].Field != "Failover.FailureThreshold" {
+		t.Fatalf("Expected 3 Failover errors, got %v", err)
+	}
+
+	config.Failover = FailoverConfig{Enabled: true, LatencyThreshold: time.Second}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected valid Failover config, got: %v", err)
+	}
+}
+
+// TestConfig_Validate_ContextExtractors tests that nil context extractors are rejected
+func TestConfig_Validate_ContextExtractors(t *testing.T) {
+	extractor := ContextExtractorFunc(func(ctx context.Context) []slog.Attr { return nil })
+	config := Config{
+		Level:             slog.LevelInfo,
+		Output:            OutputConsole,
+		ContextExtractors: []ContextExtractor{extractor, nil},
+	}
+
+	err := config.Validate()
+	valErr, ok := err.(*ValidationError)
+	if !ok || len(valErr.Errors) != 1 || valErr.Errors[0].Field != "ContextExtractors[1]" {
+		t.Fatalf("Expected a ContextExtractors[1] error, got %v", err)
+	}
+
+	config.ContextExtractors = config.ContextExtractors[:1]
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected valid ContextExtractors, got: %v", err)
+	}
+}
+
+// TestConfig_Validate_Spool tests that invalid spool sizes and a spool shared by OTEL sinks are rejected
+func TestConfig_Validate_Spool(t *testing.T) {
+	config := Config{
+		Level:           slog.LevelInfo,
+		Output:          OutputOtel,
+		OtelLoggerName:  "test-logger",
+		OtelServiceName: "test-service",
+		Spool:           SpoolConfig{Dir: "/var/spool/app", MaxSize: 1 << 20, SegmentSize: 2 << 20, RetryInterval: -time.Second},
+	}
+
+	err := config.Validate()
+	valErr, ok := err.(*ValidationError)
+	if !ok || len(valErr.Errors) != 2 || valErr.Errors[0].Field != "Spool.SegmentSize" || valErr.Errors[1].Field != "Spool.RetryInterval" {
+		t.Fatalf("Expected 2 Spool errors, got %v", err)
+	}
+
+	config.Spool = SpoolConfig{Dir: "/var/spool/app", MaxSize: 1 << 20}
+	config.Sinks = []SinkConfig{{Output: OutputOtel}, {Output: OutputOtel}}
+	err = config.Validate()
+	valErr, ok = err.(*ValidationError)
+	if !ok || len(valErr.Errors) != 1 || valErr.Errors[0].Field != "Spool.Dir" {
+		t.Fatalf("Expected a Spool.Dir error for 2 OTEL sinks, got %v", err)
+	}
+
+	config.Sinks = []SinkConfig{{Output: OutputOtel}, {Output: OutputConsole}}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected valid Spool config, got: %v", err)
+	}
+}
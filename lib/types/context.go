@@ -0,0 +1,31 @@
+package types
+
+import (
+	"context"
+	"log/slog"
+)
+
+// ContextExtractor returns attributes taken from a context, which the context handler adds to
+// every record logged with that context. Extractors registered in Config.ContextExtractors run
+// in order after ContextKeys, for every record, so they should be cheap and must be safe for
+// concurrent use. Returning no attributes omits them from the record.
+type ContextExtractor interface {
+	Extract(ctx context.Context) []slog.Attr
+}
+
+// ContextExtractorFunc is an adapter to use an ordinary function as a ContextExtractor.
+//
+// Example:
+//
+//	extractor := loggergo.ContextExtractorFunc(func(ctx context.Context) []slog.Attr {
+//	    if tenant, ok := ctx.Value(tenantKey).(string); ok {
+//	        return []slog.Attr{slog.String("tenant", tenant)}
+//	    }
+//	    return nil
+//	})
+type ContextExtractorFunc func(ctx context.Context) []slog.Attr
+
+// Extract calls f(ctx).
+func (f ContextExtractorFunc) Extract(ctx context.Context) []slog.Attr {
+	return f(ctx)
+}
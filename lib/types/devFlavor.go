@@ -32,3 +32,8 @@ func DevFlavorFromString(name string) DevFlavor {
 	slog.Warn(fmt.Sprintf("Unknown dev flavor: %q, defaulting to %s", name, DevFlavorTint))
 	return DevFlavorTint
 }
+
+// ParseDevFlavor parses a case-insensitive string to a DevFlavor, returning an error if it is unknown.
+func ParseDevFlavor(name string) (DevFlavor, error) {
+	return parseEnum[DevFlavor]("dev flavor", name)
+}
@@ -0,0 +1,206 @@
+package types
+
+import "encoding/json"
+
+// The enum types marshal to and from their names, so they can be used in JSON and YAML
+// configuration files. The zero value, meaning "not set", marshals to an empty string.
+
+// MarshalText implements encoding.TextMarshaler.
+func (f LogFormat) MarshalText() ([]byte, error) {
+	return []byte(marshalEnum(f)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseLogFormat.
+func (f *LogFormat) UnmarshalText(text []byte) error {
+	return unmarshalEnum(f, string(text), ParseLogFormat)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (f LogFormat) MarshalJSON() ([]byte, error) {
+	return json.Marshal(marshalEnum(f))
+}
+
+// UnmarshalJSON implements json.Unmarshaler using ParseLogFormat.
+func (f *LogFormat) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(f, data, ParseLogFormat)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (o OutputType) MarshalText() ([]byte, error) {
+	return []byte(marshalEnum(o)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseOutputType.
+func (o *OutputType) UnmarshalText(text []byte) error {
+	return unmarshalEnum(o, string(text), ParseOutputType)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (o OutputType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(marshalEnum(o))
+}
+
+// UnmarshalJSON implements json.Unmarshaler using ParseOutputType.
+func (o *OutputType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(o, data, ParseOutputType)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d DevFlavor) MarshalText() ([]byte, error) {
+	return []byte(marshalEnum(d)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseDevFlavor.
+func (d *DevFlavor) UnmarshalText(text []byte) error {
+	return unmarshalEnum(d, string(text), ParseDevFlavor)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d DevFlavor) MarshalJSON() ([]byte, error) {
+	return json.Marshal(marshalEnum(d))
+}
+
+// UnmarshalJSON implements json.Unmarshaler using ParseDevFlavor.
+func (d *DevFlavor) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(d, data, ParseDevFlavor)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (f SyslogFormat) MarshalText() ([]byte, error) {
+	return []byte(marshalEnum(f)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseSyslogFormat.
+func (f *SyslogFormat) UnmarshalText(text []byte) error {
+	return unmarshalEnum(f, string(text), ParseSyslogFormat)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (f SyslogFormat) MarshalJSON() ([]byte, error) {
+	return json.Marshal(marshalEnum(f))
+}
+
+// UnmarshalJSON implements json.Unmarshaler using ParseSyslogFormat.
+func (f *SyslogFormat) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(f, data, ParseSyslogFormat)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (p OverflowPolicy) MarshalText() ([]byte, error) {
+	return []byte(marshalEnum(p)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseOverflowPolicy.
+func (p *OverflowPolicy) UnmarshalText(text []byte) error {
+	return unmarshalEnum(p, string(text), ParseOverflowPolicy)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p OverflowPolicy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(marshalEnum(p))
+}
+
+// UnmarshalJSON implements json.Unmarshaler using ParseOverflowPolicy.
+func (p *OverflowPolicy) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(p, data, ParseOverflowPolicy)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s RedactionStrategy) MarshalText() ([]byte, error) {
+	return []byte(marshalEnum(s)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseRedactionStrategy.
+func (s *RedactionStrategy) UnmarshalText(text []byte) error {
+	return unmarshalEnum(s, string(text), ParseRedactionStrategy)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s RedactionStrategy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(marshalEnum(s))
+}
+
+// UnmarshalJSON implements json.Unmarshaler using ParseRedactionStrategy.
+func (s *RedactionStrategy) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(s, data, ParseRedactionStrategy)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (p OTLPProtocol) MarshalText() ([]byte, error) {
+	return []byte(marshalEnum(p)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseOTLPProtocol.
+func (p *OTLPProtocol) UnmarshalText(text []byte) error {
+	return unmarshalEnum(p, string(text), ParseOTLPProtocol)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p OTLPProtocol) MarshalJSON() ([]byte, error) {
+	return json.Marshal(marshalEnum(p))
+}
+
+// UnmarshalJSON implements json.Unmarshaler using ParseOTLPProtocol.
+func (p *OTLPProtocol) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(p, data, ParseOTLPProtocol)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c OTLPCompression) MarshalText() ([]byte, error) {
+	return []byte(marshalEnum(c)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseOTLPCompression.
+func (c *OTLPCompression) UnmarshalText(text []byte) error {
+	return unmarshalEnum(c, string(text), ParseOTLPCompression)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c OTLPCompression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(marshalEnum(c))
+}
+
+// UnmarshalJSON implements json.Unmarshaler using ParseOTLPCompression.
+func (c *OTLPCompression) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(c, data, ParseOTLPCompression)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d ResourceDetector) MarshalText() ([]byte, error) {
+	return []byte(marshalEnum(d)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseResourceDetector.
+func (d *ResourceDetector) UnmarshalText(text []byte) error {
+	return unmarshalEnum(d, string(text), ParseResourceDetector)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d ResourceDetector) MarshalJSON() ([]byte, error) {
+	return json.Marshal(marshalEnum(d))
+}
+
+// UnmarshalJSON implements json.Unmarshaler using ParseResourceDetector.
+func (d *ResourceDetector) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(d, data, ParseResourceDetector)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s BreakerState) MarshalText() ([]byte, error) {
+	return []byte(marshalEnum(s)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseBreakerState.
+func (s *BreakerState) UnmarshalText(text []byte) error {
+	return unmarshalEnum(s, string(text), ParseBreakerState)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s BreakerState) MarshalJSON() ([]byte, error) {
+	return json.Marshal(marshalEnum(s))
+}
+
+// UnmarshalJSON implements json.Unmarshaler using ParseBreakerState.
+func (s *BreakerState) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(s, data, ParseBreakerState)
+}
@@ -0,0 +1,95 @@
+package types
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/xybor-x/enum"
+)
+
+// BreakerState represents the state of the circuit breaker of an OTEL output with failover.
+type breakerState int
+type BreakerState struct{ enum.SafeEnum[breakerState] }
+
+var (
+	// BreakerClosed means exports succeed and records are written to the OTEL output.
+	BreakerClosed = enum.NewExtended[BreakerState]("closed")
+	// BreakerOpen means exports failed and records are written to the console instead.
+	BreakerOpen = enum.NewExtended[BreakerState]("open")
+	// BreakerHalfOpen means the OTEL output is being probed; records are written to both outputs.
+	BreakerHalfOpen = enum.NewExtended[BreakerState]("half_open")
+	_               = enum.Finalize[BreakerState]() // still required internally
+)
+
+// AllBreakerStates returns all defined BreakerState values.
+func AllBreakerStates() []BreakerState {
+	return enum.All[BreakerState]()
+}
+
+// BreakerStateFromString parses a string to a BreakerState, returning a fallback if not found.
+func BreakerStateFromString(name string) BreakerState {
+	if v, ok := enum.FromString[BreakerState](strings.ToLower(name)); ok {
+		return v
+	}
+	slog.Warn(fmt.Sprintf("Unknown breaker state: %q, defaulting to %s", name, BreakerClosed))
+	return BreakerClosed
+}
+
+// ParseBreakerState parses a case-insensitive string to a BreakerState, returning an error if it is unknown.
+func ParseBreakerState(name string) (BreakerState, error) {
+	return parseEnum[BreakerState]("breaker state", name)
+}
+
+// FailoverConfig represents the settings of the runtime failover of the OTEL output to the console.
+//
+// When Enabled, every export of the OTLP exporter is watched. After FailureThreshold consecutive
+// exports failed or took longer than LatencyThreshold, the circuit breaker opens and records are
+// written to the console, formatted as with OutputConsole. After OpenDuration the breaker is
+// half-open: records are written to both outputs until the next export decides whether the
+// breaker closes again or stays open for another OpenDuration.
+//
+// Example:
+//
+//	failover := loggergo.FailoverConfig{
+//	    Enabled:          true,
+//	    FailureThreshold: 3,
+//	    LatencyThreshold: 2 * time.Second,
+//	    OpenDuration:     30 * time.Second,
+//	}
+type FailoverConfig struct {
+	Enabled          bool          `json:"enabled"`           // Enabled specifies whether the OTEL output fails over to the console when exports fail. Default: false.
+	FailureThreshold int           `json:"failure_threshold"` // FailureThreshold specifies the number of consecutive failed exports that open the breaker. Default: 3.
+	LatencyThreshold time.Duration `json:"latency_threshold"` // LatencyThreshold specifies the duration above which an export counts as failed. Default: 0, latency is not watched.
+	OpenDuration     time.Duration `json:"open_duration"`     // OpenDuration specifies how long the breaker stays open before the OTEL output is probed. Default: 30s.
+}
+
+// validate checks the failover settings and returns field errors prefixed with the given field path.
+func (f *FailoverConfig) validate(field string) []FieldError {
+	var fieldErrors []FieldError
+
+	if f.FailureThreshold < 0 {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".FailureThreshold",
+			Value:  f.FailureThreshold,
+			Reason: "cannot be negative",
+		})
+	}
+	if f.LatencyThreshold < 0 {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".LatencyThreshold",
+			Value:  f.LatencyThreshold,
+			Reason: "cannot be negative",
+		})
+	}
+	if f.OpenDuration < 0 {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".OpenDuration",
+			Value:  f.OpenDuration,
+			Reason: "cannot be negative",
+		})
+	}
+
+	return fieldErrors
+}
@@ -37,3 +37,23 @@ func LogFormatFromString(name string) LogFormat {
 	slog.Warn(fmt.Sprintf("Unknown log format: %q, defaulting to %s", name, LogFormatText))
 	return LogFormatText
 }
+
+// ParseLogFormat parses a case-insensitive string to a LogFormat, returning an error if it is unknown.
+// "plain" is accepted as an alias of "text".
+func ParseLogFormat(name string) (LogFormat, error) {
+	if strings.EqualFold(strings.TrimSpace(name), "plain") {
+		name = "text"
+	}
+	return parseEnum[LogFormat]("log format", name)
+}
+
+// OtelFormatConfig represents the settings of LogFormatOtel, which writes OpenTelemetry log
+// records as JSON to OutputStream.
+//
+// By default each record is written as one line of compact JSON as soon as it is logged.
+// With Batch, records are written in the background and any pending ones are flushed by Shutdown.
+type OtelFormatConfig struct {
+	PrettyPrint    bool `json:"pretty_print"`    // PrettyPrint specifies whether records are written as indented JSON. Default: false (one record per line).
+	OmitTimestamps bool `json:"omit_timestamps"` // OmitTimestamps specifies whether the timestamps are left out, e.g. for comparing output in tests. Default: false.
+	Batch          bool `json:"batch"`           // Batch specifies whether records are written in batches by a background goroutine. Default: false (written synchronously).
+}
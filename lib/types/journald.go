@@ -0,0 +1,33 @@
+package types
+
+import "path/filepath"
+
+// DefaultJournaldSocket is the path of the journald native protocol socket.
+const DefaultJournaldSocket = "/run/systemd/journal/socket"
+
+// JournaldConfig represents the settings of the systemd-journald output.
+//
+// Example:
+//
+//	journald := loggergo.JournaldConfig{
+//	    SyslogIdentifier: "myapp",
+//	}
+type JournaldConfig struct {
+	SocketPath       string `json:"socket_path"`       // SocketPath specifies the journald socket. Default: "/run/systemd/journal/socket".
+	SyslogIdentifier string `json:"syslog_identifier"` // SyslogIdentifier specifies the SYSLOG_IDENTIFIER field. Default: Config.OtelServiceName.
+}
+
+// validate checks the journald settings and returns field errors prefixed with the given field path.
+func (j *JournaldConfig) validate(field string) []FieldError {
+	var fieldErrors []FieldError
+
+	if j.SocketPath != "" && !filepath.IsAbs(j.SocketPath) {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".SocketPath",
+			Value:  j.SocketPath,
+			Reason: "must be an absolute path",
+		})
+	}
+
+	return fieldErrors
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"fmt"
 	"log/slog"
+	"strings"
 )
 
 func LogLevelFromString(name string) slog.Level {
@@ -23,3 +24,52 @@ func AllLogLevels() []slog.Level {
 		slog.LevelError,
 	}
 }
+
+// ParseComponentLevels parses a comma-separated list of component=level pairs into a level map
+// for Config.ComponentLevels, e.g. "db=debug, db.pool=warn, *=info".
+func ParseComponentLevels(spec string) (map[string]slog.Level, error) {
+	levels := make(map[string]slog.Level)
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, levelName, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid component level %q: expected name=level", entry)
+		}
+
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(strings.TrimSpace(levelName))); err != nil {
+			return nil, fmt.Errorf("invalid component level %q: %w", entry, err)
+		}
+
+		levels[strings.TrimSpace(name)] = level
+	}
+
+	return levels, nil
+}
+
+// validateComponentName checks that a ComponentLevels key is "*" or a dot-separated name without empty segments.
+func validateComponentName(name string) bool {
+	if name == "*" {
+		return true
+	}
+	for _, segment := range strings.Split(name, ".") {
+		if segment == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseLogLevel parses a level name such as "debug", "WARN" or "info+2" to a slog.Level, returning an error if it is invalid.
+func ParseLogLevel(name string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(name))); err != nil {
+		return level, fmt.Errorf("unknown log level %q, valid values: debug, info, warn, error", name)
+	}
+	return level, nil
+}
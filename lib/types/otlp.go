@@ -0,0 +1,247 @@
+package types
+
+import (
+	"fmt"
+	"log/slog"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/xybor-x/enum"
+)
+
+// OTLPProtocol represents the transport of the OTLP log exporter.
+type otlpProtocol int
+type OTLPProtocol struct{ enum.SafeEnum[otlpProtocol] }
+
+var (
+	// OTLPProtocolGRPC exports logs over gRPC, by default to localhost:4317.
+	OTLPProtocolGRPC = enum.NewExtended[OTLPProtocol]("grpc")
+	// OTLPProtocolHTTP exports logs as protobuf over HTTP, by default to localhost:4318.
+	OTLPProtocolHTTP = enum.NewExtended[OTLPProtocol]("http/protobuf")
+	_                = enum.Finalize[OTLPProtocol]() // still required internally
+)
+
+// AllOTLPProtocols returns all defined OTLPProtocol values.
+func AllOTLPProtocols() []OTLPProtocol {
+	return enum.All[OTLPProtocol]()
+}
+
+// OTLPProtocolFromString parses a string to an OTLPProtocol, returning a fallback if not found.
+func OTLPProtocolFromString(name string) OTLPProtocol {
+	if v, ok := enum.FromString[OTLPProtocol](strings.ToLower(name)); ok {
+		return v
+	}
+	slog.Warn(fmt.Sprintf("Unknown OTLP protocol: %q, defaulting to %s", name, OTLPProtocolGRPC))
+	return OTLPProtocolGRPC
+}
+
+// ParseOTLPProtocol parses a case-insensitive string to an OTLPProtocol, returning an error if it is unknown.
+func ParseOTLPProtocol(name string) (OTLPProtocol, error) {
+	return parseEnum[OTLPProtocol]("OTLP protocol", name)
+}
+
+// OTLPCompression represents the compression of OTLP export requests.
+type otlpCompression int
+type OTLPCompression struct{ enum.SafeEnum[otlpCompression] }
+
+var (
+	// OTLPCompressionNone sends export requests uncompressed.
+	OTLPCompressionNone = enum.NewExtended[OTLPCompression]("none")
+	// OTLPCompressionGzip gzips export requests.
+	OTLPCompressionGzip = enum.NewExtended[OTLPCompression]("gzip")
+	_                   = enum.Finalize[OTLPCompression]() // still required internally
+)
+
+// AllOTLPCompressions returns all defined OTLPCompression values.
+func AllOTLPCompressions() []OTLPCompression {
+	return enum.All[OTLPCompression]()
+}
+
+// OTLPCompressionFromString parses a string to an OTLPCompression, returning a fallback if not found.
+func OTLPCompressionFromString(name string) OTLPCompression {
+	if v, ok := enum.FromString[OTLPCompression](strings.ToLower(name)); ok {
+		return v
+	}
+	slog.Warn(fmt.Sprintf("Unknown OTLP compression: %q, defaulting to %s", name, OTLPCompressionNone))
+	return OTLPCompressionNone
+}
+
+// ParseOTLPCompression parses a case-insensitive string to an OTLPCompression, returning an error if it is unknown.
+func ParseOTLPCompression(name string) (OTLPCompression, error) {
+	return parseEnum[OTLPCompression]("OTLP compression", name)
+}
+
+// OTLPConfig represents the settings of the OTLP log exporter used when Output is OutputOtel or OutputFanout.
+//
+// When the whole struct is left empty, the exporter is configured by the standard
+// OTEL_EXPORTER_OTLP_* environment variables, as before. When any field is set, the exporter
+// is created from these settings; environment variables still apply to the fields left empty.
+//
+// Example:
+//
+//	otlp := loggergo.OTLPConfig{
+//	    Endpoint:    "https://otel-collector.example.com:4318",
+//	    Protocol:    loggergo.Types.OTLPProtocolHTTP,
+//	    Headers:     map[string]string{"Authorization": "Bearer " + token},
+//	    Compression: loggergo.Types.OTLPCompressionGzip,
+//	    Timeout:     5 * time.Second,
+//	}
+type OTLPConfig struct {
+	Endpoint    string            `json:"endpoint"`    // Endpoint specifies the collector as host:port or as a URL (http:// or https://). A URL path is used as is with Protocol OTLPProtocolHTTP. Default: localhost:4317 for gRPC, localhost:4318 for HTTP.
+	Protocol    OTLPProtocol      `json:"protocol"`    // Protocol specifies the transport. Valid values are loggergo.OTLPProtocolGRPC and loggergo.OTLPProtocolHTTP. Default: loggergo.OTLPProtocolGRPC.
+	Headers     map[string]string `json:"headers"`     // Headers specifies headers (gRPC metadata) sent with every export request, e.g. for authentication.
+	Insecure    bool              `json:"insecure"`    // Insecure disables TLS. Cannot be combined with TLS settings. Default: false, or true for an http:// Endpoint.
+	TLS         OTLPTLSConfig     `json:"tls"`         // TLS specifies the CA and client certificate used to connect to the collector. Default: system roots, no client certificate.
+	Compression OTLPCompression   `json:"compression"` // Compression specifies the compression of export requests. Valid values are loggergo.OTLPCompressionNone and loggergo.OTLPCompressionGzip. Default: none.
+	Timeout     time.Duration     `json:"timeout"`     // Timeout specifies the maximum duration of an export request. Default: 10s.
+	Batch       OTLPBatchConfig   `json:"batch"`       // Batch specifies how records are batched before export.
+}
+
+// OTLPTLSConfig represents the TLS settings of the OTLP log exporter.
+type OTLPTLSConfig struct {
+	CAFile             string `json:"ca_file"`              // CAFile specifies a PEM file with the CA certificates used to verify the collector. Default: system roots.
+	CertFile           string `json:"cert_file"`            // CertFile specifies a PEM file with the client certificate. Requires KeyFile.
+	KeyFile            string `json:"key_file"`             // KeyFile specifies a PEM file with the client key. Requires CertFile.
+	ServerName         string `json:"server_name"`          // ServerName overrides the server name used to verify the collector's certificate. Default: the Endpoint host.
+	InsecureSkipVerify bool   `json:"insecure_skip_verify"` // InsecureSkipVerify disables verification of the collector's certificate. Use only for testing.
+}
+
+// OTLPBatchConfig represents the batching of the OTLP log exporter.
+type OTLPBatchConfig struct {
+	MaxQueueSize       int           `json:"max_queue_size"`        // MaxQueueSize specifies the maximum number of records waiting to be exported. Default: 2048.
+	ExportMaxBatchSize int           `json:"export_max_batch_size"` // ExportMaxBatchSize specifies the maximum number of records per export request. Cannot exceed MaxQueueSize. Default: 512.
+	ExportInterval     time.Duration `json:"export_interval"`       // ExportInterval specifies how often records are exported. Default: 1s.
+	ExportTimeout      time.Duration `json:"export_timeout"`        // ExportTimeout specifies how long an export may take, including retries. Default: 30s.
+}
+
+// IsZero reports whether no OTLP setting is set, in which case the exporter is configured by environment variables.
+func (o *OTLPConfig) IsZero() bool {
+	return o.Endpoint == "" &&
+		o.Protocol == (OTLPProtocol{}) &&
+		len(o.Headers) == 0 &&
+		!o.Insecure &&
+		o.TLS == (OTLPTLSConfig{}) &&
+		o.Compression == (OTLPCompression{}) &&
+		o.Timeout == 0 &&
+		o.Batch == (OTLPBatchConfig{})
+}
+
+// validate checks the OTLP settings and returns field errors prefixed with the given field path.
+func (o *OTLPConfig) validate(field string) []FieldError {
+	var fieldErrors []FieldError
+
+	if o.Endpoint != "" {
+		if reason := validateOTLPEndpoint(o.Endpoint); reason != "" {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:  field + ".Endpoint",
+				Value:  o.Endpoint,
+				Reason: reason,
+			})
+		}
+	}
+
+	for name := range o.Headers {
+		if strings.TrimSpace(name) == "" || strings.ContainsAny(name, " \t\r\n:") {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:  field + ".Headers",
+				Value:  name,
+				Reason: "must be a non-empty header name without spaces or colons",
+			})
+		}
+	}
+
+	if o.Insecure && o.TLS != (OTLPTLSConfig{}) {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".Insecure",
+			Value:  o.Insecure,
+			Reason: "cannot be combined with TLS settings",
+		})
+	}
+	if (o.TLS.CertFile == "") != (o.TLS.KeyFile == "") {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".TLS",
+			Value:  o.TLS,
+			Reason: "CertFile and KeyFile must be set together",
+		})
+	}
+
+	if o.Timeout < 0 {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".Timeout",
+			Value:  o.Timeout,
+			Reason: "cannot be negative",
+		})
+	}
+
+	fieldErrors = append(fieldErrors, o.Batch.validate(field+".Batch")...)
+
+	return fieldErrors
+}
+
+// validateOTLPEndpoint returns why endpoint is not a host:port or an http(s) URL, or "" if it is valid.
+func validateOTLPEndpoint(endpoint string) string {
+	if strings.Contains(endpoint, "://") {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return "must be host:port or a valid URL"
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return "URL scheme must be http or https"
+		}
+		if u.Host == "" {
+			return "URL must have a host"
+		}
+		return ""
+	}
+
+	host, port, err := net.SplitHostPort(endpoint)
+	if err != nil || host == "" || port == "" {
+		return "must be host:port or a URL such as https://collector:4318"
+	}
+	return ""
+}
+
+// validate checks the batch settings and returns field errors prefixed with the given field path.
+func (b *OTLPBatchConfig) validate(field string) []FieldError {
+	var fieldErrors []FieldError
+
+	if b.MaxQueueSize < 0 {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".MaxQueueSize",
+			Value:  b.MaxQueueSize,
+			Reason: "cannot be negative",
+		})
+	}
+	if b.ExportMaxBatchSize < 0 {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".ExportMaxBatchSize",
+			Value:  b.ExportMaxBatchSize,
+			Reason: "cannot be negative",
+		})
+	}
+	if b.MaxQueueSize > 0 && b.ExportMaxBatchSize > b.MaxQueueSize {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".ExportMaxBatchSize",
+			Value:  b.ExportMaxBatchSize,
+			Reason: "cannot exceed MaxQueueSize",
+		})
+	}
+	if b.ExportInterval < 0 {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".ExportInterval",
+			Value:  b.ExportInterval,
+			Reason: "cannot be negative",
+		})
+	}
+	if b.ExportTimeout < 0 {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".ExportTimeout",
+			Value:  b.ExportTimeout,
+			Reason: "cannot be negative",
+		})
+	}
+
+	return fieldErrors
+}
@@ -18,7 +18,13 @@ var (
 	OutputOtel = enum.NewExtended[OutputType]("otel")
 	// OutputFanout represents both console and otel output.
 	OutputFanout = enum.NewExtended[OutputType]("fanout")
-	_            = enum.Finalize[OutputType]() // still required internally
+	// OutputFile represents rotating file output.
+	OutputFile = enum.NewExtended[OutputType]("file")
+	// OutputSyslog represents syslog output.
+	OutputSyslog = enum.NewExtended[OutputType]("syslog")
+	// OutputJournald represents systemd-journald native output.
+	OutputJournald = enum.NewExtended[OutputType]("journald")
+	_              = enum.Finalize[OutputType]() // still required internally
 )
 
 func AllOutputTypes() []OutputType {
@@ -32,3 +38,8 @@ func OutputTypeFromString(name string) OutputType {
 	slog.Warn(fmt.Sprintf("Unknown output type: %q, defaulting to %s", name, OutputConsole))
 	return OutputConsole
 }
+
+// ParseOutputType parses a case-insensitive string to an OutputType, returning an error if it is unknown.
+func ParseOutputType(name string) (OutputType, error) {
+	return parseEnum[OutputType]("output type", name)
+}
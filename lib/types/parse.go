@@ -0,0 +1,61 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/xybor-x/enum"
+)
+
+// parseEnum parses a case-insensitive enum name, returning an error listing the valid names if it is unknown.
+func parseEnum[T comparable](kind, name string) (T, error) {
+	if v, ok := enum.FromString[T](strings.ToLower(strings.TrimSpace(name))); ok {
+		return v, nil
+	}
+
+	var zero T
+	var valid []string
+	for _, v := range enum.All[T]() {
+		valid = append(valid, fmt.Sprint(v))
+	}
+	return zero, fmt.Errorf("unknown %s %q, valid values: %s", kind, name, strings.Join(valid, ", "))
+}
+
+// marshalEnum returns the name of an enum value, or an empty string for the zero value.
+func marshalEnum[T comparable](v T) string {
+	var zero T
+	if v == zero {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// unmarshalEnum sets v to the enum value with the given name, or to the zero value if the name is empty.
+func unmarshalEnum[T comparable](v *T, name string, parse func(string) (T, error)) error {
+	if name == "" {
+		var zero T
+		*v = zero
+		return nil
+	}
+
+	parsed, err := parse(name)
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+// unmarshalEnumJSON decodes a JSON string into an enum value. null leaves v unchanged.
+func unmarshalEnumJSON[T comparable](v *T, data []byte, parse func(string) (T, error)) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return fmt.Errorf("expected a string, got %s", data)
+	}
+	return unmarshalEnum(v, name, parse)
+}
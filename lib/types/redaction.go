@@ -0,0 +1,125 @@
+package types
+
+import (
+	"fmt"
+	"log/slog"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/xybor-x/enum"
+)
+
+// RedactionStrategy represents what a redaction rule does with a matching value.
+type redactionStrategy int
+type RedactionStrategy struct {
+	enum.SafeEnum[redactionStrategy]
+}
+
+var (
+	// RedactMask replaces the matching value (or the matching part of it) with RedactionConfig.Mask.
+	RedactMask = enum.NewExtended[RedactionStrategy]("mask")
+	// RedactDrop removes the attribute.
+	RedactDrop = enum.NewExtended[RedactionStrategy]("drop")
+	// RedactHash replaces the matching value with a keyed hash, so equal values can still be correlated.
+	RedactHash = enum.NewExtended[RedactionStrategy]("hash")
+	_          = enum.Finalize[RedactionStrategy]() // still required internally
+)
+
+// AllRedactionStrategies returns all defined RedactionStrategy values.
+func AllRedactionStrategies() []RedactionStrategy {
+	return enum.All[RedactionStrategy]()
+}
+
+// RedactionStrategyFromString parses a string to a RedactionStrategy, returning a fallback if not found.
+func RedactionStrategyFromString(name string) RedactionStrategy {
+	if v, ok := enum.FromString[RedactionStrategy](strings.ToLower(name)); ok {
+		return v
+	}
+	slog.Warn(fmt.Sprintf("Unknown redaction strategy: %q, defaulting to %s", name, RedactMask))
+	return RedactMask
+}
+
+// ParseRedactionStrategy parses a case-insensitive string to a RedactionStrategy, returning an error if it is unknown.
+func ParseRedactionStrategy(name string) (RedactionStrategy, error) {
+	return parseEnum[RedactionStrategy]("redaction strategy", name)
+}
+
+// RedactionRule describes which attributes are redacted and how.
+//
+// Keys are glob patterns (see path.Match) matched case-insensitively against attribute keys
+// at any group depth. ValuePattern is a regular expression matched against string values.
+// A rule with only Keys redacts the whole value of matching attributes; a rule with a
+// ValuePattern redacts the matching parts of string values, limited to attributes matching
+// Keys if any are given.
+//
+// Example:
+//
+//	rules := []loggergo.RedactionRule{
+//	    {Keys: []string{"password", "*token*", "authorization"}, Strategy: loggergo.Types.RedactDrop},
+//	    {ValuePattern: `[\w.+-]+@[\w-]+\.[\w.]+`, Strategy: loggergo.Types.RedactHash},
+//	}
+type RedactionRule struct {
+	Keys         []string          `json:"keys"`          // Keys specifies glob patterns of attribute keys the rule applies to.
+	ValuePattern string            `json:"value_pattern"` // ValuePattern specifies a regular expression matched against string values.
+	Strategy     RedactionStrategy `json:"strategy"`      // Strategy specifies what happens to matching values. Default: loggergo.RedactMask.
+}
+
+// RedactionConfig represents the settings of attribute redaction.
+//
+// Example:
+//
+//	redaction := loggergo.RedactionConfig{
+//	    Rules: []loggergo.RedactionRule{
+//	        {Keys: []string{"password", "*secret*"}, Strategy: loggergo.Types.RedactDrop},
+//	    },
+//	}
+type RedactionConfig struct {
+	Rules   []RedactionRule `json:"rules"`    // Rules specifies the redaction rules, applied in order. Redaction is enabled when at least one rule is set.
+	Mask    string          `json:"mask"`     // Mask specifies the replacement used by RedactMask. Default: "[REDACTED]".
+	HashKey string          `json:"hash_key"` // HashKey specifies the HMAC key used by RedactHash. Required when any rule uses RedactHash.
+}
+
+// validate checks the redaction settings and returns field errors prefixed with the given field path.
+func (r *RedactionConfig) validate(field string) []FieldError {
+	var fieldErrors []FieldError
+
+	for i, rule := range r.Rules {
+		ruleField := fmt.Sprintf("%s.Rules[%d]", field, i)
+
+		if len(rule.Keys) == 0 && rule.ValuePattern == "" {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:  ruleField,
+				Value:  rule,
+				Reason: "must specify Keys or ValuePattern",
+			})
+		}
+		for _, key := range rule.Keys {
+			if _, err := path.Match(key, ""); err != nil {
+				fieldErrors = append(fieldErrors, FieldError{
+					Field:  ruleField + ".Keys",
+					Value:  key,
+					Reason: "invalid glob pattern",
+				})
+			}
+		}
+		if rule.ValuePattern != "" {
+			if _, err := regexp.Compile(rule.ValuePattern); err != nil {
+				fieldErrors = append(fieldErrors, FieldError{
+					Field:  ruleField + ".ValuePattern",
+					Value:  rule.ValuePattern,
+					Reason: fmt.Sprintf("invalid regular expression: %v", err),
+				})
+			}
+		}
+		if rule.Strategy == RedactHash && r.HashKey == "" {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:  field + ".HashKey",
+				Value:  r.HashKey,
+				Reason: fmt.Sprintf("is required by %s (hash strategy)", ruleField),
+			})
+		}
+	}
+
+	return fieldErrors
+}
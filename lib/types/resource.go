@@ -0,0 +1,100 @@
+package types
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/xybor-x/enum"
+)
+
+// ResourceDetector represents a source of OpenTelemetry resource attributes detected at startup.
+type resourceDetector int
+type ResourceDetector struct {
+	enum.SafeEnum[resourceDetector]
+}
+
+var (
+	// ResourceDetectorHost adds host.name, host.arch and host.id.
+	ResourceDetectorHost = enum.NewExtended[ResourceDetector]("host")
+	// ResourceDetectorProcess adds process.pid, the executable, owner and runtime, without command line arguments.
+	ResourceDetectorProcess = enum.NewExtended[ResourceDetector]("process")
+	// ResourceDetectorOS adds os.type and os.description.
+	ResourceDetectorOS = enum.NewExtended[ResourceDetector]("os")
+	// ResourceDetectorContainer adds container.id, parsed from the cgroup and mount information of the process.
+	ResourceDetectorContainer = enum.NewExtended[ResourceDetector]("container")
+	_                         = enum.Finalize[ResourceDetector]() // still required internally
+)
+
+// AllResourceDetectors returns all defined ResourceDetector values.
+func AllResourceDetectors() []ResourceDetector {
+	return enum.All[ResourceDetector]()
+}
+
+// ResourceDetectorFromString parses a string to a ResourceDetector, returning a fallback if not found.
+func ResourceDetectorFromString(name string) ResourceDetector {
+	if v, ok := enum.FromString[ResourceDetector](strings.ToLower(name)); ok {
+		return v
+	}
+	slog.Warn(fmt.Sprintf("Unknown resource detector: %q, defaulting to %s", name, ResourceDetectorHost))
+	return ResourceDetectorHost
+}
+
+// ParseResourceDetector parses a case-insensitive string to a ResourceDetector, returning an error if it is unknown.
+func ParseResourceDetector(name string) (ResourceDetector, error) {
+	return parseEnum[ResourceDetector]("resource detector", name)
+}
+
+// ResourceConfig represents the OpenTelemetry resource describing the service, used by the OTLP
+// output and by LogFormatOtel.
+//
+// Attributes are applied on top of the SDK defaults (including OTEL_RESOURCE_ATTRIBUTES) and
+// detected attributes; service.name (from OtelServiceName), ServiceVersion and Environment
+// take precedence over Attributes.
+//
+// Example:
+//
+//	res := loggergo.ResourceConfig{
+//	    ServiceVersion: "1.4.2",
+//	    Environment:    "production",
+//	    Attributes:     map[string]string{"team": "payments"},
+//	    Detectors:      []loggergo.ResourceDetector{loggergo.Types.ResourceDetectorHost, loggergo.Types.ResourceDetectorContainer},
+//	}
+type ResourceConfig struct {
+	ServiceVersion string             `json:"service_version"` // ServiceVersion sets the service.version attribute. Default: not set.
+	Environment    string             `json:"environment"`     // Environment sets the deployment.environment attribute, e.g. "production". Default: not set.
+	Attributes     map[string]string  `json:"attributes"`      // Attributes specifies additional resource attributes. Default: none.
+	Detectors      []ResourceDetector `json:"detectors"`       // Detectors specifies which attributes are detected at startup. Valid values are loggergo.ResourceDetectorHost, loggergo.ResourceDetectorProcess, loggergo.ResourceDetectorOS and loggergo.ResourceDetectorContainer. Default: none.
+}
+
+// IsZero reports whether no resource setting is set.
+func (r *ResourceConfig) IsZero() bool {
+	return r.ServiceVersion == "" && r.Environment == "" && len(r.Attributes) == 0 && len(r.Detectors) == 0
+}
+
+// validate checks the resource settings and returns field errors prefixed with the given field path.
+func (r *ResourceConfig) validate(field string) []FieldError {
+	var fieldErrors []FieldError
+
+	for key := range r.Attributes {
+		if strings.TrimSpace(key) == "" {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:  field + ".Attributes",
+				Value:  key,
+				Reason: "attribute keys cannot be empty",
+			})
+		}
+	}
+
+	for i, detector := range r.Detectors {
+		if detector == (ResourceDetector{}) {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:  fmt.Sprintf("%s.Detectors[%d]", field, i),
+				Value:  detector,
+				Reason: "must be a defined resource detector",
+			})
+		}
+	}
+
+	return fieldErrors
+}
@@ -0,0 +1,90 @@
+package types
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// RouteConfig represents a rule sending the records it matches to some of the sinks in Config.Sinks.
+//
+// A record matches a route if it matches all of the route's conditions; a route without
+// conditions matches every record. Routes are evaluated in order and the first matching route
+// decides where a record goes, unless it has Continue set, in which case the following routes
+// are evaluated as well and the record goes to the sinks of every matching route. Records that
+// match no route go to the sinks that no route names.
+//
+// Routes are evaluated below the context handler, so attributes taken from the context by
+// ContextKeys can be matched by Attributes.
+//
+// Example:
+//
+//	routes := []loggergo.RouteConfig{
+//	    {Attributes: map[string]string{"audit": "true"}, Sinks: []string{"audit"}},
+//	    {Component: "security", Sinks: []string{"syslog"}},
+//	}
+type RouteConfig struct {
+	Sinks         []string          `json:"sinks"`          // Sinks specifies the names of the sinks matching records are written to. Required.
+	Level         slog.Leveler      `json:"level"`          // Level matches records at or above the level. Default: any level.
+	MessagePrefix string            `json:"message_prefix"` // MessagePrefix matches records whose message starts with the prefix. Default: any message.
+	Component     string            `json:"component"`      // Component matches records of loggers created with loggergo.Named for the component or one of its sub-components (e.g. "db" matches "db.pool"). Default: any component.
+	Attributes    map[string]string `json:"attributes"`     // Attributes matches records having all of the attributes with the given values, compared as strings. Keys of attributes in groups are joined with dots (e.g. "http.method"). Default: any attributes.
+	Continue      bool              `json:"continue"`       // Continue specifies whether the following routes are evaluated after this one matched. Default: false.
+}
+
+// validate checks the route settings against the sinks of c and returns field errors
+// prefixed with the given field path.
+func (r *RouteConfig) validate(field string, c *Config) []FieldError {
+	var fieldErrors []FieldError
+
+	if len(r.Sinks) == 0 {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".Sinks",
+			Value:  r.Sinks,
+			Reason: "must name at least one sink",
+		})
+	}
+	for i, name := range r.Sinks {
+		if count := c.countSinks(name); count != 1 {
+			reason := "must be the name of a sink in Sinks"
+			if count > 1 {
+				reason = fmt.Sprintf("names %d sinks, sink names used by routes must be unique", count)
+			}
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:  fmt.Sprintf("%s.Sinks[%d]", field, i),
+				Value:  name,
+				Reason: reason,
+			})
+		}
+	}
+
+	if r.Component != "" && (r.Component == "*" || !validateComponentName(r.Component)) {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".Component",
+			Value:  r.Component,
+			Reason: "must be a dot-separated name without empty segments",
+		})
+	}
+
+	for key := range r.Attributes {
+		if key == "" {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:  field + ".Attributes",
+				Value:  key,
+				Reason: "keys cannot be empty",
+			})
+		}
+	}
+
+	return fieldErrors
+}
+
+// countSinks returns the number of sinks with the given name.
+func (c *Config) countSinks(name string) int {
+	count := 0
+	for i := range c.Sinks {
+		if name != "" && c.Sinks[i].Name == name {
+			count++
+		}
+	}
+	return count
+}
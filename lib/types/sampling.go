@@ -0,0 +1,96 @@
+package types
+
+import "time"
+
+// SamplingConfig represents the settings of log sampling.
+//
+// Sampling works like zap's sampler: within each Tick, the first First records with a given
+// level and message are kept, and after that only every Thereafter-th one. Records at
+// slog.LevelError and above are never sampled. The number of sampled-out records is reported
+// every SummaryInterval as a single summary record.
+//
+// Example:
+//
+//	sampling := loggergo.SamplingConfig{
+//	    Enabled:    true,
+//	    Tick:       time.Second,
+//	    First:      10,
+//	    Thereafter: 100,
+//	}
+type SamplingConfig struct {
+	Enabled         bool          `json:"enabled"`          // Enabled specifies whether records are sampled. Default: false.
+	Tick            time.Duration `json:"tick"`             // Tick specifies the time window in which records are counted. Default: 1s.
+	First           int           `json:"first"`            // First specifies how many records with the same level and message are kept per Tick. Default: 100.
+	Thereafter      int           `json:"thereafter"`       // Thereafter specifies that every Thereafter-th record is kept after First. Default: 100.
+	SummaryInterval time.Duration `json:"summary_interval"` // SummaryInterval specifies how often the number of sampled-out records is logged. Default: 1m.
+}
+
+// validate checks the sampling settings and returns field errors prefixed with the given field path.
+func (s *SamplingConfig) validate(field string) []FieldError {
+	var fieldErrors []FieldError
+
+	if s.Tick < 0 {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".Tick",
+			Value:  s.Tick,
+			Reason: "cannot be negative",
+		})
+	}
+	if s.First < 0 {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".First",
+			Value:  s.First,
+			Reason: "cannot be negative",
+		})
+	}
+	if s.Thereafter < 0 {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".Thereafter",
+			Value:  s.Thereafter,
+			Reason: "cannot be negative",
+		})
+	}
+	if s.SummaryInterval < 0 {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".SummaryInterval",
+			Value:  s.SummaryInterval,
+			Reason: "cannot be negative",
+		})
+	}
+
+	return fieldErrors
+}
+
+// TraceSamplingConfig represents the settings of trace-based sampling.
+//
+// When Enabled and Config.OtelTracingEnabled is set, records below slog.LevelWarn are kept only
+// if the span in their context is sampled, so logs follow the trace sampling decisions. Records
+// logged without a span, such as startup and background logs, are kept with probability
+// FallbackRatio, all of them if it is nil. Warn and above are always kept.
+//
+// Example:
+//
+//	fallbackRatio := 0.1
+//	traceSampling := loggergo.TraceSamplingConfig{
+//	    Enabled:       true,
+//	    FallbackRatio: &fallbackRatio,
+//	}
+type TraceSamplingConfig struct {
+	Enabled       bool     `json:"enabled"`        // Enabled specifies whether records are sampled by the span in their context. Requires OtelTracingEnabled. Default: false.
+	FallbackRatio *float64 `json:"fallback_ratio"` // FallbackRatio specifies the fraction (0 to 1) of records without a span that are kept; 0 keeps none. Default: nil, all are kept.
+}
+
+// validate checks the trace sampling settings and returns field errors prefixed with the given field path.
+func (s *TraceSamplingConfig) validate(field string) []FieldError {
+	var fieldErrors []FieldError
+
+	if s.FallbackRatio != nil && (*s.FallbackRatio < 0 || *s.FallbackRatio > 1) {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".FallbackRatio",
+			Value:  *s.FallbackRatio,
+			Reason: "must be between 0 and 1",
+		})
+	}
+
+	return fieldErrors
+}
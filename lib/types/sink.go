@@ -0,0 +1,161 @@
+package types
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+)
+
+// SinkConfig represents one output of the logger when Config.Sinks is set.
+//
+// Every record passing Config.Level (and ComponentLevels) is offered to each sink; a sink
+// writes it if it is at or above the sink's own Level. Each sink has its own output, format
+// and writer. Settings left empty are taken from the Config, so e.g. a file sink without
+// File settings uses Config.File. The OTEL settings (OtelLoggerName, OtelServiceName, OTLP,
+// Resource) and the wrappers (Async, Sampling, Redaction, context keys) are shared by all sinks.
+//
+// Example:
+//
+//	sinks := []loggergo.SinkConfig{
+//	    {Output: loggergo.Types.OutputConsole, DevMode: true, Level: slog.LevelDebug},
+//	    {Output: loggergo.Types.OutputFile, Format: loggergo.Types.LogFormatJSON, Level: slog.LevelInfo,
+//	        File: loggergo.FileConfig{Path: "/var/log/myapp/app.log"}},
+//	    {Output: loggergo.Types.OutputOtel, Level: slog.LevelWarn},
+//	}
+type SinkConfig struct {
+	Name         string          `json:"name"`          // Name identifies the sink in errors. Default: its position in Sinks.
+	Output       OutputType      `json:"output"`        // Output specifies where the sink writes. Valid values are loggergo.OutputConsole, loggergo.OutputOtel, loggergo.OutputFile, loggergo.OutputSyslog and loggergo.OutputJournald. Required.
+	Format       LogFormat       `json:"format"`        // Format specifies the log format of console and file sinks. Default: Config.Format.
+	DevMode      bool            `json:"dev_mode"`      // DevMode specifies whether a console or file sink uses the pretty development format. Default: false.
+	DevFlavor    DevFlavor       `json:"dev_flavor"`    // DevFlavor specifies the development format used with DevMode. Default: Config.DevFlavor.
+	OutputStream io.Writer       `json:"output_stream"` // OutputStream specifies the writer of a console sink. Default: Config.OutputStream.
+	Level        slog.Leveler    `json:"level"`         // Level specifies the minimum level of records written by the sink, on top of Config.Level. Use a *slog.LevelVar to change it at runtime. Default: no additional minimum.
+	Attributes   AttributeFilter `json:"attributes"`    // Attributes specifies which attributes the sink writes. Default: all.
+	File         FileConfig      `json:"file"`          // File specifies the settings of a file sink. Default: Config.File.
+	Syslog       SyslogConfig    `json:"syslog"`        // Syslog specifies the settings of a syslog sink. Default: Config.Syslog.
+	Journald     JournaldConfig  `json:"journald"`      // Journald specifies the settings of a journald sink. Default: Config.Journald.
+}
+
+// AttributeFilter represents which attributes a sink writes, by glob patterns of attribute keys
+// matched case-insensitively as in RedactionRule.Keys. A group attribute is matched by its key.
+type AttributeFilter struct {
+	Include []string `json:"include"` // Include specifies the keys that are written. Default: all keys.
+	Exclude []string `json:"exclude"` // Exclude specifies keys that are not written, even if they match Include.
+}
+
+// IsZero reports whether the filter keeps all attributes.
+func (f *AttributeFilter) IsZero() bool {
+	return len(f.Include) == 0 && len(f.Exclude) == 0
+}
+
+// OutputConfig returns the configuration of the sink's output: config with Output and the
+// sink's settings applied and without Sinks.
+func (s *SinkConfig) OutputConfig(config Config) Config {
+	config.Sinks = nil
+	config.Output = s.Output
+	config.DevMode = s.DevMode
+	if s.Format != (LogFormat{}) {
+		config.Format = s.Format
+	}
+	if s.DevFlavor != (DevFlavor{}) {
+		config.DevFlavor = s.DevFlavor
+	}
+	if s.OutputStream != nil {
+		config.OutputStream = s.OutputStream
+	}
+	if s.File != (FileConfig{}) {
+		config.File = s.File
+	}
+	if s.Syslog != (SyslogConfig{}) {
+		config.Syslog = s.Syslog
+	}
+	if s.Journald != (JournaldConfig{}) {
+		config.Journald = s.Journald
+	}
+	return config
+}
+
+// validate checks the sink settings against the configuration c and returns field errors
+// prefixed with the given field path. Settings taken from c are reported under their own field.
+func (s *SinkConfig) validate(field string, c *Config) []FieldError {
+	var fieldErrors []FieldError
+
+	switch s.Output {
+	case OutputType{}:
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".Output",
+			Value:  s.Output,
+			Reason: "must be specified (Console, OTEL, File, Syslog, or Journald)",
+		})
+	case OutputFanout:
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".Output",
+			Value:  s.Output,
+			Reason: "cannot be Fanout, list each output as a sink instead",
+		})
+	case OutputOtel:
+		if c.OtelLoggerName == "" {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:  "OtelLoggerName",
+				Value:  c.OtelLoggerName,
+				Reason: fmt.Sprintf("is required by %s (OTEL output)", field),
+			})
+		}
+		if c.OtelServiceName == "" {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:  "OtelServiceName",
+				Value:  c.OtelServiceName,
+				Reason: fmt.Sprintf("is required by %s (OTEL output)", field),
+			})
+		}
+	case OutputFile:
+		if s.File != (FileConfig{}) {
+			fieldErrors = append(fieldErrors, s.File.validate(field+".File")...)
+		} else {
+			fieldErrors = append(fieldErrors, c.File.validate("File")...)
+		}
+	case OutputSyslog:
+		if s.Syslog != (SyslogConfig{}) {
+			fieldErrors = append(fieldErrors, s.Syslog.validate(field+".Syslog")...)
+		} else {
+			fieldErrors = append(fieldErrors, c.Syslog.validate("Syslog")...)
+		}
+	case OutputJournald:
+		if s.Journald != (JournaldConfig{}) {
+			fieldErrors = append(fieldErrors, s.Journald.validate(field+".Journald")...)
+		} else {
+			fieldErrors = append(fieldErrors, c.Journald.validate("Journald")...)
+		}
+	}
+
+	fieldErrors = append(fieldErrors, s.Attributes.validate(field+".Attributes")...)
+
+	return fieldErrors
+}
+
+// validate checks the key patterns and returns field errors prefixed with the given field path.
+func (f *AttributeFilter) validate(field string) []FieldError {
+	var fieldErrors []FieldError
+
+	for _, key := range f.Include {
+		if _, err := path.Match(key, ""); err != nil {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:  field + ".Include",
+				Value:  key,
+				Reason: "invalid glob pattern",
+			})
+		}
+	}
+	for _, key := range f.Exclude {
+		if _, err := path.Match(key, ""); err != nil {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:  field + ".Exclude",
+				Value:  key,
+				Reason: "invalid glob pattern",
+			})
+		}
+	}
+
+	return fieldErrors
+}
@@ -0,0 +1,63 @@
+package types
+
+import "time"
+
+// SpoolConfig represents the settings of the disk spool of the OTLP exporter.
+//
+// When Dir is set, records the exporter fails to export with a retryable error are appended to
+// segment files in Dir instead of being dropped, and later records are queued behind them.
+// Records rejected with a non-retryable error, such as HTTP 400, are still dropped. Every
+// RetryInterval the spooled records are exported again in order, and a segment file is removed
+// once all of its records have been exported. Segments left by a previous process are replayed
+// on startup. When the segment files would grow beyond MaxSize, the oldest segments are dropped.
+// Dir is locked while in use and cannot be shared by two loggers.
+//
+// Example:
+//
+//	spool := loggergo.SpoolConfig{
+//	    Dir:           "/var/spool/myapp/otlp",
+//	    MaxSize:       512 << 20,
+//	    RetryInterval: 10 * time.Second,
+//	}
+type SpoolConfig struct {
+	Dir           string        `json:"dir"`            // Dir specifies the directory of the segment files. It is created if missing. Default: empty, records that fail to export are dropped.
+	MaxSize       int64         `json:"max_size"`       // MaxSize specifies the maximum total size in bytes of the segment files. Default: 100 MiB.
+	SegmentSize   int64         `json:"segment_size"`   // SegmentSize specifies the size in bytes at which a new segment file is started. Default: 8 MiB.
+	RetryInterval time.Duration `json:"retry_interval"` // RetryInterval specifies how often spooled records are exported again. Default: 5s.
+}
+
+// validate checks the spool settings and returns field errors prefixed with the given field path.
+func (s *SpoolConfig) validate(field string) []FieldError {
+	var fieldErrors []FieldError
+
+	if s.MaxSize < 0 {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".MaxSize",
+			Value:  s.MaxSize,
+			Reason: "cannot be negative",
+		})
+	}
+	if s.SegmentSize < 0 {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".SegmentSize",
+			Value:  s.SegmentSize,
+			Reason: "cannot be negative",
+		})
+	}
+	if s.MaxSize > 0 && s.SegmentSize > s.MaxSize {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".SegmentSize",
+			Value:  s.SegmentSize,
+			Reason: "cannot be greater than MaxSize",
+		})
+	}
+	if s.RetryInterval < 0 {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".RetryInterval",
+			Value:  s.RetryInterval,
+			Reason: "cannot be negative",
+		})
+	}
+
+	return fieldErrors
+}
@@ -0,0 +1,130 @@
+package types
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/xybor-x/enum"
+)
+
+// SyslogFormat represents the syslog message format.
+type syslogFormat int
+type SyslogFormat struct{ enum.SafeEnum[syslogFormat] }
+
+var (
+	// SyslogRFC5424 represents the RFC 5424 format with STRUCTURED-DATA.
+	SyslogRFC5424 = enum.NewExtended[SyslogFormat]("rfc5424")
+	// SyslogRFC3164 represents the legacy BSD (RFC 3164) format.
+	SyslogRFC3164 = enum.NewExtended[SyslogFormat]("rfc3164")
+	_             = enum.Finalize[SyslogFormat]() // still required internally
+)
+
+// AllSyslogFormats returns all defined SyslogFormat values.
+func AllSyslogFormats() []SyslogFormat {
+	return enum.All[SyslogFormat]()
+}
+
+// SyslogFormatFromString parses a string to a SyslogFormat, returning a fallback if not found.
+func SyslogFormatFromString(name string) SyslogFormat {
+	if v, ok := enum.FromString[SyslogFormat](strings.ToLower(name)); ok {
+		return v
+	}
+	slog.Warn(fmt.Sprintf("Unknown syslog format: %q, defaulting to %s", name, SyslogRFC5424))
+	return SyslogRFC5424
+}
+
+// ParseSyslogFormat parses a case-insensitive string to a SyslogFormat, returning an error if it is unknown.
+func ParseSyslogFormat(name string) (SyslogFormat, error) {
+	return parseEnum[SyslogFormat]("syslog format", name)
+}
+
+// SyslogFacilities maps syslog facility names to their numeric codes.
+var SyslogFacilities = map[string]int{
+	"kern":     0,
+	"user":     1,
+	"mail":     2,
+	"daemon":   3,
+	"auth":     4,
+	"syslog":   5,
+	"lpr":      6,
+	"news":     7,
+	"uucp":     8,
+	"cron":     9,
+	"authpriv": 10,
+	"ftp":      11,
+	"local0":   16,
+	"local1":   17,
+	"local2":   18,
+	"local3":   19,
+	"local4":   20,
+	"local5":   21,
+	"local6":   22,
+	"local7":   23,
+}
+
+// SyslogConfig represents the settings of the syslog output.
+//
+// With an empty Network and Address, the local syslog daemon is used via its unix socket
+// (/dev/log, /var/run/syslog or /var/run/log).
+//
+// Example:
+//
+//	syslog := loggergo.SyslogConfig{
+//	    Network:  "udp",
+//	    Address:  "syslog.example.com:514",
+//	    Format:   loggergo.SyslogRFC5424,
+//	    Facility: "local0",
+//	}
+type SyslogConfig struct {
+	Network      string       `json:"network"`       // Network specifies the transport: "udp", "tcp", "unix" or "unixgram". Default: "" (local syslog socket).
+	Address      string       `json:"address"`       // Address specifies host:port for udp/tcp or the socket path for unix/unixgram. Required for udp and tcp.
+	Format       SyslogFormat `json:"format"`        // Format specifies the message format. Valid values are loggergo.SyslogRFC5424 and loggergo.SyslogRFC3164. Default: loggergo.SyslogRFC5424.
+	Facility     string       `json:"facility"`      // Facility specifies the syslog facility name (e.g. "user", "daemon", "local0"). Default: "user".
+	AppName      string       `json:"app_name"`      // AppName specifies the APP-NAME (RFC 5424) or TAG (RFC 3164, alphanumeric characters only, at most 32). Default: Config.OtelServiceName.
+	Hostname     string       `json:"hostname"`      // Hostname specifies the HOSTNAME field. Default: os.Hostname().
+	EnterpriseID int          `json:"enterprise_id"` // EnterpriseID specifies the private enterprise number used in STRUCTURED-DATA IDs. Default: 32473 (reserved for documentation).
+}
+
+// validate checks the syslog settings and returns field errors prefixed with the given field path.
+func (s *SyslogConfig) validate(field string) []FieldError {
+	var fieldErrors []FieldError
+
+	switch s.Network {
+	case "", "unix", "unixgram":
+	case "udp", "tcp":
+		if s.Address == "" {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:  field + ".Address",
+				Value:  s.Address,
+				Reason: "required when Network is udp or tcp",
+			})
+		}
+	default:
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".Network",
+			Value:  s.Network,
+			Reason: "must be one of udp, tcp, unix or unixgram",
+		})
+	}
+
+	if s.Facility != "" {
+		if _, ok := SyslogFacilities[strings.ToLower(s.Facility)]; !ok {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:  field + ".Facility",
+				Value:  s.Facility,
+				Reason: "unknown syslog facility",
+			})
+		}
+	}
+
+	if s.EnterpriseID < 0 {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:  field + ".EnterpriseID",
+			Value:  s.EnterpriseID,
+			Reason: "cannot be negative",
+		})
+	}
+
+	return fieldErrors
+}
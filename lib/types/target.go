@@ -0,0 +1,125 @@
+package types
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// FileTarget is an io.Writer appending to the file at Path.
+//
+// It is what a file path given as Config.OutputStream in a configuration file decodes to.
+// Decoding a configuration has no side effects: the file and its parent directories are
+// created by Open, which the logger calls when it builds its handlers, so a path that cannot
+// be opened fails Init, New and configuration reloads. A FileTarget that was never opened
+// opens the file on the first write. Close closes the file; writes after Close fail with
+// os.ErrClosed until Open is called again.
+//
+// Thread Safety:
+//
+// FileTarget is safe for concurrent use.
+type FileTarget struct {
+	Path string
+
+	mu     sync.Mutex
+	file   *os.File
+	closed bool
+}
+
+// NewFileTarget returns a FileTarget for the file at path.
+func NewFileTarget(path string) *FileTarget {
+	return &FileTarget{Path: path}
+}
+
+// Open creates the file and its parent directories if needed and opens it for appending.
+// It does nothing if the file is already open.
+func (t *FileTarget) Open() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.open()
+}
+
+// open opens the file if it is not open yet. The caller must hold t.mu.
+func (t *FileTarget) open() error {
+	if t.file != nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(t.Path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(t.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	t.file = file
+	t.closed = false
+	return nil
+}
+
+// Write appends p to the file, opening it first if it was never opened.
+// It returns os.ErrClosed after Close.
+func (t *FileTarget) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return 0, os.ErrClosed
+	}
+	if err := t.open(); err != nil {
+		return 0, err
+	}
+
+	return t.file.Write(p)
+}
+
+// Close closes the file if it is open. Later writes fail with os.ErrClosed.
+func (t *FileTarget) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.closed = true
+	if t.file == nil {
+		return nil
+	}
+	err := t.file.Close()
+	t.file = nil
+	return err
+}
+
+// OutputTarget returns the writer for a named output target: os.Stdout for "stdout",
+// os.Stderr for "stderr" (both case-insensitive), nil for an empty name and a FileTarget
+// for anything else.
+func OutputTarget(name string) io.Writer {
+	switch strings.ToLower(name) {
+	case "":
+		return nil
+	case "stdout":
+		return os.Stdout
+	case "stderr":
+		return os.Stderr
+	default:
+		return NewFileTarget(name)
+	}
+}
+
+// OutputTargetName returns the name of a writer as understood by OutputTarget, or an
+// empty string if the writer has no name (e.g. a bytes.Buffer).
+func OutputTargetName(w io.Writer) string {
+	switch w := w.(type) {
+	case *FileTarget:
+		return w.Path
+	case *os.File:
+		switch w {
+		case os.Stdout:
+			return "stdout"
+		case os.Stderr:
+			return "stderr"
+		}
+		return w.Name()
+	default:
+		return ""
+	}
+}
@@ -1,12 +1,14 @@
 // Package loggergo provides a lightweight, customizable logging library for Go applications.
 //
 // LoggerGo is built on top of Go's standard log/slog package and provides:
-//   - Multiple output modes (console, OpenTelemetry, fanout)
+//   - Multiple output modes (console, OpenTelemetry, fanout, rotating file, syslog, journald)
 //   - Multiple log formats (JSON, text, OTEL)
 //   - Development mode with different flavors (tint, slogor, devslog)
 //   - Context-aware logging with automatic value extraction
 //   - OpenTelemetry integration with trace/span ID injection
-//   - Dynamic log level adjustment
+//   - Dynamic log level adjustment, globally and per named component
+//   - Asynchronous writing and sampling of repeated records
+//   - Redaction of secrets and PII in attributes
 //   - Thread-safe configuration management
 //   - Comprehensive error handling with graceful degradation
 //
@@ -41,22 +43,115 @@ import (
 	"os"
 
 	"log/slog"
+	"sync/atomic"
 
 	slogmulti "github.com/samber/slog-multi"
 
 	"github.com/wasilak/loggergo/lib"
 	"github.com/wasilak/loggergo/lib/modes"
+	"github.com/wasilak/loggergo/lib/outputs"
 	"github.com/wasilak/loggergo/lib/types"
 )
 
 var logLevel = new(slog.LevelVar)
 
+// globalLogger is the Logger most recently created by Init.
+var globalLogger atomic.Pointer[Logger]
+
 // Config represents the configuration options for the logger.
 // It is an alias for types.Config and is exported for external usage.
 //
 // See types.Config for detailed field documentation and usage examples.
 type Config = types.Config
 
+// OtelFormatConfig represents the settings of LogFormatOtel.
+// It is an alias for types.OtelFormatConfig and is exported for external usage.
+type OtelFormatConfig = types.OtelFormatConfig
+
+// SinkConfig represents one output of the logger when Config.Sinks is set.
+// It is an alias for types.SinkConfig and is exported for external usage.
+type SinkConfig = types.SinkConfig
+
+// AttributeFilter represents which attributes a sink writes.
+// It is an alias for types.AttributeFilter and is exported for external usage.
+type AttributeFilter = types.AttributeFilter
+
+// RouteConfig represents a rule sending the records it matches to some of the sinks.
+// It is an alias for types.RouteConfig and is exported for external usage.
+type RouteConfig = types.RouteConfig
+
+// FileConfig represents the rotating file output settings.
+// It is an alias for types.FileConfig and is exported for external usage.
+type FileConfig = types.FileConfig
+
+// SyslogConfig represents the syslog output settings.
+// It is an alias for types.SyslogConfig and is exported for external usage.
+type SyslogConfig = types.SyslogConfig
+
+// JournaldConfig represents the systemd-journald output settings.
+// It is an alias for types.JournaldConfig and is exported for external usage.
+type JournaldConfig = types.JournaldConfig
+
+// AsyncConfig represents the asynchronous handler settings.
+// It is an alias for types.AsyncConfig and is exported for external usage.
+type AsyncConfig = types.AsyncConfig
+
+// SamplingConfig represents the log sampling settings.
+// It is an alias for types.SamplingConfig and is exported for external usage.
+type SamplingConfig = types.SamplingConfig
+
+// TraceSamplingConfig represents the trace-based sampling settings.
+// It is an alias for types.TraceSamplingConfig and is exported for external usage.
+type TraceSamplingConfig = types.TraceSamplingConfig
+
+// RedactionConfig represents the attribute redaction settings.
+// It is an alias for types.RedactionConfig and is exported for external usage.
+type RedactionConfig = types.RedactionConfig
+
+// RedactionRule represents a single attribute redaction rule.
+// It is an alias for types.RedactionRule and is exported for external usage.
+type RedactionRule = types.RedactionRule
+
+// OTLPConfig represents the OTLP log exporter settings.
+// It is an alias for types.OTLPConfig and is exported for external usage.
+type OTLPConfig = types.OTLPConfig
+
+// OTLPTLSConfig represents the TLS settings of the OTLP log exporter.
+// It is an alias for types.OTLPTLSConfig and is exported for external usage.
+type OTLPTLSConfig = types.OTLPTLSConfig
+
+// OTLPBatchConfig represents the batching of the OTLP log exporter.
+// It is an alias for types.OTLPBatchConfig and is exported for external usage.
+type OTLPBatchConfig = types.OTLPBatchConfig
+
+// ResourceConfig represents the OpenTelemetry resource settings.
+// It is an alias for types.ResourceConfig and is exported for external usage.
+type ResourceConfig = types.ResourceConfig
+
+// ResourceDetector represents a source of detected resource attributes, used in ResourceConfig.Detectors.
+// It is an alias for types.ResourceDetector and is exported for external usage.
+type ResourceDetector = types.ResourceDetector
+
+// FailoverConfig represents the settings of the runtime failover of the OTEL output to the console.
+// It is an alias for types.FailoverConfig and is exported for external usage.
+type FailoverConfig = types.FailoverConfig
+
+// SpoolConfig represents the settings of the disk spool of the OTLP exporter.
+// It is an alias for types.SpoolConfig and is exported for external usage.
+type SpoolConfig = types.SpoolConfig
+
+// BreakerState represents the state of the circuit breaker of an OTEL output with failover.
+// It is an alias for types.BreakerState and is exported for external usage.
+type BreakerState = types.BreakerState
+
+// ContextExtractor returns attributes taken from a context, registered in Config.ContextExtractors.
+// It is an alias for types.ContextExtractor and is exported for external usage.
+type ContextExtractor = types.ContextExtractor
+
+// ContextExtractorFunc is an adapter to use an ordinary function as a ContextExtractor.
+// It is an alias for types.ContextExtractorFunc and is exported for external usage.
+type ContextExtractorFunc = types.ContextExtractorFunc
+
 // Init initializes a logger with the provided configuration and additional attributes.
 //
 // It validates the configuration, creates the appropriate handler based on the output mode,
@@ -96,13 +191,168 @@ type Config = types.Config
 //	    log.Fatalf("Failed to initialize logger: %v", err)
 //	}
 //	logger.Info("Logger initialized successfully")
-func Init(ctx context.Context, config types.Config, additionalAttrs ...any) (retCtx context.Context, retLogger *slog.Logger, retErr error) {
+func Init(ctx context.Context, config types.Config, additionalAttrs ...any) (context.Context, *slog.Logger, error) {
+	manager := lib.GlobalConfigManager()
+	manager.InitConfig()
+	manager.MergeConfig(config)
+
+	ctx, logger, err := build(ctx, manager, logLevel, additionalAttrs...)
+	if err != nil {
+		return ctx, nil, err
+	}
+
+	globalLogger.Store(logger)
+
+	return ctx, logger.Logger, nil
+}
+
+// Logger is an independently configured logger instance.
+//
+// Unlike loggers returned by Init, a Logger owns its configuration, level variable,
+// cleanup functions and handler chain, so several differently configured loggers
+// (e.g. an audit logger and an application logger) can coexist in one process.
+//
+// Logger embeds *slog.Logger, so all slog logging methods are available directly.
+//
+// Thread Safety:
+//
+// Logger is safe for concurrent use by multiple goroutines.
+type Logger struct {
+	*slog.Logger
+
+	manager  *lib.ConfigManager
+	logLevel *slog.LevelVar
+	levels   *componentLevels
+	handler  slog.Handler
+	state    atomic.Pointer[handlerState]
+	reverts  adminReverts
+}
+
+// handlerState holds the handlers of a chain built by buildHandler that a Logger reports on.
+type handlerState struct {
+	async     *AsyncHandler      // nil if Config.Async is not enabled
+	failovers []*failoverHandler // the OTEL outputs with Config.Failover enabled
+	spools    []*outputs.Spool   // the spools of the OTEL outputs if Config.Spool is set
+}
+
+// New creates an independent Logger with the provided configuration.
+//
+// Configuration is merged with the defaults (see Config) and validated the same way as in Init,
+// but it is stored in the returned Logger rather than in the package-global configuration.
+// The logger is set as slog.Default() only if Config.SetAsDefault is true.
+//
+// Resources created for the logger (e.g. OTEL providers) are released by Logger.Shutdown,
+// not by the package-level Shutdown.
+//
+// Error Handling:
+//
+// New never panics. Errors are returned as InitError, as in Init.
+//
+// Example:
+//
+//	auditLogger, err := loggergo.New(ctx, loggergo.Config{
+//	    Level:        slog.LevelInfo,
+//	    Format:       loggergo.Types.LogFormatJSON,
+//	    Output:       loggergo.Types.OutputConsole,
+//	    OutputStream: auditFile,
+//	})
+//	if err != nil {
+//	    log.Fatalf("Failed to initialize audit logger: %v", err)
+//	}
+//	defer auditLogger.Shutdown()
+//	auditLogger.Info("User logged in", "user", "alice")
+func New(ctx context.Context, config types.Config) (*Logger, error) {
+	manager := lib.NewConfigManager()
+	manager.MergeConfig(config)
+
+	_, logger, err := build(ctx, manager, new(slog.LevelVar))
+	if err != nil {
+		return nil, err
+	}
+
+	return logger, nil
+}
+
+// GetLogLevelAccessor returns the logger's level variable for dynamic level changes.
+// Changing it affects only this Logger.
+func (l *Logger) GetLogLevelAccessor() *slog.LevelVar {
+	return l.logLevel
+}
+
+// GetConfig returns a copy of the logger's configuration.
+func (l *Logger) GetConfig() types.Config {
+	return l.manager.GetConfig()
+}
+
+// DroppedRecords returns the number of records dropped by the asynchronous handler
+// because its queue was full. It is always zero if Config.Async is not enabled.
+func (l *Logger) DroppedRecords() uint64 {
+	if state := l.state.Load(); state != nil && state.async != nil {
+		return state.async.Dropped()
+	}
+	return 0
+}
+
+// Shutdown performs cleanup of the resources registered by this logger.
+// See the package-level Shutdown for details.
+func (l *Logger) Shutdown() error {
+	return l.manager.Shutdown()
+}
+
+// build creates the logger described by the manager's configuration.
+// It is shared by Init and New, which differ only in the manager and level variable they use.
+func build(ctx context.Context, manager *lib.ConfigManager, levelVar *slog.LevelVar, additionalAttrs ...any) (context.Context, *Logger, error) {
+	// The outputs filter at the lowest level any component can have; componentHandler
+	// applies the level of each logger on top of that.
+	levels := newComponentLevels(levelVar, nil)
+
+	ctx, defaultHandler, state, err := buildHandler(ctx, manager, levels)
+	if err != nil {
+		return ctx, nil, err
+	}
+
+	cfg := manager.GetConfig()
+	levelVar.Set(cfg.Level.Level())
+	levels.set(cfg.ComponentLevels)
+
+	logger := slog.New(&componentHandler{innerHandler: defaultHandler, level: levels.leveler("")})
+
+	// The code below is iterating over the `additionalAttrs` slice and calling the `With` method on the default logger for
+	// each element in the slice.
+	for _, v := range additionalAttrs {
+		logger.With(v)
+	}
+
+	if cfg.SetAsDefault {
+		// The code `slog.SetDefault(logger)` is setting the default logger to the newly created logger.
+		slog.SetDefault(logger)
+	}
+
+	result := &Logger{
+		Logger:   logger,
+		manager:  manager,
+		logLevel: levelVar,
+		levels:   levels,
+		handler:  defaultHandler,
+	}
+	result.state.Store(state)
+
+	return ctx, result, nil
+}
+
+// buildHandler validates the manager's configuration and creates the handler chain it describes,
+// up to but not including the component handler. Records are filtered at the levels of levels,
+// which the caller updates from the configuration once the chain has been built.
+// Resources created for the chain are registered as cleanups of the manager.
+// The returned state holds the handlers of the chain the Logger reports on.
+func buildHandler(ctx context.Context, manager *lib.ConfigManager, levels *componentLevels) (retCtx context.Context, retHandler slog.Handler, retState *handlerState, retErr error) {
 	// Panic recovery to ensure Init never panics
 	defer func() {
 		if r := recover(); r != nil {
-			cfg := lib.GetConfig()
+			cfg := manager.GetConfig()
 			retCtx = ctx
-			retLogger = nil
+			retHandler = nil
+			retState = nil
 			retErr = &types.InitError{
 				Stage:  "panic_recovery",
 				Cause:  fmt.Errorf("panic during initialization: %v", r),
@@ -116,29 +366,90 @@ func Init(ctx context.Context, config types.Config, additionalAttrs ...any) (ret
 	var defaultHandler slog.Handler
 	var err error
 
-	lib.InitConfig()
-	lib.MergeConfig(config)
-
 	// Validate configuration before initialization
-	cfg := lib.GetConfig()
+	cfg := manager.GetConfig()
 	if err := cfg.Validate(); err != nil {
-		return ctx, nil, &types.InitError{
+		return ctx, nil, nil, &types.InitError{
 			Stage:  "validation",
 			Cause:  err,
 			Config: cfg,
 		}
 	}
 
-	logLevel.Set(lib.GetConfig().Level.Level())
-
 	opts := slog.HandlerOptions{
-		Level:     logLevel,
-		AddSource: lib.GetConfig().Level == slog.LevelDebug,
+		Level:     levels,
+		AddSource: cfg.Level == slog.LevelDebug,
+	}
+
+	state := &handlerState{}
+
+	// Write to every sink, or to the single Output
+	if len(cfg.Sinks) > 0 {
+		ctx, defaultHandler, err = buildSinks(ctx, manager, opts, state)
+	} else {
+		ctx, defaultHandler, err = buildOutput(ctx, manager, opts, state, cfg.Output.String())
+	}
+	if err != nil {
+		return ctx, nil, nil, err
+	}
+
+	// Write records on background goroutines if requested. Registered after the outputs,
+	// so Shutdown drains the queue before the outputs are closed (LIFO).
+	if cfg.Async.Enabled {
+		asyncHandler := NewAsyncHandler(defaultHandler, cfg.Async)
+		manager.RegisterCleanup(asyncHandler.Close)
+		defaultHandler = asyncHandler
+		state.async = asyncHandler
+	}
+
+	// Sample repeated records before they reach the queue. Registered after the async handler,
+	// so its final summary is written before the queue is drained.
+	if cfg.Sampling.Enabled {
+		samplingHandler := NewSamplingHandler(defaultHandler, cfg.Sampling)
+		manager.RegisterCleanup(samplingHandler.Close)
+		defaultHandler = samplingHandler
+	}
+
+	// Redact attributes before they reach any output. The context handler wraps this one,
+	// so attributes taken from the context are redacted as well.
+	if len(cfg.Redaction.Rules) > 0 {
+		defaultHandler, err = NewRedactionHandler(defaultHandler, cfg.Redaction)
+		if err != nil {
+			return ctx, nil, nil, &types.InitError{
+				Stage:  "handler_creation",
+				Cause:  err,
+				Config: cfg,
+			}
+		}
 	}
 
-	switch lib.GetConfig().Output {
+	// The code below is creating a new CustomContextAttributeHandler with the default handler, the context keys and extractors.
+	defaultHandler = NewCustomContextAttributeHandler(defaultHandler, cfg.ContextKeys, cfg.ContextKeysDefault, cfg.ContextExtractors...).WithContextGroup(cfg.ContextGroup)
+
+	return ctx, defaultHandler, state, nil
+}
+
+// buildOutput creates the handler of the manager's config.Output, adding an OTEL output with
+// config.Failover enabled and its spool to state under the given name.
+// If OTEL setup fails, it falls back to console mode. Errors are returned as *types.InitError.
+func buildOutput(ctx context.Context, manager *lib.ConfigManager, opts slog.HandlerOptions, state *handlerState, name string) (context.Context, slog.Handler, error) {
+	var defaultHandler slog.Handler
+	var err error
+
+	cfg := manager.GetConfig()
+
+	// Keep records that fail to export over OTLP on disk
+	var wrappers []outputs.ExporterWrapper
+	if cfg.Output == types.OutputOtel || cfg.Output == types.OutputFanout {
+		wrappers, err = buildSpool(ctx, manager, state)
+		if err != nil {
+			return ctx, nil, err
+		}
+	}
+
+	switch cfg.Output {
 	case types.OutputConsole:
-		defaultHandler, err = modes.ConsoleMode(opts)
+		defaultHandler, err = modes.ConsoleMode(manager, cfg, opts)
 		if err != nil {
 			return ctx, nil, &types.InitError{
 				Stage:  "handler_creation",
@@ -147,11 +458,22 @@ func Init(ctx context.Context, config types.Config, additionalAttrs ...any) (ret
 			}
 		}
 	case types.OutputOtel:
-		defaultHandler, ctx, err = modes.OtelMode(ctx)
+		if cfg.Failover.Enabled {
+			var failover *failoverHandler
+			ctx, defaultHandler, failover, err = buildFailover(ctx, manager, opts, name, wrappers)
+			if err != nil {
+				return ctx, nil, err
+			}
+			if failover != nil {
+				state.failovers = append(state.failovers, failover)
+			}
+			break
+		}
+		defaultHandler, ctx, err = modes.OtelMode(ctx, manager, wrappers...)
 		if err != nil {
 			// Graceful degradation: fall back to console mode on OTEL failure
 			fmt.Fprintf(os.Stderr, "WARNING: OTEL initialization failed (%v), falling back to console mode\n", err)
-			defaultHandler, err = modes.ConsoleMode(opts)
+			defaultHandler, err = modes.ConsoleMode(manager, cfg, opts)
 			if err != nil {
 				return ctx, nil, &types.InitError{
 					Stage:  "handler_creation",
@@ -161,7 +483,7 @@ func Init(ctx context.Context, config types.Config, additionalAttrs ...any) (ret
 			}
 		}
 	case types.OutputFanout:
-		consoleModeHandler, err := modes.ConsoleMode(opts)
+		consoleModeHandler, err := modes.ConsoleMode(manager, cfg, opts)
 		if err != nil {
 			return ctx, nil, &types.InitError{
 				Stage:  "handler_creation",
@@ -169,7 +491,7 @@ func Init(ctx context.Context, config types.Config, additionalAttrs ...any) (ret
 				Config: cfg,
 			}
 		}
-		otelModeHandler, newCtx, err := modes.OtelMode(ctx)
+		otelModeHandler, newCtx, err := modes.OtelMode(ctx, manager, wrappers...)
 		if err != nil {
 			// Graceful degradation: use only console mode on OTEL failure in fanout
 			fmt.Fprintf(os.Stderr, "WARNING: OTEL initialization failed in fanout mode (%v), using console mode only\n", err)
@@ -181,31 +503,42 @@ func Init(ctx context.Context, config types.Config, additionalAttrs ...any) (ret
 				otelModeHandler,
 			)
 		}
+	case types.OutputFile:
+		defaultHandler, err = modes.FileMode(manager, opts)
+		if err != nil {
+			return ctx, nil, &types.InitError{
+				Stage:  "handler_creation",
+				Cause:  err,
+				Config: cfg,
+			}
+		}
+	case types.OutputSyslog:
+		defaultHandler, err = modes.SyslogMode(manager, opts)
+		if err != nil {
+			return ctx, nil, &types.InitError{
+				Stage:  "handler_creation",
+				Cause:  err,
+				Config: cfg,
+			}
+		}
+	case types.OutputJournald:
+		defaultHandler, err = modes.JournaldMode(manager, opts)
+		if err != nil {
+			return ctx, nil, &types.InitError{
+				Stage:  "handler_creation",
+				Cause:  err,
+				Config: cfg,
+			}
+		}
 	default:
 		return ctx, nil, &types.InitError{
 			Stage:  "validation",
-			Cause:  fmt.Errorf("invalid mode: %s. Valid options: [loggergo.OutputConsole, loggergo.OutputOtel, loggergo.OutputFanout]", lib.GetConfig().Output),
+			Cause:  fmt.Errorf("invalid mode: %s. Valid options: [loggergo.OutputConsole, loggergo.OutputOtel, loggergo.OutputFanout, loggergo.OutputFile, loggergo.OutputSyslog, loggergo.OutputJournald]", cfg.Output),
 			Config: cfg,
 		}
 	}
 
-	// The code below is creating a new CustomContextAttributeHandler with the default handler and the context keys.
-	defaultHandler = NewCustomContextAttributeHandler(defaultHandler, lib.GetConfig().ContextKeys, lib.GetConfig().ContextKeysDefault)
-
-	logger := slog.New(defaultHandler)
-
-	// The code below is iterating over the `additionalAttrs` slice and calling the `With` method on the default logger for
-	// each element in the slice.
-	for _, v := range additionalAttrs {
-		logger.With(v)
-	}
-
-	if lib.GetConfig().SetAsDefault {
-		// The code `slog.SetDefault(logger)` is setting the default logger to the newly created logger.
-		slog.SetDefault(logger)
-	}
-
-	return ctx, logger, nil
+	return ctx, defaultHandler, nil
 }
 
 // GetLogLevelAccessor returns the log level accessor for dynamic level changes.
@@ -229,6 +562,15 @@ func GetConfig() types.Config {
 	return lib.GetConfig()
 }
 
+// DroppedRecords returns the number of records dropped by the asynchronous handler of the
+// logger created by Init because its queue was full. It is always zero if Config.Async is not enabled.
+func DroppedRecords() uint64 {
+	if logger := globalLogger.Load(); logger != nil {
+		return logger.DroppedRecords()
+	}
+	return 0
+}
+
 // Shutdown performs cleanup of all registered resources.
 //
 // This function should be called when the application is shutting down to ensure
@@ -296,7 +296,7 @@ func TestInit_SetAsDefault_OTEL(t *testing.T) {
 	// Create a buffer to capture log output
 	var buf bytes.Buffer
 
-	// Configure the logger to use the buffer with plain text format
+	// Configure the logger to use the buffer with OTEL format
 	config := types.Config{
 		OutputStream: &buf,
 		Output:       types.OutputConsole,
@@ -318,29 +318,35 @@ func TestInit_SetAsDefault_OTEL(t *testing.T) {
 	}
 
 	// Split the buffer by newlines to handle multiple log entries
-	logLines := bytes.Split(buf.Bytes(), []byte("\n"))
+	logLines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(logLines) != len(expectedMsgs) {
+		t.Fatalf("Expected %d log lines in OutputStream, got %d: %s", len(expectedMsgs), len(logLines), buf.String())
+	}
 
 	// Iterate over each log line and check the contents
 	for i, line := range logLines {
-		if len(line) == 0 {
-			continue // Skip empty lines
+		var record struct {
+			Timestamp    *string
+			SeverityText string
+			Body         struct{ Value string }
+		}
+		if err := json.Unmarshal(line, &record); err != nil {
+			t.Fatalf("Failed to parse OTEL record %q: %v", line, err)
 		}
-
-		logLine := string(line)
 
 		// Check for each expected message
-		if !bytes.Contains(line, []byte(fmt.Sprintf("msg=%q", expectedMsgs[i]))) {
-			t.Errorf("Expected message '%s' in log output, but got: %s", expectedMsgs[i], logLine)
+		if record.Body.Value != expectedMsgs[i] {
+			t.Errorf("Expected message '%s' in log output, but got: %s", expectedMsgs[i], line)
 		}
 
 		// Check that the level is correctly set
-		if !bytes.Contains(line, []byte("level=INFO")) {
-			t.Errorf("Expected 'level=INFO' in log output, but got: %s", logLine)
+		if record.SeverityText != "INFO" {
+			t.Errorf("Expected 'INFO' severity in log output, but got: %s", line)
 		}
 
 		// Check if the time field is present
-		if !bytes.Contains(line, []byte("time=")) {
-			t.Errorf("Expected 'time' field in log output, but got: %s", logLine)
+		if record.Timestamp == nil {
+			t.Errorf("Expected 'Timestamp' field in log output, but got: %s", line)
 		}
 	}
 }
@@ -1503,3 +1509,232 @@ func TestShutdown_Idempotent(t *testing.T) {
 		t.Errorf("Third Shutdown failed: %v", err)
 	}
 }
+
+// TestNew_IndependentLoggers tests that loggers created with New do not share configuration or levels
+func TestNew_IndependentLoggers(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	var auditBuf, appBuf bytes.Buffer
+
+	auditLogger, err := New(ctx, types.Config{
+		Level:        slog.LevelWarn,
+		Format:       types.LogFormatJSON,
+		Output:       types.OutputConsole,
+		OutputStream: &auditBuf,
+	})
+	if err != nil {
+		t.Fatalf("New failed for audit logger: %v", err)
+	}
+	defer auditLogger.Shutdown()
+
+	appLogger, err := New(ctx, types.Config{
+		Level:        slog.LevelDebug,
+		Format:       types.LogFormatText,
+		Output:       types.OutputConsole,
+		OutputStream: &appBuf,
+	})
+	if err != nil {
+		t.Fatalf("New failed for app logger: %v", err)
+	}
+	defer appLogger.Shutdown()
+
+	auditLogger.Info("audit info")
+	auditLogger.Warn("audit warn")
+	appLogger.Debug("app debug")
+
+	if bytes.Contains(auditBuf.Bytes(), []byte("audit info")) {
+		t.Error("Audit logger should not log below Warn")
+	}
+	if !bytes.Contains(auditBuf.Bytes(), []byte(`"msg":"audit warn"`)) {
+		t.Errorf("Expected JSON warn record in audit output, got: %s", auditBuf.String())
+	}
+	if !bytes.Contains(appBuf.Bytes(), []byte("msg=\"app debug\"")) {
+		t.Errorf("Expected text debug record in app output, got: %s", appBuf.String())
+	}
+	if bytes.Contains(appBuf.Bytes(), []byte("audit")) || bytes.Contains(auditBuf.Bytes(), []byte("app debug")) {
+		t.Error("Loggers should not write to each other's output")
+	}
+
+	// Changing one logger's level must not affect the other
+	appLogger.GetLogLevelAccessor().Set(slog.LevelError)
+	if auditLogger.GetLogLevelAccessor().Level() != slog.LevelWarn {
+		t.Errorf("Expected audit level to stay Warn, got %v", auditLogger.GetLogLevelAccessor().Level())
+	}
+
+	if auditLogger.GetConfig().Format != types.LogFormatJSON || appLogger.GetConfig().Format != types.LogFormatText {
+		t.Error("Each logger should keep its own configuration")
+	}
+}
+
+// TestNew_DoesNotTouchGlobalConfig tests that New does not modify the configuration used by Init
+func TestNew_DoesNotTouchGlobalConfig(t *testing.T) {
+	ctx := context.Background()
+
+	_, _, err := Init(ctx, types.Config{
+		Level:          slog.LevelInfo,
+		Output:         types.OutputConsole,
+		OtelLoggerName: "global-logger",
+	})
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	globalLevel := GetLogLevelAccessor().Level()
+
+	var buf bytes.Buffer
+	logger, err := New(ctx, types.Config{
+		Level:          slog.LevelError,
+		Output:         types.OutputConsole,
+		OutputStream:   &buf,
+		OtelLoggerName: "instance-logger",
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer logger.Shutdown()
+
+	if GetConfig().OtelLoggerName != "global-logger" {
+		t.Errorf("Expected global OtelLoggerName to stay %q, got %q", "global-logger", GetConfig().OtelLoggerName)
+	}
+	if GetLogLevelAccessor().Level() != globalLevel {
+		t.Errorf("Expected global level to stay %v, got %v", globalLevel, GetLogLevelAccessor().Level())
+	}
+	if logger.GetConfig().OtelLoggerName != "instance-logger" {
+		t.Errorf("Expected instance OtelLoggerName %q, got %q", "instance-logger", logger.GetConfig().OtelLoggerName)
+	}
+}
+
+// TestNew_ValidationError tests that New returns an InitError for invalid configuration
+func TestNew_ValidationError(t *testing.T) {
+	t.Parallel()
+
+	// An empty OtelServiceName cannot be merged away, so use an invalid ContextKeysDefault instead
+	logger, err := New(context.Background(), types.Config{
+		Level:              slog.LevelInfo,
+		Output:             types.OutputConsole,
+		ContextKeysDefault: "unknown",
+	})
+	if err == nil {
+		t.Fatal("Expected validation error, got nil")
+	}
+	if logger != nil {
+		t.Error("Expected nil logger on error")
+	}
+
+	var initErr *types.InitError
+	if !errors.As(err, &initErr) {
+		t.Fatalf("Expected InitError, got %T", err)
+	}
+	if initErr.Stage != "validation" {
+		t.Errorf("Expected stage 'validation', got %q", initErr.Stage)
+	}
+}
+
+// TestNew_ShutdownIsPerInstance tests that Logger.Shutdown only runs the instance's cleanup functions
+func TestNew_ShutdownIsPerInstance(t *testing.T) {
+	ctx := context.Background()
+
+	// Clear any cleanup functions from previous tests
+	lib.Shutdown()
+
+	globalCalled := false
+	lib.RegisterCleanup(func() error {
+		globalCalled = true
+		return nil
+	})
+	defer lib.Shutdown()
+
+	logger, err := New(ctx, types.Config{
+		Level:           slog.LevelInfo,
+		Output:          types.OutputOtel,
+		OtelLoggerName:  "test-logger",
+		OtelServiceName: "test-service",
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	if err := logger.Shutdown(); err != nil {
+		t.Logf("Shutdown returned error (expected if OTEL endpoint unavailable): %v", err)
+	}
+
+	if globalCalled {
+		t.Error("Logger.Shutdown should not run global cleanup functions")
+	}
+}
+
+// TestInit_FileOutput tests that OutputFile writes records to the configured file and closes it on Shutdown
+func TestInit_FileOutput(t *testing.T) {
+	ctx := context.Background()
+	path := t.TempDir() + "/app.log"
+
+	_, logger, err := Init(ctx, types.Config{
+		Level:  slog.LevelInfo,
+		Format: types.LogFormatJSON,
+		Output: types.OutputFile,
+		File:   types.FileConfig{Path: path},
+	})
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	logger.Info("file message")
+
+	if GetConfig().OutputStream != os.Stdout {
+		t.Errorf("Expected the configured OutputStream to be kept, got %T", GetConfig().OutputStream)
+	}
+
+	if err := Shutdown(); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	if !bytes.Contains(data, []byte(`"msg":"file message"`)) {
+		t.Errorf("Expected record in log file, got: %s", data)
+	}
+}
+
+// TestInit_FileOutputRequiresPath tests that OutputFile without a path fails validation
+func TestInit_FileOutputRequiresPath(t *testing.T) {
+	_, _, err := Init(context.Background(), types.Config{
+		Level:  slog.LevelInfo,
+		Output: types.OutputFile,
+	})
+
+	var initErr *types.InitError
+	if !errors.As(err, &initErr) || initErr.Stage != "validation" {
+		t.Fatalf("Expected validation InitError, got %v", err)
+	}
+}
+
+// TestNew_TraceSampling tests that trace sampling drops Info records logged outside a sampled span
+func TestNew_TraceSampling(t *testing.T) {
+	var buf bytes.Buffer
+	fallbackRatio := 0.0
+
+	logger, err := New(context.Background(), types.Config{
+		Level:              slog.LevelInfo,
+		Output:             types.OutputConsole,
+		OutputStream:       &buf,
+		OtelTracingEnabled: true,
+		TraceSampling:      types.TraceSamplingConfig{Enabled: true, FallbackRatio: &fallbackRatio},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer logger.Shutdown()
+
+	logger.Info("info without span")
+	logger.Warn("warn without span")
+
+	if bytes.Contains(buf.Bytes(), []byte("info without span")) {
+		t.Error("Expected Info record without span to be dropped")
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("warn without span")) {
+		t.Error("Expected Warn record to be kept")
+	}
+}
@@ -0,0 +1,104 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"google.golang.org/grpc/grpclog"
+)
+
+// grpcLogger is a grpclog.LoggerV2 writing to a slog.Logger.
+type grpcLogger struct {
+	logger    *slog.Logger
+	verbosity int
+}
+
+// NewGRPCLogger returns a grpclog.LoggerV2 that writes gRPC's internal logs to logger.
+//
+// gRPC's Info, Warning and Error logs are written at the corresponding slog levels, and Fatal
+// logs at Error level before exiting. V reports whether verbose logs up to verbosity are enabled.
+// Passing a named logger, e.g. loggergo.Named("grpc"), lets component levels control how much
+// of gRPC's output is kept.
+func NewGRPCLogger(logger *slog.Logger, verbosity int) grpclog.LoggerV2 {
+	return &grpcLogger{logger: logger, verbosity: verbosity}
+}
+
+// SetGRPCLogger routes gRPC's internal logs to logger. See NewGRPCLogger.
+//
+// Like grpclog.SetLoggerV2, it must be called before any gRPC functions, e.g. in an init function.
+//
+// Example:
+//
+//	loggergrpc.SetGRPCLogger(loggergo.Named("grpc"), 0)
+func SetGRPCLogger(logger *slog.Logger, verbosity int) {
+	grpclog.SetLoggerV2(NewGRPCLogger(logger, verbosity))
+}
+
+// log writes a gRPC log message at the given level.
+func (l *grpcLogger) log(level slog.Level, msg string) {
+	l.logger.Log(context.Background(), level, msg)
+}
+
+// Info logs to INFO log. Arguments are handled in the manner of fmt.Print.
+func (l *grpcLogger) Info(args ...any) { l.log(slog.LevelInfo, fmt.Sprint(args...)) }
+
+// Infoln logs to INFO log. Arguments are handled in the manner of fmt.Println.
+func (l *grpcLogger) Infoln(args ...any) { l.log(slog.LevelInfo, sprintln(args...)) }
+
+// Infof logs to INFO log. Arguments are handled in the manner of fmt.Printf.
+func (l *grpcLogger) Infof(format string, args ...any) {
+	l.log(slog.LevelInfo, fmt.Sprintf(format, args...))
+}
+
+// Warning logs to WARNING log. Arguments are handled in the manner of fmt.Print.
+func (l *grpcLogger) Warning(args ...any) { l.log(slog.LevelWarn, fmt.Sprint(args...)) }
+
+// Warningln logs to WARNING log. Arguments are handled in the manner of fmt.Println.
+func (l *grpcLogger) Warningln(args ...any) { l.log(slog.LevelWarn, sprintln(args...)) }
+
+// Warningf logs to WARNING log. Arguments are handled in the manner of fmt.Printf.
+func (l *grpcLogger) Warningf(format string, args ...any) {
+	l.log(slog.LevelWarn, fmt.Sprintf(format, args...))
+}
+
+// Error logs to ERROR log. Arguments are handled in the manner of fmt.Print.
+func (l *grpcLogger) Error(args ...any) { l.log(slog.LevelError, fmt.Sprint(args...)) }
+
+// Errorln logs to ERROR log. Arguments are handled in the manner of fmt.Println.
+func (l *grpcLogger) Errorln(args ...any) { l.log(slog.LevelError, sprintln(args...)) }
+
+// Errorf logs to ERROR log. Arguments are handled in the manner of fmt.Printf.
+func (l *grpcLogger) Errorf(format string, args ...any) {
+	l.log(slog.LevelError, fmt.Sprintf(format, args...))
+}
+
+// Fatal logs to ERROR log and exits. Arguments are handled in the manner of fmt.Print.
+func (l *grpcLogger) Fatal(args ...any) {
+	l.log(slog.LevelError, fmt.Sprint(args...))
+	os.Exit(1)
+}
+
+// Fatalln logs to ERROR log and exits. Arguments are handled in the manner of fmt.Println.
+func (l *grpcLogger) Fatalln(args ...any) {
+	l.log(slog.LevelError, sprintln(args...))
+	os.Exit(1)
+}
+
+// Fatalf logs to ERROR log and exits. Arguments are handled in the manner of fmt.Printf.
+func (l *grpcLogger) Fatalf(format string, args ...any) {
+	l.log(slog.LevelError, fmt.Sprintf(format, args...))
+	os.Exit(1)
+}
+
+// V reports whether verbosity level v is enabled.
+func (l *grpcLogger) V(v int) bool {
+	return v <= l.verbosity
+}
+
+// sprintln formats like fmt.Sprintln without the trailing newline.
+func sprintln(args ...any) string {
+	msg := fmt.Sprintln(args...)
+	return msg[:len(msg)-1]
+}
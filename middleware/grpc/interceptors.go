@@ -0,0 +1,363 @@
+// Package grpc provides gRPC interceptors that copy request metadata into the context, where
+// loggergo's context handler picks it up, and log the start and end of each call.
+//
+// Add the context keys of Config.MetadataKeys to Config.ContextKeys of loggergo so every record
+// logged while handling a call carries them:
+//
+//	ctx, logger, err := loggergo.Init(ctx, loggergo.Config{
+//	    ContextKeys: []interface{}{"request_id"},
+//	})
+//
+//	config := loggergrpc.Config{Logger: logger}
+//	server := grpc.NewServer(
+//	    grpc.ChainUnaryInterceptor(loggergrpc.NewUnaryServerInterceptor(config)),
+//	    grpc.ChainStreamInterceptor(loggergrpc.NewStreamServerInterceptor(config)),
+//	)
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// RequestIDKey is the default context key of the request ID, the same as in the middleware/http package.
+const RequestIDKey = "request_id"
+
+// RequestIDMetadataKey is the default metadata key of the request ID.
+const RequestIDMetadataKey = "x-request-id"
+
+// defaultCodeLevels are the levels of finished calls for codes missing from Config.CodeLevels.
+// Codes caused by the caller are logged at Warn, codes caused by the server at Error.
+var defaultCodeLevels = map[codes.Code]slog.Level{
+	codes.OK:                 slog.LevelInfo,
+	codes.Canceled:           slog.LevelWarn,
+	codes.InvalidArgument:    slog.LevelWarn,
+	codes.NotFound:           slog.LevelWarn,
+	codes.AlreadyExists:      slog.LevelWarn,
+	codes.PermissionDenied:   slog.LevelWarn,
+	codes.ResourceExhausted:  slog.LevelWarn,
+	codes.FailedPrecondition: slog.LevelWarn,
+	codes.Aborted:            slog.LevelWarn,
+	codes.OutOfRange:         slog.LevelWarn,
+	codes.Unauthenticated:    slog.LevelWarn,
+}
+
+// Config represents the configuration of the interceptors.
+//
+// All fields are optional.
+type Config struct {
+	Logger       *slog.Logger              // Logger writes the call records. Default: slog.Default() at call time.
+	MetadataKeys map[string]any            // MetadataKeys maps metadata keys to context keys. Server interceptors copy incoming metadata into the context; client interceptors copy context values into outgoing metadata. Default: x-request-id to RequestIDKey.
+	CodeLevels   map[codes.Code]slog.Level // CodeLevels maps status codes to the level of the "grpc call finished" record. Default: Info for OK, Warn for caller errors, Error otherwise.
+	LogPayloads  bool                      // LogPayloads logs every message sent and received at Debug level, with protobuf fields as attributes so that redaction rules apply to them.
+}
+
+// withDefaults returns the config with defaults applied.
+func (c Config) withDefaults() Config {
+	if c.MetadataKeys == nil {
+		c.MetadataKeys = map[string]any{RequestIDMetadataKey: RequestIDKey}
+	}
+	return c
+}
+
+// logger returns the configured logger or slog.Default().
+func (c Config) logger() *slog.Logger {
+	if c.Logger != nil {
+		return c.Logger
+	}
+	return slog.Default()
+}
+
+// level returns the level of a finished call with the given code.
+func (c Config) level(code codes.Code) slog.Level {
+	if level, ok := c.CodeLevels[code]; ok {
+		return level
+	}
+	if level, ok := defaultCodeLevels[code]; ok {
+		return level
+	}
+	return slog.LevelError
+}
+
+// NewUnaryServerInterceptor returns an interceptor that copies the incoming metadata listed in
+// MetadataKeys into the context and logs the start and end of each unary call.
+//
+// "grpc call started" is logged at Debug level with the method and peer. "grpc call finished"
+// adds the status code, duration and error, at the level CodeLevels gives the code.
+func NewUnaryServerInterceptor(config Config) grpc.UnaryServerInterceptor {
+	config = config.withDefaults()
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx = config.contextFromMetadata(ctx)
+		call := config.start(ctx, "server", info.FullMethod)
+
+		call.payload(ctx, "grpc message received", req)
+		resp, err := handler(ctx, req)
+		if err == nil {
+			call.payload(ctx, "grpc message sent", resp)
+		}
+
+		call.finish(ctx, err)
+		return resp, err
+	}
+}
+
+// NewStreamServerInterceptor returns an interceptor that copies the incoming metadata listed in
+// MetadataKeys into the stream's context and logs the start and end of each streaming call.
+// See NewUnaryServerInterceptor for the records.
+func NewStreamServerInterceptor(config Config) grpc.StreamServerInterceptor {
+	config = config.withDefaults()
+
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := config.contextFromMetadata(stream.Context())
+		call := config.start(ctx, "server", info.FullMethod)
+
+		err := handler(srv, &serverStream{ServerStream: stream, ctx: ctx, call: call})
+
+		call.finish(ctx, err)
+		return err
+	}
+}
+
+// NewUnaryClientInterceptor returns an interceptor that copies the context values listed in
+// MetadataKeys into the outgoing metadata and logs the start and end of each unary call.
+// See NewUnaryServerInterceptor for the records.
+func NewUnaryClientInterceptor(config Config) grpc.UnaryClientInterceptor {
+	config = config.withDefaults()
+
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx = config.metadataFromContext(ctx)
+		call := config.start(ctx, "client", method)
+
+		call.payload(ctx, "grpc message sent", req)
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil {
+			call.payload(ctx, "grpc message received", reply)
+		}
+
+		call.finish(ctx, err)
+		return err
+	}
+}
+
+// NewStreamClientInterceptor returns an interceptor that copies the context values listed in
+// MetadataKeys into the outgoing metadata and logs the start and end of each streaming call.
+// The call is finished when the stream fails to open or the first error (including io.EOF)
+// is returned by RecvMsg, or, for calls whose server does not stream (client-streaming calls),
+// when the response is received.
+func NewStreamClientInterceptor(config Config) grpc.StreamClientInterceptor {
+	config = config.withDefaults()
+
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx = config.metadataFromContext(ctx)
+		call := config.start(ctx, "client", method)
+
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			call.finish(ctx, err)
+			return nil, err
+		}
+
+		return &clientStream{ClientStream: stream, ctx: ctx, call: call, serverStreams: desc.ServerStreams}, nil
+	}
+}
+
+// contextFromMetadata copies the incoming metadata listed in MetadataKeys into the context.
+func (c Config) contextFromMetadata(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	for mdKey, ctxKey := range c.MetadataKeys {
+		if values := md.Get(mdKey); len(values) > 0 {
+			ctx = context.WithValue(ctx, ctxKey, values[0])
+		}
+	}
+	return ctx
+}
+
+// metadataFromContext copies the context values listed in MetadataKeys into the outgoing
+// metadata, unless the metadata already has the key.
+func (c Config) metadataFromContext(ctx context.Context) context.Context {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	for mdKey, ctxKey := range c.MetadataKeys {
+		if value := ctx.Value(ctxKey); value != nil && len(md.Get(mdKey)) == 0 {
+			ctx = metadata.AppendToOutgoingContext(ctx, mdKey, fmt.Sprint(value))
+		}
+	}
+	return ctx
+}
+
+// call is a call being logged.
+type call struct {
+	config Config
+	logger *slog.Logger
+	attrs  []slog.Attr
+	start  time.Time
+}
+
+// start logs the start of a call and returns it.
+func (c Config) start(ctx context.Context, kind, method string) *call {
+	attrs := []slog.Attr{slog.String("kind", kind), slog.String("method", method)}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		attrs = append(attrs, slog.String("peer", p.Addr.String()))
+	}
+
+	cl := &call{config: c, logger: c.logger(), attrs: attrs, start: time.Now()}
+	cl.logger.LogAttrs(ctx, slog.LevelDebug, "grpc call started", attrs...)
+	return cl
+}
+
+// finish logs the end of the call.
+func (cl *call) finish(ctx context.Context, err error) {
+	code := status.Code(err)
+	level := cl.config.level(code)
+	if !cl.logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := slices.Concat(cl.attrs, []slog.Attr{
+		slog.String("code", code.String()),
+		slog.Duration("duration", time.Since(cl.start)),
+	})
+	if err != nil {
+		attrs = append(attrs, slog.String("error", status.Convert(err).Message()))
+	}
+
+	cl.logger.LogAttrs(ctx, level, "grpc call finished", attrs...)
+}
+
+// payload logs a message sent or received, if LogPayloads is enabled.
+func (cl *call) payload(ctx context.Context, msg string, payload any) {
+	if !cl.config.LogPayloads || !cl.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	cl.logger.LogAttrs(ctx, slog.LevelDebug, msg, slices.Concat(cl.attrs, []slog.Attr{payloadAttr(payload)})...)
+}
+
+// payloadAttr returns a "payload" group with the fields of a protobuf message, so that the
+// logger's redaction rules apply to each field. Other values are logged as they are.
+func payloadAttr(payload any) slog.Attr {
+	message, ok := payload.(proto.Message)
+	if !ok {
+		return slog.Any("payload", payload)
+	}
+
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(message)
+	if err != nil {
+		return slog.String("payload", fmt.Sprintf("<unable to marshal %T: %v>", payload, err))
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return slog.String("payload", string(data))
+	}
+	return slog.Attr{Key: "payload", Value: jsonValue(fields)}
+}
+
+// jsonValue converts decoded JSON to a slog.Value, with objects as groups.
+func jsonValue(value any) slog.Value {
+	switch v := value.(type) {
+	case map[string]any:
+		attrs := make([]slog.Attr, 0, len(v))
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			attrs = append(attrs, slog.Attr{Key: key, Value: jsonValue(v[key])})
+		}
+		return slog.GroupValue(attrs...)
+	case []any:
+		values := make([]any, len(v))
+		for i, item := range v {
+			values[i] = jsonValue(item).Resolve().Any()
+		}
+		return slog.AnyValue(values)
+	default:
+		return slog.AnyValue(v)
+	}
+}
+
+// serverStream is a grpc.ServerStream with the interceptor's context, logging payloads.
+type serverStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	call *call
+}
+
+// Context returns the context with the copied metadata.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// RecvMsg receives a message and logs it.
+func (s *serverStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.call.payload(s.ctx, "grpc message received", m)
+	}
+	return err
+}
+
+// SendMsg sends a message and logs it.
+func (s *serverStream) SendMsg(m any) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.call.payload(s.ctx, "grpc message sent", m)
+	}
+	return err
+}
+
+// clientStream is a grpc.ClientStream that logs payloads and the end of the call.
+type clientStream struct {
+	grpc.ClientStream
+	ctx           context.Context
+	call          *call
+	serverStreams bool // false if the server sends a single response, which ends the call
+
+	finished bool
+}
+
+// SendMsg sends a message and logs it.
+func (s *clientStream) SendMsg(m any) error {
+	err := s.ClientStream.SendMsg(m)
+	if err == nil {
+		s.call.payload(s.ctx, "grpc message sent", m)
+	}
+	return err
+}
+
+// RecvMsg receives a message and logs it, finishing the call on the first error, or on the
+// response if the server does not stream. io.EOF marks a successful end of the stream.
+func (s *clientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == nil {
+		s.call.payload(s.ctx, "grpc message received", m)
+		if !s.serverStreams && !s.finished {
+			s.finished = true
+			s.call.finish(s.ctx, nil)
+		}
+		return nil
+	}
+
+	if !s.finished {
+		s.finished = true
+		if err == io.EOF {
+			s.call.finish(s.ctx, nil)
+		} else {
+			s.call.finish(s.ctx, err)
+		}
+	}
+	return err
+}
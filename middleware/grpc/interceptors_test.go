@@ -0,0 +1,269 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/wasilak/loggergo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// lockedBuffer is a bytes.Buffer safe for the concurrent writes of server and client
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// records decodes the JSON records written so far
+func (b *lockedBuffer) records(t *testing.T) []map[string]any {
+	t.Helper()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(b.buf.String()), "\n") {
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("Invalid JSON line %q: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// find returns the records with the given message and kind
+func find(records []map[string]any, msg, kind string) []map[string]any {
+	var found []map[string]any
+	for _, record := range records {
+		if record["msg"] == msg && record["kind"] == kind {
+			found = append(found, record)
+		}
+	}
+	return found
+}
+
+// startServer serves the health service with the interceptors over bufconn and returns a connected client
+func startServer(t *testing.T, config Config) healthpb.HealthClient {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(NewUnaryServerInterceptor(config)),
+		grpc.ChainStreamInterceptor(NewStreamServerInterceptor(config)),
+	)
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("orders", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(NewUnaryClientInterceptor(config)),
+		grpc.WithChainStreamInterceptor(NewStreamClientInterceptor(config)),
+	)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return healthpb.NewHealthClient(conn)
+}
+
+// newTestLogger returns a loggergo logger that adds the request ID from the context and redacts "service"
+func newTestLogger(t *testing.T, buf *lockedBuffer) *slog.Logger {
+	t.Helper()
+	logger, err := loggergo.New(context.Background(), loggergo.Config{
+		Level:        slog.LevelDebug,
+		OutputStream: buf,
+		SetAsDefault: false,
+		ContextKeys:  []interface{}{RequestIDKey},
+		Redaction:    loggergo.RedactionConfig{Rules: []loggergo.RedactionRule{{Keys: []string{"service"}}}},
+	})
+	if err != nil {
+		t.Fatalf("loggergo.New failed: %v", err)
+	}
+	t.Cleanup(func() { logger.Shutdown() })
+	return logger.Logger
+}
+
+// TestUnaryInterceptors tests metadata propagation and call records of unary calls
+func TestUnaryInterceptors(t *testing.T) {
+	var buf lockedBuffer
+	client := startServer(t, Config{Logger: newTestLogger(t, &buf), LogPayloads: true})
+
+	ctx := context.WithValue(context.Background(), RequestIDKey, "req-123")
+	if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "orders"}); err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	_, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("Expected NotFound, got %v", err)
+	}
+
+	records := buf.records(t)
+
+	finished := find(records, "grpc call finished", "server")
+	if len(finished) != 2 {
+		t.Fatalf("Expected 2 finished server calls, got %d:\n%v", len(finished), records)
+	}
+	if finished[0]["method"] != "/grpc.health.v1.Health/Check" || finished[0]["code"] != "OK" || finished[0]["level"] != "INFO" {
+		t.Errorf("Unexpected record of successful call: %v", finished[0])
+	}
+	if finished[1]["code"] != "NotFound" || finished[1]["level"] != "WARN" || finished[1]["error"] != "unknown service" {
+		t.Errorf("Unexpected record of failed call: %v", finished[1])
+	}
+	if _, ok := finished[0]["duration"]; !ok {
+		t.Errorf("Expected duration in %v", finished[0])
+	}
+
+	// The request ID travels from the client context through metadata into the server context
+	for _, record := range records {
+		if record["request_id"] != "req-123" {
+			t.Errorf("Expected request ID in every record, got %v", record)
+		}
+	}
+
+	received := find(records, "grpc message received", "server")
+	if len(received) != 2 || received[0]["payload"].(map[string]any)["service"] != "[REDACTED]" {
+		t.Errorf("Expected redacted payloads, got %v", received)
+	}
+	sent := find(records, "grpc message sent", "server")
+	if len(sent) != 1 || sent[0]["payload"].(map[string]any)["status"] != "SERVING" {
+		t.Errorf("Expected response payload, got %v", sent)
+	}
+
+	if len(find(records, "grpc call started", "client")) != 2 || len(find(records, "grpc call finished", "client")) != 2 {
+		t.Errorf("Expected client records for both calls, got %v", records)
+	}
+}
+
+// TestStreamInterceptors tests metadata propagation and call records of streaming calls
+func TestStreamInterceptors(t *testing.T) {
+	var buf lockedBuffer
+	client := startServer(t, Config{
+		Logger:       newTestLogger(t, &buf),
+		LogPayloads:  true,
+		MetadataKeys: map[string]any{"x-request-id": RequestIDKey},
+		CodeLevels:   map[codes.Code]slog.Level{codes.Canceled: slog.LevelDebug},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ctx = metadata.AppendToOutgoingContext(ctx, "x-request-id", "from-metadata")
+
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "orders"})
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	response, err := stream.Recv()
+	if err != nil || response.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("Expected SERVING, got %v, %v", response, err)
+	}
+	cancel()
+	if _, err := stream.Recv(); status.Code(err) != codes.Canceled {
+		t.Fatalf("Expected Canceled, got %v", err)
+	}
+
+	finished := find(buf.records(t), "grpc call finished", "client")
+	if len(finished) != 1 || finished[0]["code"] != "Canceled" || finished[0]["level"] != "DEBUG" {
+		t.Errorf("Expected Canceled client call at DEBUG, got %v", finished)
+	}
+
+	received := find(buf.records(t), "grpc message received", "server")
+	if len(received) != 1 || received[0]["request_id"] != "from-metadata" {
+		t.Errorf("Expected request ID from metadata in server records, got %v", received)
+	}
+}
+
+// fakeClientStream is a client-streaming call whose server answers with a single response
+type fakeClientStream struct {
+	grpc.ClientStream
+	sent int
+}
+
+func (s *fakeClientStream) SendMsg(m any) error { s.sent++; return nil }
+func (s *fakeClientStream) CloseSend() error    { return nil }
+
+func (s *fakeClientStream) RecvMsg(m any) error {
+	m.(*healthpb.HealthCheckResponse).Status = healthpb.HealthCheckResponse_SERVING
+	return nil
+}
+
+// TestStreamClientInterceptor_ClientStreaming tests that a client-streaming call is finished when its response is received
+func TestStreamClientInterceptor_ClientStreaming(t *testing.T) {
+	var buf lockedBuffer
+	interceptor := NewStreamClientInterceptor(Config{Logger: newTestLogger(t, &buf), LogPayloads: true})
+
+	fake := &fakeClientStream{}
+	desc := &grpc.StreamDesc{StreamName: "Upload", ClientStreams: true}
+	stream, err := interceptor(context.Background(), desc, nil, "/test.Uploader/Upload",
+		func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return fake, nil
+		})
+	if err != nil {
+		t.Fatalf("Interceptor failed: %v", err)
+	}
+
+	// What the generated CloseAndRecv does after the requests are sent
+	for _, service := range []string{"orders", "billing"} {
+		if err := stream.SendMsg(&healthpb.HealthCheckRequest{Service: service}); err != nil {
+			t.Fatalf("SendMsg failed: %v", err)
+		}
+	}
+	stream.CloseSend()
+	var response healthpb.HealthCheckResponse
+	if err := stream.RecvMsg(&response); err != nil {
+		t.Fatalf("RecvMsg failed: %v", err)
+	}
+
+	records := buf.records(t)
+	finished := find(records, "grpc call finished", "client")
+	if len(finished) != 1 || finished[0]["method"] != "/test.Uploader/Upload" || finished[0]["code"] != "OK" {
+		t.Errorf("Expected the call to be finished with OK, got %v", records)
+	}
+	if fake.sent != 2 || len(find(records, "grpc message sent", "client")) != 2 {
+		t.Errorf("Expected 2 logged requests, got %v", records)
+	}
+}
+
+// TestGRPCLogger tests that gRPC's logs are written at the corresponding levels
+func TestGRPCLogger(t *testing.T) {
+	var buf lockedBuffer
+	logger := NewGRPCLogger(slog.New(slog.NewJSONHandler(&buf, nil)), 2)
+
+	logger.Infof("connecting to %s", "server")
+	logger.Warningln("slow", "response")
+	logger.Error("failed")
+
+	records := buf.records(t)
+	want := []struct{ level, msg string }{{"INFO", "connecting to server"}, {"WARN", "slow response"}, {"ERROR", "failed"}}
+	for i, w := range want {
+		if records[i]["level"] != w.level || records[i]["msg"] != w.msg {
+			t.Errorf("Record %d: expected %s %q, got %v", i, w.level, w.msg, records[i])
+		}
+	}
+
+	if !logger.V(2) || logger.V(3) {
+		t.Error("Expected verbosity up to 2 to be enabled")
+	}
+}
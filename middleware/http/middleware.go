@@ -0,0 +1,338 @@
+// Package http provides net/http middleware that puts request details into the context,
+// where loggergo's context handler picks them up, and writes one access-log record per request.
+//
+// Add the middleware's context keys to Config.ContextKeys so every record logged while
+// handling a request carries its request ID, remote IP, method and route:
+//
+//	ctx, logger, err := loggergo.Init(ctx, loggergo.Config{
+//	    ContextKeys: loggerhttp.ContextKeys(),
+//	})
+//
+//	handler := loggerhttp.NewMiddleware(loggerhttp.Config{Logger: logger})(mux)
+//	http.ListenAndServe(":8080", handler)
+package http
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Default context keys under which the middleware stores request details.
+// They are strings so they can be listed in configuration files and environment variables.
+const (
+	RequestIDKey = "request_id"
+	RemoteIPKey  = "remote_ip"
+	MethodKey    = "method"
+	RouteKey     = "route"
+)
+
+// RequestIDHeader is the default header a request ID is read from and written to.
+const RequestIDHeader = "X-Request-ID"
+
+// traceparentHeader is the W3C Trace Context header a request ID is taken from if there is no RequestIDHeader.
+const traceparentHeader = "traceparent"
+
+// maxRequestIDLength is the maximum length of a request ID taken from the RequestIDHeader.
+const maxRequestIDLength = 128
+
+// defaultStatusLevels are the access-log levels used for status classes missing from Config.StatusLevels.
+var defaultStatusLevels = map[int]slog.Level{
+	1: slog.LevelInfo,
+	2: slog.LevelInfo,
+	3: slog.LevelInfo,
+	4: slog.LevelWarn,
+	5: slog.LevelError,
+}
+
+// Config represents the configuration of the middleware.
+//
+// All fields are optional.
+type Config struct {
+	Logger            *slog.Logger               // Logger writes the access-log records. Default: slog.Default() at request time.
+	Message           string                     // Message is the message of access-log records. Default: "http request".
+	StatusLevels      map[int]slog.Level         // StatusLevels maps status classes (2 for 2xx, 4 for 4xx, ...) to access-log levels. Default: Info for 1xx-3xx, Warn for 4xx, Error for 5xx.
+	DisableAccessLog  bool                       // DisableAccessLog only populates the context, without writing access-log records.
+	RequestIDKey      any                        // RequestIDKey is the context key of the request ID. Default: RequestIDKey.
+	RemoteIPKey       any                        // RemoteIPKey is the context key of the client IP. Default: RemoteIPKey.
+	MethodKey         any                        // MethodKey is the context key of the request method. Default: MethodKey.
+	RouteKey          any                        // RouteKey is the context key of the matched route. Default: RouteKey.
+	RequestIDHeader   string                     // RequestIDHeader is the header the request ID is read from and echoed in. Default: RequestIDHeader.
+	GenerateRequestID func() string              // GenerateRequestID creates request IDs when the request has none. Default: 32 random hex digits.
+	Route             func(*http.Request) string // Route returns the route of a request. Default: the http.ServeMux pattern (Request.Pattern).
+	TrustProxyHeaders bool                       // TrustProxyHeaders takes the client IP from X-Forwarded-For or X-Real-IP. Enable only behind a trusted proxy.
+}
+
+// ContextKeys returns the default context keys of the middleware, for use in Config.ContextKeys of loggergo.
+func ContextKeys() []interface{} {
+	return []interface{}{RequestIDKey, RemoteIPKey, MethodKey, RouteKey}
+}
+
+// NewMiddleware returns middleware that stores the request ID, client IP, method and route of
+// each request in its context and writes an access-log record when the request completes.
+//
+// The request ID is taken from the RequestIDHeader, then from the trace ID of a W3C traceparent
+// header, and is generated otherwise. A RequestIDHeader longer than 128 characters or with
+// characters other than printable ASCII is ignored, so clients cannot inject arbitrary data
+// into logs. The request ID is echoed in the response's RequestIDHeader.
+//
+// The ResponseWriter passed to the next handler implements http.Flusher and http.Hijacker
+// only if the one of the server does.
+//
+// The access-log record has "status", "bytes" and "latency" attributes and is logged with the
+// request's context, so the context values above are added by loggergo's context handler. Its
+// level is chosen from StatusLevels by the status class.
+//
+// The route is the http.ServeMux pattern. When the middleware wraps the whole mux, the pattern
+// is only known once the mux has routed the request: the context then has no route, but the
+// access-log record has a "route" attribute. Wrapping each registered handler puts the route
+// in the context as well.
+//
+// Example:
+//
+//	mux := http.NewServeMux()
+//	mux.HandleFunc("GET /users/{id}", getUser)
+//
+//	middleware := loggerhttp.NewMiddleware(loggerhttp.Config{
+//	    StatusLevels: map[int]slog.Level{2: slog.LevelDebug},
+//	})
+//	http.ListenAndServe(":8080", middleware(mux))
+func NewMiddleware(config Config) func(http.Handler) http.Handler {
+	if config.Message == "" {
+		config.Message = "http request"
+	}
+	if config.RequestIDKey == nil {
+		config.RequestIDKey = RequestIDKey
+	}
+	if config.RemoteIPKey == nil {
+		config.RemoteIPKey = RemoteIPKey
+	}
+	if config.MethodKey == nil {
+		config.MethodKey = MethodKey
+	}
+	if config.RouteKey == nil {
+		config.RouteKey = RouteKey
+	}
+	if config.RequestIDHeader == "" {
+		config.RequestIDHeader = RequestIDHeader
+	}
+	if config.GenerateRequestID == nil {
+		config.GenerateRequestID = generateRequestID
+	}
+	if config.Route == nil {
+		config.Route = func(r *http.Request) string { return r.Pattern }
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			requestID := requestIDFromHeaders(r, config.RequestIDHeader)
+			if requestID == "" {
+				requestID = config.GenerateRequestID()
+			}
+			w.Header().Set(config.RequestIDHeader, requestID)
+
+			ctx := r.Context()
+			ctx = context.WithValue(ctx, config.RequestIDKey, requestID)
+			ctx = context.WithValue(ctx, config.RemoteIPKey, remoteIP(r, config.TrustProxyHeaders))
+			ctx = context.WithValue(ctx, config.MethodKey, r.Method)
+			if route := config.Route(r); route != "" {
+				ctx = context.WithValue(ctx, config.RouteKey, route)
+			}
+			r = r.WithContext(ctx)
+
+			recorder := &responseRecorder{ResponseWriter: w}
+			next.ServeHTTP(recorder.wrap(), r)
+
+			if config.DisableAccessLog {
+				return
+			}
+
+			logger := config.Logger
+			if logger == nil {
+				logger = slog.Default()
+			}
+
+			status := recorder.statusCode()
+			level, ok := config.StatusLevels[status/100]
+			if !ok {
+				level = defaultStatusLevels[status/100]
+			}
+			if !logger.Enabled(ctx, level) {
+				return
+			}
+
+			attrs := []slog.Attr{
+				slog.Int("status", status),
+				slog.Int64("bytes", recorder.bytes),
+				slog.Duration("latency", time.Since(start)),
+			}
+			// The mux sets the pattern on the request it was given, so it is known now even if it wasn't before
+			if ctx.Value(config.RouteKey) == nil {
+				if route := config.Route(r); route != "" {
+					attrs = append(attrs, slog.String(fmt.Sprintf("%v", config.RouteKey), route))
+				}
+			}
+
+			logger.LogAttrs(ctx, level, config.Message, attrs...)
+		})
+	}
+}
+
+// requestIDFromHeaders returns the request ID from the given header, if it is valid, or the trace ID of the traceparent header.
+func requestIDFromHeaders(r *http.Request, header string) string {
+	if id := strings.TrimSpace(r.Header.Get(header)); validRequestID(id) {
+		return id
+	}
+
+	// traceparent is version-traceid-parentid-flags, e.g. 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+	parts := strings.Split(r.Header.Get(traceparentHeader), "-")
+	if len(parts) < 4 || len(parts[1]) != 32 || parts[1] == strings.Repeat("0", 32) {
+		return ""
+	}
+	if _, err := hex.DecodeString(parts[1]); err != nil {
+		return ""
+	}
+	return strings.ToLower(parts[1])
+}
+
+// validRequestID reports whether id is a non-empty request ID of at most maxRequestIDLength printable ASCII characters.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// generateRequestID returns 16 random bytes as hex, the format of a trace ID.
+func generateRequestID() string {
+	var id [16]byte
+	rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
+
+// remoteIP returns the client IP of the request, optionally taken from proxy headers.
+func remoteIP(r *http.Request, trustProxyHeaders bool) string {
+	if trustProxyHeaders {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			// The first address is the client; proxies append their own
+			client, _, _ := strings.Cut(forwarded, ",")
+			return strings.TrimSpace(client)
+		}
+		if realIP := strings.TrimSpace(r.Header.Get("X-Real-IP")); realIP != "" {
+			return realIP
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// responseRecorder records the status code and body size of a response.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+// WriteHeader records the status code and writes it to the wrapped ResponseWriter.
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write counts the bytes written to the wrapped ResponseWriter.
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += int64(n)
+	return n, err
+}
+
+// wrap returns r as a ResponseWriter that implements http.Flusher and http.Hijacker only if
+// the wrapped ResponseWriter does, so handlers can rely on type assertions.
+func (r *responseRecorder) wrap() http.ResponseWriter {
+	_, flusher := r.ResponseWriter.(http.Flusher)
+	_, hijacker := r.ResponseWriter.(http.Hijacker)
+	switch {
+	case flusher && hijacker:
+		return flushHijackRecorder{r}
+	case flusher:
+		return flushRecorder{r}
+	case hijacker:
+		return hijackRecorder{r}
+	}
+	return r
+}
+
+// flush records the implicit 200 status and flushes the wrapped ResponseWriter, which must implement http.Flusher.
+func (r *responseRecorder) flush() {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	r.ResponseWriter.(http.Flusher).Flush()
+}
+
+// hijack takes over the connection of the wrapped ResponseWriter, which must implement http.Hijacker.
+// The status is recorded as 101 if the handler wrote none, as hijacking usually switches protocols.
+func (r *responseRecorder) hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := r.ResponseWriter.(http.Hijacker).Hijack()
+	if err == nil && r.status == 0 {
+		r.status = http.StatusSwitchingProtocols
+	}
+	return conn, rw, err
+}
+
+// Unwrap returns the wrapped ResponseWriter, for use by http.ResponseController.
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// flushRecorder is a responseRecorder whose wrapped ResponseWriter implements http.Flusher.
+type flushRecorder struct{ *responseRecorder }
+
+// Flush flushes the wrapped ResponseWriter.
+func (r flushRecorder) Flush() { r.flush() }
+
+// hijackRecorder is a responseRecorder whose wrapped ResponseWriter implements http.Hijacker.
+type hijackRecorder struct{ *responseRecorder }
+
+// Hijack takes over the connection of the wrapped ResponseWriter.
+func (r hijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) { return r.hijack() }
+
+// flushHijackRecorder is a responseRecorder whose wrapped ResponseWriter implements http.Flusher and http.Hijacker.
+type flushHijackRecorder struct{ *responseRecorder }
+
+// Flush flushes the wrapped ResponseWriter.
+func (r flushHijackRecorder) Flush() { r.flush() }
+
+// Hijack takes over the connection of the wrapped ResponseWriter.
+func (r flushHijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) { return r.hijack() }
+
+// statusCode returns the recorded status code, which is 200 if the handler wrote nothing.
+func (r *responseRecorder) statusCode() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
@@ -0,0 +1,242 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/wasilak/loggergo"
+)
+
+// newTestLogger returns a loggergo logger with the middleware's context keys writing JSON to buf
+func newTestLogger(t *testing.T, buf *bytes.Buffer) *slog.Logger {
+	t.Helper()
+	logger, err := loggergo.New(context.Background(), loggergo.Config{
+		Level:        slog.LevelDebug,
+		OutputStream: buf,
+		SetAsDefault: false,
+		ContextKeys:  ContextKeys(),
+	})
+	if err != nil {
+		t.Fatalf("loggergo.New failed: %v", err)
+	}
+	t.Cleanup(func() { logger.Shutdown() })
+	return logger.Logger
+}
+
+// decodeLines decodes the JSON records in buf
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("Invalid JSON line %q: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// TestMiddleware tests that handler records carry the request details and an access record is written
+func TestMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newTestLogger(t, &buf)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		logger.InfoContext(r.Context(), "loading user")
+		w.Write([]byte("hello"))
+	})
+
+	handler := NewMiddleware(Config{Logger: logger})(mux)
+
+	request := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	request.RemoteAddr = "192.0.2.1:54321"
+	request.Header.Set("X-Request-ID", "req-123")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	if recorder.Header().Get("X-Request-ID") != "req-123" {
+		t.Errorf("Expected request ID to be echoed, got %q", recorder.Header().Get("X-Request-ID"))
+	}
+
+	records := decodeLines(t, &buf)
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d: %s", len(records), buf.String())
+	}
+
+	inner, access := records[0], records[1]
+	if inner["request_id"] != "req-123" || inner["remote_ip"] != "192.0.2.1" || inner["method"] != "GET" {
+		t.Errorf("Expected request details in handler record, got %v", inner)
+	}
+	if access["msg"] != "http request" || access["level"] != "INFO" || access["status"] != 200.0 || access["bytes"] != 5.0 {
+		t.Errorf("Unexpected access record: %v", access)
+	}
+	if access["route"] != "GET /users/{id}" || access["request_id"] != "req-123" {
+		t.Errorf("Expected route and request ID in access record, got %v", access)
+	}
+	if _, ok := access["latency"]; !ok {
+		t.Errorf("Expected latency in access record, got %v", access)
+	}
+}
+
+// TestMiddleware_RequestID tests where the request ID comes from
+func TestMiddleware_RequestID(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    string
+	}{
+		{"header", map[string]string{"X-Request-ID": "abc"}, "abc"},
+		{"longest header", map[string]string{"X-Request-ID": strings.Repeat("a", 128)}, strings.Repeat("a", 128)},
+		{"too long header", map[string]string{"X-Request-ID": strings.Repeat("a", 129)}, "generated"},
+		{"non-printable header", map[string]string{"X-Request-ID": "abc\x1b[31m"}, "generated"},
+		{"non-ASCII header", map[string]string{"X-Request-ID": "abc\u00e9"}, "generated"},
+		{"invalid header with traceparent", map[string]string{"X-Request-ID": "abc\x00", "traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}, "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{"traceparent", map[string]string{"traceparent": "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01"}, "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{"invalid traceparent", map[string]string{"traceparent": "00-00000000000000000000000000000000-00f067aa0ba902b7-01"}, "generated"},
+		{"generated", nil, "generated"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got any
+			handler := NewMiddleware(Config{
+				DisableAccessLog:  true,
+				GenerateRequestID: func() string { return "generated" },
+			})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Context().Value(RequestIDKey)
+			}))
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			for key, value := range tt.headers {
+				request.Header.Set(key, value)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), request)
+
+			if got != tt.want {
+				t.Errorf("Expected request ID %q, got %v", tt.want, got)
+			}
+		})
+	}
+
+	if id := generateRequestID(); len(id) != 32 || id == generateRequestID() {
+		t.Errorf("Expected unique 32 digit request IDs, got %q", id)
+	}
+}
+
+// TestMiddleware_StatusLevels tests that the access-log level follows the status class
+func TestMiddleware_StatusLevels(t *testing.T) {
+	tests := []struct {
+		status int
+		want   string
+	}{
+		{http.StatusOK, "DEBUG"},
+		{http.StatusFound, "INFO"},
+		{http.StatusNotFound, "WARN"},
+		{http.StatusServiceUnavailable, "ERROR"},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		handler := NewMiddleware(Config{
+			Logger:       newTestLogger(t, &buf),
+			StatusLevels: map[int]slog.Level{2: slog.LevelDebug},
+		})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+		}))
+
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		records := decodeLines(t, &buf)
+		if records[0]["level"] != tt.want || records[0]["status"] != float64(tt.status) {
+			t.Errorf("Status %d: expected level %s, got %v", tt.status, tt.want, records[0])
+		}
+	}
+}
+
+// TestMiddleware_ProxyHeaders tests the client IP with and without trusted proxy headers
+func TestMiddleware_ProxyHeaders(t *testing.T) {
+	for _, trust := range []bool{false, true} {
+		var got any
+		handler := NewMiddleware(Config{DisableAccessLog: true, TrustProxyHeaders: trust})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = r.Context().Value(RemoteIPKey)
+		}))
+
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.RemoteAddr = "10.0.0.1:1234"
+		request.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.2")
+		handler.ServeHTTP(httptest.NewRecorder(), request)
+
+		want := "10.0.0.1"
+		if trust {
+			want = "203.0.113.7"
+		}
+		if got != want {
+			t.Errorf("TrustProxyHeaders=%v: expected %s, got %v", trust, want, got)
+		}
+	}
+}
+
+// plainWriter hides the optional interfaces of the embedded ResponseWriter
+type plainWriter struct{ http.ResponseWriter }
+
+// hijackWriter is a ResponseWriter without http.Flusher whose connection can be hijacked
+type hijackWriter struct {
+	plainWriter
+	conn net.Conn
+}
+
+func (w hijackWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return w.conn, bufio.NewReadWriter(bufio.NewReader(w.conn), bufio.NewWriter(w.conn)), nil
+}
+
+// TestMiddleware_ResponseWriterInterfaces tests that the handler sees http.Flusher and http.Hijacker only if the server's writer has them
+func TestMiddleware_ResponseWriterInterfaces(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	tests := []struct {
+		name              string
+		writer            http.ResponseWriter
+		flusher, hijacker bool
+		hijack            bool
+		wantStatus        float64
+	}{
+		{"plain", plainWriter{httptest.NewRecorder()}, false, false, false, 200},
+		{"flusher", httptest.NewRecorder(), true, false, false, 200},
+		{"hijacker", hijackWriter{plainWriter{httptest.NewRecorder()}, server}, false, true, true, 101},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			handler := NewMiddleware(Config{Logger: newTestLogger(t, &buf)})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, flusher := w.(http.Flusher)
+				hijacker, ok := w.(http.Hijacker)
+				if flusher != tt.flusher || ok != tt.hijacker {
+					t.Errorf("Expected Flusher %v and Hijacker %v, got %v and %v", tt.flusher, tt.hijacker, flusher, ok)
+				}
+				if tt.hijack {
+					if conn, _, err := hijacker.Hijack(); err != nil || conn != server {
+						t.Errorf("Expected the server's connection, got %v, %v", conn, err)
+					}
+				}
+			}))
+			handler.ServeHTTP(tt.writer, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if records := decodeLines(t, &buf); records[0]["status"] != tt.wantStatus {
+				t.Errorf("Expected status %v in the access record, got %v", tt.wantStatus, records[0])
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package loggergo
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/wasilak/loggergo/lib/types"
+)
+
+// defaultRedactionMask is the replacement used by RedactMask when RedactionConfig.Mask is empty.
+const defaultRedactionMask = "[REDACTED]"
+
+// redactionRule is a RedactionRule with its patterns prepared for matching.
+type redactionRule struct {
+	keys     []string
+	pattern  *regexp.Regexp
+	strategy types.RedactionStrategy
+}
+
+// redactor applies redaction rules to attributes.
+type redactor struct {
+	rules   []redactionRule
+	mask    string
+	hashKey []byte
+}
+
+// RedactionHandler wraps an existing slog.Handler and removes secrets and PII from attributes.
+//
+// Each attribute, including attributes inside groups, attributes added with WithAttrs and
+// values produced by slog.LogValuer, is checked against the configured rules in order.
+// Rules matching by key replace the whole value; rules with a ValuePattern replace the
+// matching parts of string values. The record message is not redacted.
+//
+// The RedactHash strategy replaces a value with "hmac:" followed by the first 16 bytes of its
+// HMAC-SHA256 under RedactionConfig.HashKey, hex-encoded, so equal values can still be joined.
+//
+// Thread Safety:
+//
+// RedactionHandler is safe for concurrent use.
+type RedactionHandler struct {
+	innerHandler slog.Handler
+	redactor     *redactor
+}
+
+// NewRedactionHandler creates a new RedactionHandler wrapping the given handler.
+// It returns an error if a key glob or value pattern is invalid.
+//
+// Example:
+//
+//	handler, err := loggergo.NewRedactionHandler(slog.NewJSONHandler(os.Stdout, nil), loggergo.RedactionConfig{
+//	    Rules: []loggergo.RedactionRule{
+//	        {Keys: []string{"password", "*token*"}, Strategy: loggergo.Types.RedactDrop},
+//	    },
+//	})
+func NewRedactionHandler(handler slog.Handler, config types.RedactionConfig) (*RedactionHandler, error) {
+	r := &redactor{
+		mask:    config.Mask,
+		hashKey: []byte(config.HashKey),
+	}
+	if r.mask == "" {
+		r.mask = defaultRedactionMask
+	}
+
+	for i, rule := range config.Rules {
+		compiled := redactionRule{strategy: rule.Strategy}
+		if compiled.strategy == (types.RedactionStrategy{}) {
+			compiled.strategy = types.RedactMask
+		}
+
+		for _, key := range rule.Keys {
+			key = strings.ToLower(key)
+			if _, err := path.Match(key, ""); err != nil {
+				return nil, fmt.Errorf("redaction rule %d: invalid key pattern %q: %w", i, key, err)
+			}
+			compiled.keys = append(compiled.keys, key)
+		}
+
+		if rule.ValuePattern != "" {
+			pattern, err := regexp.Compile(rule.ValuePattern)
+			if err != nil {
+				return nil, fmt.Errorf("redaction rule %d: invalid value pattern: %w", i, err)
+			}
+			compiled.pattern = pattern
+		}
+
+		r.rules = append(r.rules, compiled)
+	}
+
+	return &RedactionHandler{innerHandler: handler, redactor: r}, nil
+}
+
+// Enabled reports whether the handler handles records at the given level.
+// It delegates the check to the inner handler.
+func (h *RedactionHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.innerHandler.Enabled(ctx, level)
+}
+
+// Handle redacts the record's attributes and passes it to the inner handler.
+func (h *RedactionHandler) Handle(ctx context.Context, record slog.Record) error {
+	redacted := slog.NewRecord(record.Time, record.Level, record.Message, record.PC)
+	record.Attrs(func(a slog.Attr) bool {
+		if a, ok := h.redactor.redact(a); ok {
+			redacted.AddAttrs(a)
+		}
+		return true
+	})
+	return h.innerHandler.Handle(ctx, redacted)
+}
+
+// WithAttrs returns a new handler with the given attributes redacted and added.
+func (h *RedactionHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		if a, ok := h.redactor.redact(a); ok {
+			redacted = append(redacted, a)
+		}
+	}
+	return &RedactionHandler{innerHandler: h.innerHandler.WithAttrs(redacted), redactor: h.redactor}
+}
+
+// WithGroup returns a new handler with the given group name.
+func (h *RedactionHandler) WithGroup(name string) slog.Handler {
+	return &RedactionHandler{innerHandler: h.innerHandler.WithGroup(name), redactor: h.redactor}
+}
+
+// redact applies the rules to the attribute, recursing into groups.
+// It returns false if the attribute must be dropped.
+func (r *redactor) redact(attr slog.Attr) (slog.Attr, bool) {
+	attr.Value = attr.Value.Resolve()
+	key := strings.ToLower(attr.Key)
+
+	// Rules matching by key alone replace the whole value, groups included
+	for _, rule := range r.rules {
+		if rule.pattern != nil || !rule.matchesKey(key) {
+			continue
+		}
+		switch rule.strategy {
+		case types.RedactDrop:
+			return attr, false
+		case types.RedactHash:
+			attr.Value = slog.StringValue(r.hash(attr.Value.String()))
+		default:
+			attr.Value = slog.StringValue(r.mask)
+		}
+		return attr, true
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		group := attr.Value.Group()
+		redacted := make([]slog.Attr, 0, len(group))
+		for _, a := range group {
+			if a, ok := r.redact(a); ok {
+				redacted = append(redacted, a)
+			}
+		}
+		attr.Value = slog.GroupValue(redacted...)
+		return attr, true
+	}
+
+	if attr.Value.Kind() != slog.KindString && attr.Value.Kind() != slog.KindAny {
+		return attr, true
+	}
+
+	// Rules matching by value replace the matching parts of the value's string form
+	value := attr.Value.String()
+	matched := false
+	for _, rule := range r.rules {
+		if rule.pattern == nil || (len(rule.keys) > 0 && !rule.matchesKey(key)) || !rule.pattern.MatchString(value) {
+			continue
+		}
+		matched = true
+		switch rule.strategy {
+		case types.RedactDrop:
+			return attr, false
+		case types.RedactHash:
+			value = rule.pattern.ReplaceAllStringFunc(value, r.hash)
+		default:
+			value = rule.pattern.ReplaceAllLiteralString(value, r.mask)
+		}
+	}
+	if matched {
+		attr.Value = slog.StringValue(value)
+	}
+
+	return attr, true
+}
+
+// hash returns the keyed hash used by RedactHash.
+func (r *redactor) hash(value string) string {
+	mac := hmac.New(sha256.New, r.hashKey)
+	mac.Write([]byte(value))
+	return "hmac:" + hex.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// matchesKey reports whether the lowercased key matches any of the rule's key patterns.
+func (rule *redactionRule) matchesKey(key string) bool {
+	for _, pattern := range rule.keys {
+		if ok, _ := path.Match(pattern, key); ok {
+			return true
+		}
+	}
+	return false
+}
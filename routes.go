@@ -0,0 +1,179 @@
+package loggergo
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"strings"
+
+	"github.com/wasilak/loggergo/lib/types"
+)
+
+// route is a RouteConfig with its sink names resolved to indexes of the sink handlers.
+type route struct {
+	types.RouteConfig
+	sinks []int
+}
+
+// routeHandler sends each record to the sinks of the routes it matches, or to the sinks
+// no route names if it matches none.
+type routeHandler struct {
+	routes    []route
+	sinks     []slog.Handler
+	defaults  []int
+	needAttrs bool              // whether any route matches by Component or Attributes
+	attrs     map[string]string // logger attributes by dotted key
+	prefix    string            // open groups, each followed by a dot
+}
+
+// newRouteHandler creates a handler routing records to the handlers of the given sinks.
+// The routes must have been validated against the sinks.
+func newRouteHandler(routes []types.RouteConfig, sinks []types.SinkConfig, handlers []slog.Handler) *routeHandler {
+	h := &routeHandler{sinks: handlers, attrs: map[string]string{}}
+
+	index := make(map[string]int, len(sinks))
+	for i, sink := range sinks {
+		if sink.Name != "" {
+			index[sink.Name] = i
+		}
+	}
+
+	named := make([]bool, len(sinks))
+	for _, config := range routes {
+		r := route{RouteConfig: config}
+		for _, name := range config.Sinks {
+			if i, ok := index[name]; ok {
+				r.sinks = append(r.sinks, i)
+				named[i] = true
+			}
+		}
+		h.routes = append(h.routes, r)
+		h.needAttrs = h.needAttrs || config.Component != "" || len(config.Attributes) > 0
+	}
+
+	for i := range sinks {
+		if !named[i] {
+			h.defaults = append(h.defaults, i)
+		}
+	}
+	return h
+}
+
+// Enabled reports whether any sink handles records at the given level.
+func (h *routeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, sink := range h.sinks {
+		if sink.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle passes the record to the sinks of the routes it matches, or to the default sinks.
+func (h *routeHandler) Handle(ctx context.Context, record slog.Record) error {
+	var attrs map[string]string
+	if h.needAttrs {
+		attrs = maps.Clone(h.attrs)
+		record.Attrs(func(a slog.Attr) bool {
+			flattenAttr(attrs, h.prefix, a)
+			return true
+		})
+	}
+
+	selected := make([]bool, len(h.sinks))
+	matched := false
+	for i := range h.routes {
+		r := &h.routes[i]
+		if !r.matches(record, attrs) {
+			continue
+		}
+		matched = true
+		for _, sink := range r.sinks {
+			selected[sink] = true
+		}
+		if !r.Continue {
+			break
+		}
+	}
+	if !matched {
+		for _, sink := range h.defaults {
+			selected[sink] = true
+		}
+	}
+
+	var errs []error
+	for i, ok := range selected {
+		if ok && h.sinks[i].Enabled(ctx, record.Level) {
+			if err := h.sinks[i].Handle(ctx, record.Clone()); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WithAttrs returns a new handler with the given attributes added to every sink.
+func (h *routeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.sinks = make([]slog.Handler, len(h.sinks))
+	for i, sink := range h.sinks {
+		clone.sinks[i] = sink.WithAttrs(attrs)
+	}
+	if h.needAttrs {
+		clone.attrs = maps.Clone(h.attrs)
+		for _, a := range attrs {
+			flattenAttr(clone.attrs, h.prefix, a)
+		}
+	}
+	return &clone
+}
+
+// WithGroup returns a new handler with the given group name opened in every sink.
+func (h *routeHandler) WithGroup(name string) slog.Handler {
+	clone := *h
+	clone.sinks = make([]slog.Handler, len(h.sinks))
+	for i, sink := range h.sinks {
+		clone.sinks[i] = sink.WithGroup(name)
+	}
+	clone.prefix = h.prefix + name + "."
+	return &clone
+}
+
+// matches reports whether the record with the given flattened attributes matches all conditions of the route.
+func (r *route) matches(record slog.Record, attrs map[string]string) bool {
+	if r.Level != nil && record.Level < r.Level.Level() {
+		return false
+	}
+	if !strings.HasPrefix(record.Message, r.MessagePrefix) {
+		return false
+	}
+	if r.Component != "" {
+		component, ok := attrs[ComponentAttributeKey]
+		if !ok || (component != r.Component && !strings.HasPrefix(component, r.Component+".")) {
+			return false
+		}
+	}
+	for key, value := range r.Attributes {
+		if v, ok := attrs[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+// flattenAttr adds the string value of the attribute to attrs under its dotted key.
+// Attributes of groups are added under the group's key, those of groups without a key inline.
+func flattenAttr(attrs map[string]string, prefix string, a slog.Attr) {
+	value := a.Value.Resolve()
+	if value.Kind() != slog.KindGroup {
+		attrs[prefix+a.Key] = value.String()
+		return
+	}
+	if a.Key != "" {
+		prefix += a.Key + "."
+	}
+	for _, attr := range value.Group() {
+		flattenAttr(attrs, prefix, attr)
+	}
+}
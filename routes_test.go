@@ -0,0 +1,87 @@
+package loggergo
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// TestRoutes_ContextComponentAndDefault tests routing by a context value and a component, with the rest going to the unnamed sink
+func TestRoutes_ContextComponentAndDefault(t *testing.T) {
+	type contextKey string
+	audit, security, stdout := &syncBuffer{}, &syncBuffer{}, &syncBuffer{}
+	logger, err := New(context.Background(), Config{
+		Level:        slog.LevelInfo,
+		SetAsDefault: false,
+		ContextKeys:  []interface{}{contextKey("audit")},
+		Sinks: []SinkConfig{
+			{Name: "audit", Output: Types.OutputConsole, OutputStream: audit},
+			{Name: "security", Output: Types.OutputConsole, OutputStream: security},
+			{Name: "stdout", Output: Types.OutputConsole, OutputStream: stdout},
+		},
+		Routes: []RouteConfig{
+			{Attributes: map[string]string{"audit": "true"}, Sinks: []string{"audit"}},
+			{Component: "security", Sinks: []string{"security"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer logger.Shutdown()
+
+	logger.InfoContext(context.WithValue(context.Background(), contextKey("audit"), true), "user deleted")
+	logger.Named("security.auth").Warn("login failed")
+	logger.Named("securityscanner").Info("scan done")
+	logger.Info("request served")
+
+	if out := audit.String(); !strings.Contains(out, "user deleted") || strings.Count(out, "\n") != 1 {
+		t.Errorf("Expected only the audit record in the audit sink, got %q", out)
+	}
+	if out := security.String(); !strings.Contains(out, "login failed") || strings.Count(out, "\n") != 1 {
+		t.Errorf("Expected only the security record in the security sink, got %q", out)
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "scan done") || !strings.Contains(out, "request served") || strings.Count(out, "\n") != 2 {
+		t.Errorf("Expected the unrouted records in the default sink, got %q", out)
+	}
+}
+
+// TestRoutes_LevelPrefixGroupsAndContinue tests level, message prefix and grouped attribute conditions and Continue
+func TestRoutes_LevelPrefixGroupsAndContinue(t *testing.T) {
+	errorsSink, billing, all := &syncBuffer{}, &syncBuffer{}, &syncBuffer{}
+	logger, err := New(context.Background(), Config{
+		Level:        slog.LevelDebug,
+		SetAsDefault: false,
+		Sinks: []SinkConfig{
+			{Name: "errors", Output: Types.OutputConsole, OutputStream: errorsSink},
+			{Name: "billing", Output: Types.OutputConsole, OutputStream: billing},
+			{Name: "all", Output: Types.OutputConsole, OutputStream: all},
+		},
+		Routes: []RouteConfig{
+			{Level: slog.LevelError, Sinks: []string{"errors"}, Continue: true},
+			{MessagePrefix: "invoice", Attributes: map[string]string{"http.method": "POST"}, Sinks: []string{"billing"}},
+			{Sinks: []string{"all"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer logger.Shutdown()
+
+	http := logger.WithGroup("http")
+	http.Error("invoice failed", "method", "POST")
+	http.Info("invoice created", "method", "POST")
+	http.Info("invoice listed", "method", "GET")
+	logger.Debug("debug message")
+
+	if out := errorsSink.String(); !strings.Contains(out, "invoice failed") || strings.Count(out, "\n") != 1 {
+		t.Errorf("Expected only the error record in the errors sink, got %q", out)
+	}
+	if out := billing.String(); !strings.Contains(out, "invoice failed") || !strings.Contains(out, "invoice created") || strings.Count(out, "\n") != 2 {
+		t.Errorf("Expected the POST invoice records in the billing sink, got %q", out)
+	}
+	if out := all.String(); !strings.Contains(out, "invoice listed") || !strings.Contains(out, "debug message") || strings.Count(out, "\n") != 2 {
+		t.Errorf("Expected the remaining records in the catch-all sink, got %q", out)
+	}
+}
@@ -0,0 +1,228 @@
+package loggergo
+
+import (
+	"context"
+	"hash/fnv"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/wasilak/loggergo/lib/types"
+)
+
+// Defaults used when the corresponding SamplingConfig fields are zero.
+const (
+	defaultSamplingTick            = time.Second
+	defaultSamplingFirst           = 100
+	defaultSamplingThereafter      = 100
+	defaultSamplingSummaryInterval = time.Minute
+	samplingCounters               = 4096
+)
+
+// samplingCounter counts records with the same level and message hash within one tick.
+type samplingCounter struct {
+	resetAt atomic.Int64
+	count   atomic.Uint64
+}
+
+// incCheckReset increments the counter, starting a new tick if the current one has ended.
+func (c *samplingCounter) incCheckReset(t time.Time, tick time.Duration) uint64 {
+	tn := t.UnixNano()
+	resetAfter := c.resetAt.Load()
+	if resetAfter > tn {
+		return c.count.Add(1)
+	}
+
+	c.count.Store(1)
+
+	newResetAfter := tn + tick.Nanoseconds()
+	if !c.resetAt.CompareAndSwap(resetAfter, newResetAfter) {
+		// Another goroutine started the new tick first; count this record in it
+		return c.count.Add(1)
+	}
+	return 1
+}
+
+// samplingCore is the state shared by a SamplingHandler and the handlers derived from it.
+type samplingCore struct {
+	config   types.SamplingConfig
+	handler  slog.Handler
+	counters [samplingCounters]samplingCounter
+
+	// Sampled-out records since the last summary, by level: debug, info, warn
+	sampledOut [3]atomic.Uint64
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// SamplingHandler wraps an existing slog.Handler and drops repeated records.
+//
+// Within each Tick, the first First records with a given level and message are passed to
+// the wrapped handler, and after that only every Thereafter-th one. Records at slog.LevelError
+// and above are always passed. Counters are kept in a fixed-size table indexed by a hash of
+// level and message, so memory use does not grow with the number of distinct messages.
+//
+// Every SummaryInterval, and on Close, the number of sampled-out records is logged as a
+// single record at slog.LevelWarn with the message "log records sampled out".
+//
+// Thread Safety:
+//
+// SamplingHandler is safe for concurrent use. Handlers returned by WithAttrs and WithGroup
+// share the counters of the handler they were derived from.
+type SamplingHandler struct {
+	innerHandler slog.Handler
+	core         *samplingCore
+}
+
+// NewSamplingHandler creates a new SamplingHandler wrapping the given handler and starts
+// reporting sampled-out records.
+//
+// Example:
+//
+//	handler := loggergo.NewSamplingHandler(slog.NewJSONHandler(os.Stdout, nil), loggergo.SamplingConfig{
+//	    Enabled:    true,
+//	    First:      10,
+//	    Thereafter: 100,
+//	})
+//	defer handler.Close()
+//	logger := slog.New(handler)
+func NewSamplingHandler(handler slog.Handler, config types.SamplingConfig) *SamplingHandler {
+	if config.Tick <= 0 {
+		config.Tick = defaultSamplingTick
+	}
+	if config.First <= 0 {
+		config.First = defaultSamplingFirst
+	}
+	if config.Thereafter <= 0 {
+		config.Thereafter = defaultSamplingThereafter
+	}
+	if config.SummaryInterval <= 0 {
+		config.SummaryInterval = defaultSamplingSummaryInterval
+	}
+
+	core := &samplingCore{
+		config:  config,
+		handler: handler,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go core.reportLoop()
+
+	return &SamplingHandler{innerHandler: handler, core: core}
+}
+
+// Enabled reports whether the handler handles records at the given level.
+// It delegates the check to the inner handler.
+func (h *SamplingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.innerHandler.Enabled(ctx, level)
+}
+
+// Handle passes the record to the inner handler unless it is sampled out.
+func (h *SamplingHandler) Handle(ctx context.Context, record slog.Record) error {
+	if record.Level >= slog.LevelError || h.core.sample(record) {
+		return h.innerHandler.Handle(ctx, record)
+	}
+	return nil
+}
+
+// WithAttrs returns a new handler with the given attributes added.
+// The new handler shares the counters of h.
+func (h *SamplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &SamplingHandler{innerHandler: h.innerHandler.WithAttrs(attrs), core: h.core}
+}
+
+// WithGroup returns a new handler with the given group name.
+// The new handler shares the counters of h.
+func (h *SamplingHandler) WithGroup(name string) slog.Handler {
+	return &SamplingHandler{innerHandler: h.innerHandler.WithGroup(name), core: h.core}
+}
+
+// Close stops the periodic summary and logs a final one for records sampled out since the last.
+// Calling Close more than once is safe.
+func (h *SamplingHandler) Close() error {
+	h.core.closeOnce.Do(func() {
+		close(h.core.stop)
+		<-h.core.done
+	})
+	return nil
+}
+
+// sample reports whether the record should be kept, counting it as sampled out otherwise.
+func (c *samplingCore) sample(record slog.Record) bool {
+	t := record.Time
+	if t.IsZero() {
+		t = time.Now()
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(record.Level.String()))
+	hash.Write([]byte(record.Message))
+	counter := &c.counters[hash.Sum32()%samplingCounters]
+
+	n := counter.incCheckReset(t, c.config.Tick)
+	if n <= uint64(c.config.First) || (n-uint64(c.config.First))%uint64(c.config.Thereafter) == 0 {
+		return true
+	}
+
+	c.sampledOut[samplingLevelIndex(record.Level)].Add(1)
+	return false
+}
+
+// reportLoop logs a summary every SummaryInterval until Close, then logs a final one.
+func (c *samplingCore) reportLoop() {
+	defer close(c.done)
+
+	ticker := time.NewTicker(c.config.SummaryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.report()
+		case <-c.stop:
+			c.report()
+			return
+		}
+	}
+}
+
+// report logs the number of records sampled out since the previous report, if any.
+func (c *samplingCore) report() {
+	counts := [3]uint64{}
+	var total uint64
+	for i := range c.sampledOut {
+		counts[i] = c.sampledOut[i].Swap(0)
+		total += counts[i]
+	}
+	if total == 0 {
+		return
+	}
+
+	record := slog.NewRecord(time.Now(), slog.LevelWarn, "log records sampled out", 0)
+	record.AddAttrs(
+		slog.Uint64("sampled_out", total),
+		slog.Uint64("debug", counts[0]),
+		slog.Uint64("info", counts[1]),
+		slog.Uint64("warn", counts[2]),
+	)
+
+	ctx := context.Background()
+	if c.handler.Enabled(ctx, record.Level) {
+		c.handler.Handle(ctx, record)
+	}
+}
+
+// samplingLevelIndex maps a level below slog.LevelError to its sampledOut counter.
+func samplingLevelIndex(level slog.Level) int {
+	switch {
+	case level < slog.LevelInfo:
+		return 0
+	case level < slog.LevelWarn:
+		return 1
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,197 @@
+package loggergo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wasilak/loggergo/lib/types"
+)
+
+// TestSamplingHandler_FirstThenThereafter tests that the first N records are kept, then every Mth
+func TestSamplingHandler_FirstThenThereafter(t *testing.T) {
+	var buf bytes.Buffer
+	handler := NewSamplingHandler(slog.NewJSONHandler(&buf, nil), types.SamplingConfig{
+		Enabled:    true,
+		Tick:       time.Hour,
+		First:      3,
+		Thereafter: 5,
+	})
+	defer handler.Close()
+	logger := slog.New(handler)
+
+	for i := 0; i < 20; i++ {
+		logger.Info("repeated", "i", i)
+	}
+	logger.Info("other")
+
+	var kept []float64
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Failed to parse log output: %v", err)
+		}
+		if entry["msg"] == "repeated" {
+			kept = append(kept, entry["i"].(float64))
+		}
+	}
+
+	// Records 1-3 are kept, then the 8th, 13th and 18th
+	want := []float64{0, 1, 2, 7, 12, 17}
+	if len(kept) != len(want) {
+		t.Fatalf("Expected records %v, got %v", want, kept)
+	}
+	for i := range want {
+		if kept[i] != want[i] {
+			t.Fatalf("Expected records %v, got %v", want, kept)
+		}
+	}
+	if !strings.Contains(buf.String(), `"msg":"other"`) {
+		t.Error("Expected a different message to be counted separately")
+	}
+}
+
+// TestSamplingHandler_ErrorsNeverSampled tests that Error and above always pass
+func TestSamplingHandler_ErrorsNeverSampled(t *testing.T) {
+	var buf bytes.Buffer
+	handler := NewSamplingHandler(slog.NewTextHandler(&buf, nil), types.SamplingConfig{
+		Enabled:    true,
+		Tick:       time.Hour,
+		First:      1,
+		Thereafter: 1000,
+	})
+	defer handler.Close()
+	logger := slog.New(handler)
+
+	for i := 0; i < 10; i++ {
+		logger.Error("failure")
+	}
+
+	if got := strings.Count(buf.String(), "failure"); got != 10 {
+		t.Errorf("Expected all 10 error records, got %d", got)
+	}
+}
+
+// TestSamplingHandler_NewTick tests that counters restart when the tick ends
+func TestSamplingHandler_NewTick(t *testing.T) {
+	var buf bytes.Buffer
+	handler := NewSamplingHandler(slog.NewTextHandler(&buf, nil), types.SamplingConfig{
+		Enabled:    true,
+		Tick:       time.Second,
+		First:      2,
+		Thereafter: 1000,
+	})
+	defer handler.Close()
+
+	// The tick is measured from the record time, so records can be placed in consecutive windows
+	start := time.Now()
+	for window := 0; window < 3; window++ {
+		for i := 0; i < 5; i++ {
+			record := slog.NewRecord(start.Add(time.Duration(window)*time.Second), slog.LevelInfo, "tick", 0)
+			handler.Handle(context.Background(), record)
+		}
+	}
+
+	if got := strings.Count(buf.String(), "msg=tick"); got != 6 {
+		t.Errorf("Expected 2 records per tick (6 total), got %d", got)
+	}
+}
+
+// TestSamplingHandler_Summary tests that sampled-out counts are reported on Close
+func TestSamplingHandler_Summary(t *testing.T) {
+	var buf bytes.Buffer
+	handler := NewSamplingHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}), types.SamplingConfig{
+		Enabled:    true,
+		Tick:       time.Hour,
+		First:      1,
+		Thereafter: 1000,
+	})
+	logger := slog.New(handler).With("component", "test")
+
+	for i := 0; i < 5; i++ {
+		logger.Debug("debug message")
+		logger.Info("info message")
+	}
+	logger.Warn("warn message")
+
+	handler.Close()
+	handler.Close()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	var summary map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &summary); err != nil {
+		t.Fatalf("Failed to parse log output: %v", err)
+	}
+
+	if summary["msg"] != "log records sampled out" || summary["level"] != "WARN" {
+		t.Fatalf("Expected summary record, got %v", summary)
+	}
+	if summary["sampled_out"] != float64(8) || summary["debug"] != float64(4) || summary["info"] != float64(4) || summary["warn"] != float64(0) {
+		t.Errorf("Expected 4 debug and 4 info records sampled out, got %v", summary)
+	}
+	if _, ok := summary["component"]; ok {
+		t.Error("Expected summary record not to carry attributes of derived loggers")
+	}
+	if strings.Count(buf.String(), "log records sampled out") != 1 {
+		t.Error("Expected exactly one summary record")
+	}
+}
+
+// TestSamplingHandler_PeriodicSummary tests that the summary is logged every SummaryInterval
+func TestSamplingHandler_PeriodicSummary(t *testing.T) {
+	var buf syncBuffer
+	handler := NewSamplingHandler(slog.NewTextHandler(&buf, nil), types.SamplingConfig{
+		Enabled:         true,
+		Tick:            time.Hour,
+		First:           1,
+		Thereafter:      1000,
+		SummaryInterval: 10 * time.Millisecond,
+	})
+	defer handler.Close()
+	logger := slog.New(handler)
+
+	logger.Info("repeated")
+	logger.Info("repeated")
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !strings.Contains(buf.String(), "sampled_out=1") {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected periodic summary, got %q", buf.String())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// TestInit_Sampling tests that Init applies sampling to the handler chain and reports on Shutdown
+func TestInit_Sampling(t *testing.T) {
+	var buf syncBuffer
+
+	_, logger, err := Init(context.Background(), types.Config{
+		Level:        slog.LevelInfo,
+		Output:       types.OutputConsole,
+		OutputStream: &buf,
+		Sampling:     types.SamplingConfig{Enabled: true, Tick: time.Hour, First: 2, Thereafter: 1000},
+	})
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		logger.Info("sampled message")
+	}
+
+	if err := Shutdown(); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	if got := strings.Count(buf.String(), "sampled message"); got != 2 {
+		t.Errorf("Expected 2 records to be kept, got %d", got)
+	}
+	if !strings.Contains(buf.String(), "log records sampled out") {
+		t.Error("Expected summary record on Shutdown")
+	}
+}
@@ -0,0 +1,142 @@
+package loggergo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"strconv"
+	"strings"
+
+	slogmulti "github.com/samber/slog-multi"
+	"github.com/wasilak/loggergo/lib"
+	"github.com/wasilak/loggergo/lib/types"
+)
+
+// buildSinks creates the handler of every sink in the manager's config.Sinks and combines them with slogmulti.Fanout,
+// or with a routeHandler if config.Routes is set.
+// Each sink is built by buildOutput with its own manager, which is shut down by the manager's Shutdown,
+// and adds its OTEL output with failover to state. Errors are returned as *types.InitError naming the sink.
+func buildSinks(ctx context.Context, manager *lib.ConfigManager, opts slog.HandlerOptions, state *handlerState) (context.Context, slog.Handler, error) {
+	cfg := manager.GetConfig()
+
+	handlers := make([]slog.Handler, 0, len(cfg.Sinks))
+	for i, sink := range cfg.Sinks {
+		name := sink.Name
+		if name == "" {
+			name = strconv.Itoa(i)
+		}
+
+		// Registered before the sink is built, so whatever it opened is closed even if it fails
+		sinkManager := lib.NewConfigManager()
+		sinkManager.SetConfig(sink.OutputConfig(cfg))
+		manager.RegisterCleanup(sinkManager.Shutdown)
+
+		var handler slog.Handler
+		var err error
+		ctx, handler, err = buildOutput(ctx, sinkManager, opts, state, name)
+		if err != nil {
+			var initErr *types.InitError
+			if errors.As(err, &initErr) {
+				initErr.Cause = fmt.Errorf("sink %s: %w", name, initErr.Cause)
+				initErr.Config = cfg
+			}
+			return ctx, nil, err
+		}
+
+		handlers = append(handlers, newSinkHandler(handler, sink.Level, sink.Attributes))
+	}
+
+	if len(cfg.Routes) > 0 {
+		return ctx, newRouteHandler(cfg.Routes, cfg.Sinks, handlers), nil
+	}
+	return ctx, slogmulti.Fanout(handlers...), nil
+}
+
+// sinkHandler applies the minimum level and attribute filter of a sink to its handler.
+type sinkHandler struct {
+	innerHandler slog.Handler
+	level        slog.Leveler
+	include      []string
+	exclude      []string
+}
+
+// newSinkHandler wraps handler with the sink's level and attribute filter.
+// Key patterns are lowercased, as they are matched case-insensitively.
+func newSinkHandler(handler slog.Handler, level slog.Leveler, filter types.AttributeFilter) *sinkHandler {
+	h := &sinkHandler{innerHandler: handler, level: level}
+	for _, key := range filter.Include {
+		h.include = append(h.include, strings.ToLower(key))
+	}
+	for _, key := range filter.Exclude {
+		h.exclude = append(h.exclude, strings.ToLower(key))
+	}
+	return h
+}
+
+// Enabled reports whether the level is at or above the sink's level and the inner handler is enabled.
+func (h *sinkHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	if h.level != nil && level < h.level.Level() {
+		return false
+	}
+	return h.innerHandler.Enabled(ctx, level)
+}
+
+// Handle passes the record with the filtered attributes to the inner handler if it is at or above the sink's level.
+func (h *sinkHandler) Handle(ctx context.Context, record slog.Record) error {
+	if h.level != nil && record.Level < h.level.Level() {
+		return nil
+	}
+	if len(h.include) == 0 && len(h.exclude) == 0 {
+		return h.innerHandler.Handle(ctx, record)
+	}
+
+	filtered := slog.NewRecord(record.Time, record.Level, record.Message, record.PC)
+	record.Attrs(func(a slog.Attr) bool {
+		if h.keep(a.Key) {
+			filtered.AddAttrs(a)
+		}
+		return true
+	})
+	return h.innerHandler.Handle(ctx, filtered)
+}
+
+// WithAttrs returns a new handler with the filtered attributes added.
+func (h *sinkHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	filtered := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		if h.keep(a.Key) {
+			filtered = append(filtered, a)
+		}
+	}
+	clone := *h
+	clone.innerHandler = h.innerHandler.WithAttrs(filtered)
+	return &clone
+}
+
+// WithGroup returns a new handler with the given group name.
+func (h *sinkHandler) WithGroup(name string) slog.Handler {
+	clone := *h
+	clone.innerHandler = h.innerHandler.WithGroup(name)
+	return &clone
+}
+
+// keep reports whether an attribute with the given key passes the attribute filter.
+func (h *sinkHandler) keep(key string) bool {
+	key = strings.ToLower(key)
+	if len(h.include) > 0 && !matchesAnyKey(h.include, key) {
+		return false
+	}
+	return !matchesAnyKey(h.exclude, key)
+}
+
+// matchesAnyKey reports whether the lowercased key matches any of the glob patterns.
+func matchesAnyKey(patterns []string, key string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, key); ok {
+			return true
+		}
+	}
+	return false
+}
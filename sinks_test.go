@@ -0,0 +1,124 @@
+package loggergo
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wasilak/loggergo/lib/types"
+)
+
+// TestSinks_LevelsAndFormats tests that each sink applies its own level, format and writer
+func TestSinks_LevelsAndFormats(t *testing.T) {
+	debugSink, warnSink := &syncBuffer{}, &syncBuffer{}
+	logger, err := New(context.Background(), Config{
+		Level:        slog.LevelDebug,
+		SetAsDefault: false,
+		Sinks: []SinkConfig{
+			{Output: Types.OutputConsole, Format: Types.LogFormatText, OutputStream: debugSink, Level: slog.LevelDebug},
+			{Output: Types.OutputConsole, Format: Types.LogFormatJSON, OutputStream: warnSink, Level: slog.LevelWarn},
+		},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer logger.Shutdown()
+
+	logger.Debug("debug message")
+	logger.Warn("warn message", "user", "alice")
+
+	if out := debugSink.String(); !strings.Contains(out, "msg=\"debug message\"") || !strings.Contains(out, "msg=\"warn message\"") {
+		t.Errorf("Expected both records as text in the debug sink, got %q", out)
+	}
+	out := warnSink.String()
+	if strings.Contains(out, "debug message") {
+		t.Errorf("Expected the warn sink to drop debug records, got %q", out)
+	}
+	if !strings.Contains(out, `"msg":"warn message"`) || !strings.Contains(out, `"user":"alice"`) {
+		t.Errorf("Expected the warn record as JSON in the warn sink, got %q", out)
+	}
+}
+
+// TestSinks_AttributeFilter tests that a sink's attribute filter applies to record and logger attributes
+func TestSinks_AttributeFilter(t *testing.T) {
+	filtered, unfiltered := &syncBuffer{}, &syncBuffer{}
+	logger, err := New(context.Background(), Config{
+		Level:        slog.LevelInfo,
+		SetAsDefault: false,
+		Sinks: []SinkConfig{
+			{Output: Types.OutputConsole, OutputStream: filtered, Attributes: AttributeFilter{Include: []string{"user", "http.*"}, Exclude: []string{"HTTP.Body"}}},
+			{Output: Types.OutputConsole, OutputStream: unfiltered},
+		},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer logger.Shutdown()
+
+	logger.With("request_id", "r1", "user", "alice").Info("request", "http.method", "GET", "http.body", "{}", "payload", "large")
+
+	out := filtered.String()
+	for _, want := range []string{`"user":"alice"`, `"http.method":"GET"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %s in the filtered sink, got %q", want, out)
+		}
+	}
+	for _, unwanted := range []string{"request_id", "http.body", "payload"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("Expected %s to be filtered out, got %q", unwanted, out)
+		}
+	}
+	if !strings.Contains(unfiltered.String(), `"payload":"large"`) {
+		t.Errorf("Expected all attributes in the unfiltered sink, got %q", unfiltered.String())
+	}
+}
+
+// TestSinks_FileSink tests a file sink next to a console sink and that Shutdown closes it
+func TestSinks_FileSink(t *testing.T) {
+	console := &syncBuffer{}
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger, err := New(context.Background(), Config{
+		Level:        slog.LevelInfo,
+		SetAsDefault: false,
+		Sinks: []SinkConfig{
+			{Name: "terminal", Output: Types.OutputConsole, OutputStream: console, DevMode: true},
+			{Name: "file", Output: Types.OutputFile, Format: Types.LogFormatJSON, File: FileConfig{Path: path}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	logger.Info("to both sinks")
+	if err := logger.Shutdown(); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(data), `"msg":"to both sinks"`) {
+		t.Errorf("Expected the record as JSON in the file, got %q, %v", data, err)
+	}
+	if !strings.Contains(console.String(), "to both sinks") {
+		t.Errorf("Expected the record in the console sink, got %q", console.String())
+	}
+}
+
+// TestSinks_InvalidSink tests that sink errors are reported with the sink's field path
+func TestSinks_InvalidSink(t *testing.T) {
+	_, err := New(context.Background(), Config{
+		Level: slog.LevelInfo,
+		Sinks: []SinkConfig{
+			{Output: Types.OutputConsole},
+			{Output: Types.OutputFile},
+		},
+	})
+
+	var initErr *types.InitError
+	if !errors.As(err, &initErr) || initErr.Stage != "validation" || !strings.Contains(err.Error(), "File.Path") {
+		t.Errorf("Expected validation error for the file sink, got %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package loggergo
+
+import (
+	"context"
+
+	"github.com/wasilak/loggergo/lib"
+	"github.com/wasilak/loggergo/lib/outputs"
+	"github.com/wasilak/loggergo/lib/types"
+)
+
+// SpoolStats represents the queue depth and dropped bytes of the disk spool of the OTLP exporter.
+// It is an alias for outputs.SpoolStats and is exported for external usage.
+type SpoolStats = outputs.SpoolStats
+
+// buildSpool opens the spool described by the manager's config.Spool, if it is set, and returns
+// the exporter wrapper to pass to modes.OtelMode. The spool is added to state and closed by the
+// manager's Shutdown. Errors are returned as *types.InitError.
+func buildSpool(ctx context.Context, manager *lib.ConfigManager, state *handlerState) ([]outputs.ExporterWrapper, error) {
+	cfg := manager.GetConfig()
+	if cfg.Spool.Dir == "" {
+		return nil, nil
+	}
+
+	spool, err := outputs.NewSpool(ctx, cfg)
+	if err != nil {
+		return nil, &types.InitError{
+			Stage:  "handler_creation",
+			Cause:  err,
+			Config: cfg,
+		}
+	}
+	manager.RegisterCleanup(spool.Close)
+	state.spools = append(state.spools, spool)
+
+	return []outputs.ExporterWrapper{spool.WrapExporter}, nil
+}
+
+// SpoolStats returns the number and size of the records waiting in the disk spool of the
+// OTLP exporter and the size of the records it dropped. It is zero if Config.Spool is not set.
+func (l *Logger) SpoolStats() SpoolStats {
+	var stats SpoolStats
+	if state := l.state.Load(); state != nil {
+		for _, spool := range state.spools {
+			spoolStats := spool.Stats()
+			stats.Records += spoolStats.Records
+			stats.Bytes += spoolStats.Bytes
+			stats.Segments += spoolStats.Segments
+			stats.DroppedBytes += spoolStats.DroppedBytes
+		}
+	}
+	return stats
+}
+
+// GetSpoolStats returns the disk spool metrics of the logger created by Init.
+// See Logger.SpoolStats. It is zero if Init has not been called.
+func GetSpoolStats() SpoolStats {
+	if logger := globalLogger.Load(); logger != nil {
+		return logger.SpoolStats()
+	}
+	return SpoolStats{}
+}
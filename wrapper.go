@@ -32,43 +32,155 @@ import (
 var Types = struct {
 	AllDevFlavors       func() []types.DevFlavor
 	DevFlavorFromString func(string) types.DevFlavor
+	ParseDevFlavor      func(string) (types.DevFlavor, error)
 	DevFlavorTint       types.DevFlavor
 	DevFlavorSlogor     types.DevFlavor
 	DevFlavorDevslog    types.DevFlavor
 
 	AllLogFormats       func() []types.LogFormat
 	LogFormatFromString func(string) types.LogFormat
+	ParseLogFormat      func(string) (types.LogFormat, error)
 	LogFormatText       types.LogFormat
 	LogFormatJSON       types.LogFormat
 	LogFormatOtel       types.LogFormat
 
-	AllLogLevels       func() []slog.Level
-	LogLevelFromString func(string) slog.Level
+	AllLogLevels         func() []slog.Level
+	LogLevelFromString   func(string) slog.Level
+	ParseLogLevel        func(string) (slog.Level, error)
+	ParseComponentLevels func(string) (map[string]slog.Level, error)
 
 	AllOutputTypes       func() []types.OutputType
 	OutputTypeFromString func(string) types.OutputType
+	ParseOutputType      func(string) (types.OutputType, error)
 	OutputConsole        types.OutputType
 	OutputOtel           types.OutputType
 	OutputFanout         types.OutputType
+	OutputFile           types.OutputType
+	OutputSyslog         types.OutputType
+	OutputJournald       types.OutputType
+
+	AllSyslogFormats       func() []types.SyslogFormat
+	SyslogFormatFromString func(string) types.SyslogFormat
+	ParseSyslogFormat      func(string) (types.SyslogFormat, error)
+	SyslogRFC5424          types.SyslogFormat
+	SyslogRFC3164          types.SyslogFormat
+
+	AllOverflowPolicies      func() []types.OverflowPolicy
+	OverflowPolicyFromString func(string) types.OverflowPolicy
+	ParseOverflowPolicy      func(string) (types.OverflowPolicy, error)
+	OverflowBlock            types.OverflowPolicy
+	OverflowDropNewest       types.OverflowPolicy
+	OverflowDropOldest       types.OverflowPolicy
+	OverflowDropBelowLevel   types.OverflowPolicy
+
+	AllRedactionStrategies      func() []types.RedactionStrategy
+	RedactionStrategyFromString func(string) types.RedactionStrategy
+	ParseRedactionStrategy      func(string) (types.RedactionStrategy, error)
+	RedactMask                  types.RedactionStrategy
+	RedactDrop                  types.RedactionStrategy
+	RedactHash                  types.RedactionStrategy
+
+	AllOTLPProtocols       func() []types.OTLPProtocol
+	OTLPProtocolFromString func(string) types.OTLPProtocol
+	ParseOTLPProtocol      func(string) (types.OTLPProtocol, error)
+	OTLPProtocolGRPC       types.OTLPProtocol
+	OTLPProtocolHTTP       types.OTLPProtocol
+
+	AllOTLPCompressions       func() []types.OTLPCompression
+	OTLPCompressionFromString func(string) types.OTLPCompression
+	ParseOTLPCompression      func(string) (types.OTLPCompression, error)
+	OTLPCompressionNone       types.OTLPCompression
+	OTLPCompressionGzip       types.OTLPCompression
+
+	AllResourceDetectors       func() []types.ResourceDetector
+	ResourceDetectorFromString func(string) types.ResourceDetector
+	ParseResourceDetector      func(string) (types.ResourceDetector, error)
+	ResourceDetectorHost       types.ResourceDetector
+	ResourceDetectorProcess    types.ResourceDetector
+	ResourceDetectorOS         types.ResourceDetector
+	ResourceDetectorContainer  types.ResourceDetector
+
+	AllBreakerStates       func() []types.BreakerState
+	BreakerStateFromString func(string) types.BreakerState
+	ParseBreakerState      func(string) (types.BreakerState, error)
+	BreakerClosed          types.BreakerState
+	BreakerOpen            types.BreakerState
+	BreakerHalfOpen        types.BreakerState
 }{
 	AllDevFlavors:       types.AllDevFlavors,
 	DevFlavorFromString: types.DevFlavorFromString,
+	ParseDevFlavor:      types.ParseDevFlavor,
 	DevFlavorTint:       types.DevFlavorTint,
 	DevFlavorSlogor:     types.DevFlavorSlogor,
 	DevFlavorDevslog:    types.DevFlavorDevslog,
 
 	AllLogFormats:       types.AllLogFormats,
 	LogFormatFromString: types.LogFormatFromString,
+	ParseLogFormat:      types.ParseLogFormat,
 	LogFormatText:       types.LogFormatText,
 	LogFormatJSON:       types.LogFormatJSON,
 	LogFormatOtel:       types.LogFormatOtel,
 
-	AllLogLevels:       types.AllLogLevels,
-	LogLevelFromString: types.LogLevelFromString,
+	AllLogLevels:         types.AllLogLevels,
+	LogLevelFromString:   types.LogLevelFromString,
+	ParseLogLevel:        types.ParseLogLevel,
+	ParseComponentLevels: types.ParseComponentLevels,
 
 	AllOutputTypes:       types.AllOutputTypes,
 	OutputTypeFromString: types.OutputTypeFromString,
+	ParseOutputType:      types.ParseOutputType,
 	OutputConsole:        types.OutputConsole,
 	OutputOtel:           types.OutputOtel,
 	OutputFanout:         types.OutputFanout,
+	OutputFile:           types.OutputFile,
+	OutputSyslog:         types.OutputSyslog,
+	OutputJournald:       types.OutputJournald,
+
+	AllSyslogFormats:       types.AllSyslogFormats,
+	SyslogFormatFromString: types.SyslogFormatFromString,
+	ParseSyslogFormat:      types.ParseSyslogFormat,
+	SyslogRFC5424:          types.SyslogRFC5424,
+	SyslogRFC3164:          types.SyslogRFC3164,
+
+	AllOverflowPolicies:      types.AllOverflowPolicies,
+	OverflowPolicyFromString: types.OverflowPolicyFromString,
+	ParseOverflowPolicy:      types.ParseOverflowPolicy,
+	OverflowBlock:            types.OverflowBlock,
+	OverflowDropNewest:       types.OverflowDropNewest,
+	OverflowDropOldest:       types.OverflowDropOldest,
+	OverflowDropBelowLevel:   types.OverflowDropBelowLevel,
+
+	AllRedactionStrategies:      types.AllRedactionStrategies,
+	RedactionStrategyFromString: types.RedactionStrategyFromString,
+	ParseRedactionStrategy:      types.ParseRedactionStrategy,
+	RedactMask:                  types.RedactMask,
+	RedactDrop:                  types.RedactDrop,
+	RedactHash:                  types.RedactHash,
+
+	AllOTLPProtocols:       types.AllOTLPProtocols,
+	OTLPProtocolFromString: types.OTLPProtocolFromString,
+	ParseOTLPProtocol:      types.ParseOTLPProtocol,
+	OTLPProtocolGRPC:       types.OTLPProtocolGRPC,
+	OTLPProtocolHTTP:       types.OTLPProtocolHTTP,
+
+	AllOTLPCompressions:       types.AllOTLPCompressions,
+	OTLPCompressionFromString: types.OTLPCompressionFromString,
+	ParseOTLPCompression:      types.ParseOTLPCompression,
+	OTLPCompressionNone:       types.OTLPCompressionNone,
+	OTLPCompressionGzip:       types.OTLPCompressionGzip,
+
+	AllResourceDetectors:       types.AllResourceDetectors,
+	ResourceDetectorFromString: types.ResourceDetectorFromString,
+	ParseResourceDetector:      types.ParseResourceDetector,
+	ResourceDetectorHost:       types.ResourceDetectorHost,
+	ResourceDetectorProcess:    types.ResourceDetectorProcess,
+	ResourceDetectorOS:         types.ResourceDetectorOS,
+	ResourceDetectorContainer:  types.ResourceDetectorContainer,
+
+	AllBreakerStates:       types.AllBreakerStates,
+	BreakerStateFromString: types.BreakerStateFromString,
+	ParseBreakerState:      types.ParseBreakerState,
+	BreakerClosed:          types.BreakerClosed,
+	BreakerOpen:            types.BreakerOpen,
+	BreakerHalfOpen:        types.BreakerHalfOpen,
 }